@@ -1,11 +1,12 @@
 package builder
 
 import (
+	"database/sql"
 	"fmt"
 
 	"github.com/otkinlife/crud-generator/database"
+	"github.com/otkinlife/crud-generator/dialect"
 	"github.com/otkinlife/crud-generator/generator"
-	"github.com/otkinlife/crud-generator/parser"
 	"github.com/otkinlife/crud-generator/services"
 	"github.com/otkinlife/crud-generator/types"
 	"github.com/otkinlife/crud-generator/validator"
@@ -43,18 +44,23 @@ func NewCRUDBuilderFromConfig(configID uint) (*CRUDBuilder, error) {
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
 
-	// 解析建表语句
-	parser := parser.NewPostgreSQLParser()
-	schema, err := parser.ParseCreateStatement(config.CreateStatement)
+	// 解析建表语句，未配置时读取数据库中的实时表结构
+	schema, err := services.LoadTableSchema(db, config.TableName, config.CreateStatement)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse create statement: %w", err)
+		return nil, err
+	}
+
+	// 按连接的数据库类型选择 SQL 方言
+	sqlDialect, err := dialect.FromDB(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select SQL dialect: %w", err)
 	}
 
 	// 创建生成器和验证器
-	queryGen := generator.NewQueryGenerator(schema, config)
-	crudGen := generator.NewCRUDGenerator(schema, config)
+	queryGen := generator.NewQueryGenerator(schema, config, sqlDialect)
+	crudGen := generator.NewCRUDGenerator(schema, config, sqlDialect)
 	validator := validator.NewValidator(config)
-	dictProvider := generator.NewDictProvider(db)
+	dictProvider := generator.NewDictProvider(db, sqlDialect)
 
 	return &CRUDBuilder{
 		configID:      configID,
@@ -97,34 +103,15 @@ func (b *CRUDBuilder) Query(params types.QueryParams) (*types.QueryResult, error
 		return nil, fmt.Errorf("failed to count records: %w", err)
 	}
 
-	var data []map[string]interface{}
 	rows, err := db.Raw(query, args...).Rows()
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
 	defer rows.Close()
 
-	columns, err := rows.Columns()
+	data, err := scanRows(rows)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get columns: %w", err)
-	}
-
-	for rows.Next() {
-		values := make([]interface{}, len(columns))
-		valuePtrs := make([]interface{}, len(columns))
-		for i := range values {
-			valuePtrs[i] = &values[i]
-		}
-
-		if err := rows.Scan(valuePtrs...); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
-
-		row := make(map[string]interface{})
-		for i, col := range columns {
-			row[col] = values[i]
-		}
-		data = append(data, row)
+		return nil, err
 	}
 
 	totalPages := int((total + int64(params.PageSize) - 1) / int64(params.PageSize))
@@ -138,6 +125,41 @@ func (b *CRUDBuilder) Query(params types.QueryParams) (*types.QueryResult, error
 	}, nil
 }
 
+// Get 按主键读取单条记录，记录不存在时返回 generator.ErrRecordNotFound
+func (b *CRUDBuilder) Get(id interface{}) (map[string]interface{}, error) {
+	// 获取数据库连接
+	tableConfig, err := b.configService.GetConfigByID(b.configID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table configuration: %w", err)
+	}
+
+	db, err := database.GetDatabaseManager().GetConnection(tableConfig.ConnectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query, args, err := b.crudGen.GenerateSelect(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate select query: %w", err)
+	}
+
+	rows, err := db.Raw(query, args...).Rows()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	data, err := scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w in table '%s'", generator.ErrRecordNotFound, b.config.TableName)
+	}
+
+	return data[0], nil
+}
+
 func (b *CRUDBuilder) Create(data map[string]interface{}) (*types.CreateResult, error) {
 	validationErrors := b.validator.ValidateCreate(data)
 	if len(validationErrors) > 0 {
@@ -164,8 +186,28 @@ func (b *CRUDBuilder) Create(data map[string]interface{}) (*types.CreateResult,
 	}
 
 	var insertedID interface{}
-	if err := db.Raw(query, args...).Scan(&insertedID).Error; err != nil {
-		return nil, fmt.Errorf("failed to execute insert: %w", err)
+	switch b.crudGen.Dialect().InsertIDStrategy() {
+	case dialect.InsertIDLastInsertID:
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+		}
+		result, err := sqlDB.Exec(query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute insert: %w", err)
+		}
+		if id, err := result.LastInsertId(); err == nil {
+			insertedID = id
+		}
+	default:
+		if err := db.Raw(query, args...).Scan(&insertedID).Error; err != nil {
+			return nil, fmt.Errorf("failed to execute insert: %w", err)
+		}
+	}
+
+	// 联合主键无法通过 RETURNING 或自增 ID 返回，使用插入数据中的主键值
+	if primaryKey := b.crudGen.PrimaryKey(); len(primaryKey) > 1 {
+		insertedID = generator.RecordKeyOf(primaryKey, data)
 	}
 
 	return &types.CreateResult{
@@ -266,19 +308,58 @@ func (b *CRUDBuilder) RefreshConfig() error {
 		return fmt.Errorf("failed to convert configuration: %w", err)
 	}
 
-	// 重新解析建表语句
-	parser := parser.NewPostgreSQLParser()
-	schema, err := parser.ParseCreateStatement(config.CreateStatement)
+	db, err := database.GetDatabaseManager().GetConnection(tableConfig.ConnectionID)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	// 重新解析建表语句，未配置时读取数据库中的实时表结构
+	schema, err := services.LoadTableSchema(db, config.TableName, config.CreateStatement)
 	if err != nil {
-		return fmt.Errorf("failed to parse create statement: %w", err)
+		return err
+	}
+
+	sqlDialect, err := dialect.FromDB(db)
+	if err != nil {
+		return fmt.Errorf("failed to select SQL dialect: %w", err)
 	}
 
 	// 更新组件
 	b.config = config
 	b.schema = schema
-	b.queryGen = generator.NewQueryGenerator(schema, config)
-	b.crudGen = generator.NewCRUDGenerator(schema, config)
+	b.queryGen = generator.NewQueryGenerator(schema, config, sqlDialect)
+	b.crudGen = generator.NewCRUDGenerator(schema, config, sqlDialect)
 	b.validator = validator.NewValidator(config)
+	b.dictProvider = generator.NewDictProvider(db, sqlDialect)
 
 	return nil
 }
+
+// scanRows 将查询结果读取为列名到值的映射
+func scanRows(rows *sql.Rows) ([]map[string]interface{}, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+
+	var data []map[string]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		valuePtrs := make([]interface{}, len(columns))
+		for i := range values {
+			valuePtrs[i] = &values[i]
+		}
+
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		row := make(map[string]interface{})
+		for i, col := range columns {
+			row[col] = values[i]
+		}
+		data = append(data, row)
+	}
+
+	return data, nil
+}
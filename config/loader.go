@@ -0,0 +1,62 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/otkinlife/crud-generator/types"
+)
+
+// Loader 从 JSON 读取表配置，不连接数据库
+type Loader struct {
+	validator *validator.Validate
+}
+
+func NewLoader() *Loader {
+	return &Loader{
+		validator: validator.New(),
+	}
+}
+
+// LoadFromFile 读取并校验 JSON 配置文件
+func (l *Loader) LoadFromFile(path string) (*types.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return l.LoadFromBytes(data)
+}
+
+// LoadFromBytes 解析并校验 JSON 配置
+func (l *Loader) LoadFromBytes(data []byte) (*types.Config, error) {
+	var config types.Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if err := l.Validate(&config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate 校验必填项；没有数据库连接时无法读取实时表结构，建表语句必填
+func (l *Loader) Validate(config *types.Config) error {
+	if err := l.validator.Struct(config); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	if strings.TrimSpace(config.CreateStatement) == "" {
+		return fmt.Errorf("create_statement is required")
+	}
+
+	if config.QueryConfig != nil {
+		for i, field := range config.QueryConfig.SearchFields {
+			if strings.TrimSpace(field.Field) == "" {
+				return fmt.Errorf("search field %d has no field name", i+1)
+			}
+		}
+	}
+	return nil
+}
@@ -12,9 +12,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/otkinlife/crud-generator/database"
+	"github.com/otkinlife/crud-generator/generator"
 	"github.com/otkinlife/crud-generator/models"
+	"github.com/otkinlife/crud-generator/services"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -38,6 +41,11 @@ type Config struct {
 
 	// Middleware configuration
 	MiddlewareConfig *MiddlewareConfig `json:"-"` // Not serialized, only for runtime
+
+	// ViewOwner identifies the user of a request as the owner of saved views. By default the
+	// user_id set by middleware.JWTAuth is used. Requests without an owner only see and save
+	// shared views.
+	ViewOwner func(c *gin.Context) string `json:"-"`
 }
 
 // MiddlewareBuilder provides fluent API for middleware configuration
@@ -94,7 +102,7 @@ func (mb *MiddlewareBuilder) Build() *MiddlewareConfig {
 
 // DatabaseConnection represents a database connection configuration
 type DatabaseConnection struct {
-	Type         string `json:"type"` // postgresql, mysql
+	Type         string `json:"type"` // postgresql, mysql, sqlite
 	Host         string `json:"host"`
 	Port         int    `json:"port"`
 	Database     string `json:"database"`
@@ -114,12 +122,28 @@ type TableConfig struct {
 
 	// SQL Schema
 	CreateStatement string `json:"create_statement"`
+	// PrimaryKey lists the key columns separated by commas, e.g. "tenant_id,order_no".
+	// When empty the primary key of the table schema is used.
+	PrimaryKey string `json:"primary_key"`
 
 	// Query configuration
 	QueryPagination     bool   `json:"query_pagination"`
 	QueryDisplayFields  string `json:"query_display_fields"`
 	QuerySearchFields   string `json:"query_search_fields"`
 	QuerySortableFields string `json:"query_sortable_fields"`
+	// QueryDefaultSort is applied when a list has no sort, e.g. "status,-created_at"
+	QueryDefaultSort string `json:"query_default_sort"`
+	// QueryFilterFields is a JSON list of the filter operators allowed per field,
+	// e.g. [{"field": "age", "operators": ["gte", "lte", "between"]}]
+	QueryFilterFields string `json:"query_filter_fields"`
+	// QueryHiddenFields is a JSON list of columns that are never returned, e.g. ["password_hash"]
+	QueryHiddenFields string `json:"query_hidden_fields"`
+	// QueryRelations is a JSON list of child collections shown under a record,
+	// e.g. [{"name": "items", "config": "order_items", "foreign_key": "order_id"}]
+	QueryRelations string `json:"query_relations"`
+	// QueryAggregate whitelists the group-by columns and the metrics per column of Aggregate,
+	// e.g. {"group_by": ["status"], "metrics": [{"field": "amount", "functions": ["sum", "avg"]}]}
+	QueryAggregate string `json:"query_aggregate"`
 
 	// Create/Update configuration
 	CreateCreatableFields string `json:"create_creatable_fields"`
@@ -263,6 +287,10 @@ func NewWithSQLDB(sqlDB *sql.DB, dbType, connectionName string, config *Config)
 		dialector = mysql.New(mysql.Config{
 			Conn: sqlDB,
 		})
+	case "sqlite":
+		dialector = sqlite.New(sqlite.Config{
+			Conn: sqlDB,
+		})
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", dbType)
 	}
@@ -341,6 +369,23 @@ func (cg *CRUDGenerator) DeleteTableConfig(id uint) error {
 	return cg.services.ConfigService.DeleteConfig(id)
 }
 
+// GetTableInfo reads the live structure of a table from the given connection
+func (cg *CRUDGenerator) GetTableInfo(connectionID, tableName string) (*TableInfo, error) {
+	return cg.services.ConfigService.GetTableInfo(connectionID, tableName)
+}
+
+// ScaffoldTableConfig generates a default table configuration from the live table.
+// The config is returned for review and must be saved with AddTableConfig.
+func (cg *CRUDGenerator) ScaffoldTableConfig(connectionID, tableName string) (*TableConfig, error) {
+	return cg.services.ConfigService.ScaffoldConfig(connectionID, tableName, "")
+}
+
+// DetectDrift reports configured columns missing from the live table, type mismatches
+// against CreateStatement and live columns that no config exposes
+func (cg *CRUDGenerator) DetectDrift(configID uint) (*DriftReport, error) {
+	return cg.services.ConfigService.DetectDrift(configID)
+}
+
 // CRUD operations - these provide direct programmatic access to CRUD operations
 
 // List performs a list operation on the specified table
@@ -348,21 +393,112 @@ func (cg *CRUDGenerator) List(configName string, params *QueryParams) (*QueryRes
 	return cg.services.CRUDService.List(configName, params)
 }
 
+// Aggregate counts, sums, averages or takes the minimum and maximum of the records matching the
+// same search and filters as List, optionally grouped by columns. Group-by columns and metrics must
+// be allowed by QueryAggregate, otherwise ErrInvalidAggregate is returned.
+func (cg *CRUDGenerator) Aggregate(configName string, params *AggregateParams) (*AggregateResult, error) {
+	return cg.services.CRUDService.Aggregate(configName, params)
+}
+
+// Facets counts, for each single and multi_select search field with a dictionary, the records per
+// dictionary item. The counts of a field apply every search parameter and filter except the search
+// parameter of the field itself.
+func (cg *CRUDGenerator) Facets(configName string, params *FacetParams) (*FacetResult, error) {
+	return cg.services.CRUDService.Facets(configName, params)
+}
+
+// Get retrieves a single record from the specified table. id is addressed the same way as in Update.
+// When display fields are configured the record holds the display fields, the primary key and the
+// updatable fields. ErrRecordNotFound is returned when no record matches id.
+func (cg *CRUDGenerator) Get(configName string, id interface{}) (map[string]interface{}, error) {
+	return cg.services.CRUDService.Get(configName, id)
+}
+
+// ListChildren lists the records of the child configuration of a relation whose foreign key holds
+// the key of the record id. The child configuration's own search, sort and paging settings apply.
+// ErrRelationNotFound is returned when configName has no such relation.
+func (cg *CRUDGenerator) ListChildren(configName string, id interface{}, relation string, params *QueryParams) (*QueryResult, error) {
+	return cg.services.CRUDService.ListChildren(configName, id, relation, params)
+}
+
 // Create creates a new record in the specified table
 func (cg *CRUDGenerator) Create(configName string, data map[string]interface{}) (*CRUDResult, error) {
 	return cg.services.CRUDService.Create(configName, data)
 }
 
-// Update updates a record in the specified table
+// Update updates a record in the specified table.
+// id is the value of a single column primary key, a map of the primary key column values,
+// or a composite key segment built with EncodeRecordKey.
 func (cg *CRUDGenerator) Update(configName string, id interface{}, data map[string]interface{}) (*CRUDResult, error) {
 	return cg.services.CRUDService.Update(configName, id, data)
 }
 
-// Delete deletes a record from the specified table
+// Delete deletes a record from the specified table, id is addressed the same way as in Update
 func (cg *CRUDGenerator) Delete(configName string, id interface{}) (*CRUDResult, error) {
 	return cg.services.CRUDService.Delete(configName, id)
 }
 
+var (
+	// ErrRecordNotFound is returned by Get when no record matches the key
+	ErrRecordNotFound = generator.ErrRecordNotFound
+	// ErrInvalidRecordKey is returned when a key does not match the primary key of the table
+	ErrInvalidRecordKey = generator.ErrInvalidRecordKey
+	// ErrInvalidFilter is returned by List when a filter operator is not allowed on its field or has an unusable value
+	ErrInvalidFilter = generator.ErrInvalidFilter
+	// ErrInvalidCursor is returned by List when a cursor cannot be decoded or does not match the sort order
+	ErrInvalidCursor = services.ErrInvalidCursor
+	// ErrInvalidField is returned by List when a requested field is hidden or not displayed
+	ErrInvalidField = generator.ErrInvalidField
+	// ErrRelationNotFound is returned by ListChildren when the configuration has no relation of that name
+	ErrRelationNotFound = services.ErrRelationNotFound
+	// ErrInvalidAggregate is returned by Aggregate when a group-by column or metric is not allowed
+	ErrInvalidAggregate = generator.ErrInvalidAggregate
+	// ErrViewNotFound is returned when no saved view of that name is visible to the user
+	ErrViewNotFound = services.ErrViewNotFound
+	// ErrViewExists is returned when the user already has a saved view of that name
+	ErrViewExists = services.ErrViewExists
+	// ErrViewNotOwned is returned when a user changes or deletes a view shared by another user
+	ErrViewNotOwned = services.ErrViewNotOwned
+	// ErrViewOwnerRequired is returned when a private view is saved, or a view is changed or deleted, without an owner
+	ErrViewOwnerRequired = services.ErrViewOwnerRequired
+)
+
+// ListViews lists the saved views of configName owned by owner and the views shared by other users.
+// A view of owner hides a shared view of the same name, as in GetView.
+func (cg *CRUDGenerator) ListViews(configName, owner string) ([]SavedView, error) {
+	return cg.services.CRUDService.ListViews(configName, owner)
+}
+
+// GetView retrieves a saved view by name, preferring the view of owner over a view of the same
+// name shared by another user. ErrViewNotFound is returned when owner cannot see such a view.
+func (cg *CRUDGenerator) GetView(configName, name, owner string) (*SavedView, error) {
+	return cg.services.CRUDService.GetView(configName, name, owner)
+}
+
+// CreateView saves a view of owner. The ID, owner and timestamps of view are ignored.
+// ErrViewExists is returned when owner already has a view of that name, ErrViewOwnerRequired
+// for a private view without an owner.
+func (cg *CRUDGenerator) CreateView(configName, owner string, view *SavedView) (*SavedView, error) {
+	return cg.services.CRUDService.CreateView(configName, owner, view)
+}
+
+// UpdateView replaces the settings of the view name of owner, including its name and sharing.
+// ErrViewNotOwned is returned for a view shared by another user, ErrViewOwnerRequired without an owner.
+func (cg *CRUDGenerator) UpdateView(configName, name, owner string, view *SavedView) (*SavedView, error) {
+	return cg.services.CRUDService.UpdateView(configName, name, owner, view)
+}
+
+// DeleteView deletes the view name of owner, ErrViewOwnerRequired is returned without an owner
+func (cg *CRUDGenerator) DeleteView(configName, name, owner string) error {
+	return cg.services.CRUDService.DeleteView(configName, name, owner)
+}
+
+// EncodeRecordKey encodes the values of a composite primary key, in primary key order,
+// as the key segment accepted by Update, Delete and the /update/:id and /delete/:id routes
+func EncodeRecordKey(values ...interface{}) string {
+	return generator.EncodeRecordKey(values...)
+}
+
 // GetDict retrieves dictionary data for a field
 func (cg *CRUDGenerator) GetDict(configName, field string) ([]DictItem, error) {
 	return cg.services.CRUDService.GetDict(configName, field)
@@ -439,7 +575,9 @@ func getDSNFromDialector(dialector gorm.Dialector) string {
 		// For mysql dialector, try to access DSN field via interface
 		return ""
 	case "sqlite":
-		// For sqlite dialector, try to access DSN field via interface
+		if sqliteDialector, ok := dialector.(*sqlite.Dialector); ok {
+			return sqliteDialector.DSN
+		}
 		return ""
 	default:
 		// Generic fallback - may not work for all dialectors
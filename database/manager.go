@@ -9,9 +9,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/otkinlife/crud-generator/dialect"
 	"github.com/otkinlife/crud-generator/models"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
@@ -133,6 +135,8 @@ func (dm *DatabaseManager) InitMainDB() error {
 		dialector = postgres.Open(dsn)
 	case "mysql":
 		dialector = mysql.Open(dsn)
+	case "sqlite":
+		dialector = sqlite.Open(dsn)
 	default:
 		return fmt.Errorf("unsupported database type: %s", defaultConfig.DbType)
 	}
@@ -167,6 +171,7 @@ func (dm *DatabaseManager) InitMainDB() error {
 func (dm *DatabaseManager) AutoMigrate() error {
 	return dm.mainDB.AutoMigrate(
 		&models.TableConfiguration{},
+		&models.SavedView{},
 	)
 }
 
@@ -219,6 +224,8 @@ func (dm *DatabaseManager) createConnection(connectionID string) (*gorm.DB, erro
 		dialector = postgres.Open(dsn)
 	case "mysql":
 		dialector = mysql.Open(dsn)
+	case "sqlite":
+		dialector = sqlite.Open(dsn)
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", dbConfig.DbType)
 	}
@@ -292,6 +299,25 @@ func (dm *DatabaseManager) buildDSN(dbConfig *models.DatabaseConfig) (string, er
 
 		return dsn, nil
 
+	case "sqlite":
+		// SQLite 的 database_name 为数据库文件路径，如 ./data/app.db 或 :memory:
+		dsn := dbConfig.DatabaseName
+
+		var paramPairs []string
+		for key, value := range dbConfig.ConnectionParams {
+			paramPairs = append(paramPairs, fmt.Sprintf("%s=%v", key, value))
+		}
+
+		if len(paramPairs) > 0 {
+			separator := "?"
+			if strings.Contains(dsn, "?") {
+				separator = "&"
+			}
+			dsn += separator + strings.Join(paramPairs, "&")
+		}
+
+		return dsn, nil
+
 	default:
 		return "", fmt.Errorf("unsupported database type: %s", dbConfig.DbType)
 	}
@@ -323,6 +349,8 @@ func (dm *DatabaseManager) TestConnectionWithTable(connectionID string, tableNam
 		dialector = postgres.Open(dsn)
 	case "mysql":
 		dialector = mysql.Open(dsn)
+	case "sqlite":
+		dialector = sqlite.Open(dsn)
 	default:
 		return fmt.Errorf("unsupported database type: %s", dbConfig.DbType)
 	}
@@ -343,10 +371,16 @@ func (dm *DatabaseManager) TestConnectionWithTable(connectionID string, tableNam
 	// 如果提供了表名，测试表查询；否则只测试连接
 	if tableName != "" {
 		// 执行 SELECT * FROM table_name LIMIT 1 来测试表访问
-		query := fmt.Sprintf("SELECT * FROM %s LIMIT 1", tableName)
-		if err := db.Raw(query).Error; err != nil {
+		sqlDialect, err := dialect.FromDB(db)
+		if err != nil {
+			return err
+		}
+		query := fmt.Sprintf("SELECT * FROM %s%s", sqlDialect.QuoteIdentifier(tableName), sqlDialect.LimitOffset(1, 0))
+		rows, err := db.Raw(query).Rows()
+		if err != nil {
 			return fmt.Errorf("failed to query table '%s': %w", tableName, err)
 		}
+		rows.Close()
 	} else {
 		// 原有的ping测试
 		if err := sqlDB.Ping(); err != nil {
@@ -7,6 +7,7 @@ import (
 
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
@@ -51,6 +52,11 @@ func (dm *DatabaseManager) createConnection(config DatabaseConnection) (*gorm.DB
 		)
 		dialector = mysql.Open(dsn)
 
+	case "sqlite":
+		// For SQLite the database is the file path, e.g. ./data/app.db or :memory:
+		dsn = config.Database
+		dialector = sqlite.Open(dsn)
+
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", config.Type)
 	}
@@ -170,6 +176,8 @@ func (dm *DatabaseManager) GetConnectionInfo(connectionID string) (*DatabaseInfo
 			db.Raw("SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = 'public'").Scan(&count)
 		case "mysql":
 			db.Raw("SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = ?", config.Database).Scan(&count)
+		case "sqlite":
+			db.Raw("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'").Scan(&count)
 		}
 		info.TableCount = int(count)
 	}
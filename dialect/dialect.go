@@ -0,0 +1,156 @@
+package dialect
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// InsertIDStrategy 读取插入记录 ID 的方式
+type InsertIDStrategy int
+
+const (
+	// InsertIDReturning 追加 RETURNING 子句，从返回的行中读取 ID
+	InsertIDReturning InsertIDStrategy = iota
+	// InsertIDLastInsertID 执行插入后读取 sql.Result.LastInsertId
+	InsertIDLastInsertID
+)
+
+// Dialect 封装各数据库之间的 SQL 差异
+type Dialect interface {
+	Name() string
+	// Placeholder 返回第 index 个参数（从 1 开始）的占位符
+	Placeholder(index int) string
+	// QuoteIdentifier 引用列名或表名，带限定名时每一段分别引用
+	QuoteIdentifier(name string) string
+	// CaseInsensitiveLike 返回模糊搜索使用的操作符
+	CaseInsensitiveLike() string
+	InsertIDStrategy() InsertIDStrategy
+	// ReturningClause 返回追加在 INSERT 之后的子句，数据库不支持返回行时为空
+	ReturningClause(column string) string
+	LimitOffset(limit, offset int) string
+	BooleanLiteral(value bool) string
+	// NullsFirst 升序排序时 NULL 是否排在所有值之前
+	NullsFirst() bool
+	// FullTextMatch 返回在 columns 中匹配 placeholder 绑定的检索文本的条件，
+	// language 是 PostgreSQL 的全文检索配置，其他数据库忽略
+	FullTextMatch(columns []string, language, placeholder string) string
+	// FullTextRank 返回行与检索文本相关度的表达式，数据库不支持相关度时为空
+	FullTextRank(columns []string, language, placeholder string) string
+	// QuoteAlias 把结果列名（包括其中的点）作为一个标识符引用
+	QuoteAlias(name string) string
+	// JSONExtractText 以文本读取已引用的 JSON 列中 path 上的值，数字路径段表示数组下标
+	JSONExtractText(column string, path []string) string
+	// JSONContains 返回已引用的 JSON 列中 path 上的值等于 placeholder 绑定的 JSON 文档，
+	// 或者是包含该文档的数组的条件
+	JSONContains(column string, path []string, placeholder string) string
+	// ArrayLiteral 编码写入数组列的值：PostgreSQL 上是 {"a","b"} 形式的数组字面量，
+	// 没有数组类型的数据库上是 JSON 数组
+	ArrayLiteral(values []interface{}) (string, error)
+	// ParseArray 解析以文本读取的数组列，value 不是数组时 ok 为 false
+	ParseArray(value string) ([]interface{}, bool)
+	// ArrayContains 返回已引用的数组列包含 placeholder 绑定的数组中全部元素的条件
+	ArrayContains(column, placeholder string) string
+	// ArrayOverlaps 返回已引用的数组列包含 placeholder 绑定的数组中任一元素的条件
+	ArrayOverlaps(column, placeholder string) string
+}
+
+// New 按连接的数据库类型返回对应的方言
+func New(dbType string) (Dialect, error) {
+	switch dbType {
+	case "postgresql", "postgres":
+		return &PostgreSQL{}, nil
+	case "mysql":
+		return &MySQL{}, nil
+	case "sqlite":
+		return &SQLite{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported database type: %s", dbType)
+	}
+}
+
+// FromDB 返回 GORM 连接对应的方言
+func FromDB(db *gorm.DB) (Dialect, error) {
+	return New(db.Dialector.Name())
+}
+
+// quoteIdentifier 用 quote 分别引用 name 中以点分隔的每一段，名称中的引号写两次
+func quoteIdentifier(name string, quote string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		part = strings.Trim(strings.TrimSpace(part), quote)
+		parts[i] = quote + strings.ReplaceAll(part, quote, quote+quote) + quote
+	}
+	return strings.Join(parts, ".")
+}
+
+// quoteAlias 用 quote 把 name 作为一个标识符引用，名称中的引号写两次
+func quoteAlias(name string, quote string) string {
+	return quote + strings.ReplaceAll(name, quote, quote+quote) + quote
+}
+
+// jsonPathLiteral 返回 MySQL 和 SQLite 的 JSON 路径字符串字面量，例如 '$.tags[0]'
+func jsonPathLiteral(path []string) string {
+	var builder strings.Builder
+	builder.WriteString("$")
+	for _, segment := range path {
+		if isArrayIndex(segment) {
+			builder.WriteString("[" + segment + "]")
+			continue
+		}
+		builder.WriteString(`."` + strings.ReplaceAll(segment, `"`, `\"`) + `"`)
+	}
+	return "'" + strings.ReplaceAll(builder.String(), "'", "''") + "'"
+}
+
+// isArrayIndex 判断 JSON 路径段是否为数组下标
+func isArrayIndex(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for _, r := range segment {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonArrayLiteral 把 values 编码为 JSON 数组，没有数组类型的数据库用它保存数组列
+func jsonArrayLiteral(values []interface{}) (string, error) {
+	if values == nil {
+		values = []interface{}{}
+	}
+	literal, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode array: %w", err)
+	}
+	return string(literal), nil
+}
+
+// parseJSONArray 解析 JSON 数组
+func parseJSONArray(value string) ([]interface{}, bool) {
+	var values []interface{}
+	if err := json.Unmarshal([]byte(value), &values); err != nil || values == nil {
+		return nil, false
+	}
+	return values, true
+}
+
+// concatColumns 把已引用的列用空格拼接为一个文本表达式，NULL 列按空字符串处理
+func concatColumns(d Dialect, columns []string) string {
+	parts := make([]string, len(columns))
+	for i, column := range columns {
+		parts[i] = fmt.Sprintf("COALESCE(%s, '')", d.QuoteIdentifier(column))
+	}
+	return strings.Join(parts, " || ' ' || ")
+}
+
+func limitOffset(limit, offset int) string {
+	if offset > 0 {
+		return fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
+	}
+	return fmt.Sprintf(" LIMIT %d", limit)
+}
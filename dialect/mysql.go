@@ -0,0 +1,94 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+)
+
+type MySQL struct{}
+
+func (d *MySQL) Name() string {
+	return "mysql"
+}
+
+func (d *MySQL) Placeholder(index int) string {
+	return "?"
+}
+
+func (d *MySQL) QuoteIdentifier(name string) string {
+	return quoteIdentifier(name, "`")
+}
+
+// CaseInsensitiveLike 返回 LIKE，默认排序规则下不区分大小写
+func (d *MySQL) CaseInsensitiveLike() string {
+	return "LIKE"
+}
+
+func (d *MySQL) InsertIDStrategy() InsertIDStrategy {
+	return InsertIDLastInsertID
+}
+
+func (d *MySQL) ReturningClause(column string) string {
+	return ""
+}
+
+func (d *MySQL) LimitOffset(limit, offset int) string {
+	return limitOffset(limit, offset)
+}
+
+func (d *MySQL) BooleanLiteral(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+// NullsFirst 为 true，MySQL 把 NULL 当作比任何值都小
+func (d *MySQL) NullsFirst() bool {
+	return true
+}
+
+// FullTextMatch 使用 MATCH ... AGAINST，需要在这些列上建立 FULLTEXT 索引（列必须完全一致）
+func (d *MySQL) FullTextMatch(columns []string, language, placeholder string) string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = d.QuoteIdentifier(column)
+	}
+	return fmt.Sprintf("MATCH (%s) AGAINST (%s IN NATURAL LANGUAGE MODE)", strings.Join(quoted, ", "), placeholder)
+}
+
+// FullTextRank 返回 MATCH ... AGAINST 计算的相关度
+func (d *MySQL) FullTextRank(columns []string, language, placeholder string) string {
+	return d.FullTextMatch(columns, language, placeholder)
+}
+
+func (d *MySQL) QuoteAlias(name string) string {
+	return quoteAlias(name, "`")
+}
+
+// JSONExtractText 去掉 JSON_EXTRACT 结果的引号，字符串比较时不带引号
+func (d *MySQL) JSONExtractText(column string, path []string) string {
+	return fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, %s))", column, jsonPathLiteral(path))
+}
+
+func (d *MySQL) JSONContains(column string, path []string, placeholder string) string {
+	return fmt.Sprintf("JSON_CONTAINS(%s, %s, %s)", column, placeholder, jsonPathLiteral(path))
+}
+
+// ArrayLiteral 以 JSON 数组保存数组，MySQL 没有数组类型
+func (d *MySQL) ArrayLiteral(values []interface{}) (string, error) {
+	return jsonArrayLiteral(values)
+}
+
+func (d *MySQL) ParseArray(value string) ([]interface{}, bool) {
+	return parseJSONArray(value)
+}
+
+func (d *MySQL) ArrayContains(column, placeholder string) string {
+	return fmt.Sprintf("JSON_CONTAINS(%s, %s)", column, placeholder)
+}
+
+// ArrayOverlaps 使用 JSON_OVERLAPS，MySQL 8.0.17 起可用
+func (d *MySQL) ArrayOverlaps(column, placeholder string) string {
+	return fmt.Sprintf("JSON_OVERLAPS(%s, %s)", column, placeholder)
+}
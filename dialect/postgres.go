@@ -0,0 +1,181 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+)
+
+type PostgreSQL struct{}
+
+func (d *PostgreSQL) Name() string {
+	return "postgres"
+}
+
+func (d *PostgreSQL) Placeholder(index int) string {
+	return fmt.Sprintf("$%d", index)
+}
+
+func (d *PostgreSQL) QuoteIdentifier(name string) string {
+	return quoteIdentifier(name, `"`)
+}
+
+func (d *PostgreSQL) CaseInsensitiveLike() string {
+	return "ILIKE"
+}
+
+func (d *PostgreSQL) InsertIDStrategy() InsertIDStrategy {
+	return InsertIDReturning
+}
+
+func (d *PostgreSQL) ReturningClause(column string) string {
+	return " RETURNING " + d.QuoteIdentifier(column)
+}
+
+func (d *PostgreSQL) LimitOffset(limit, offset int) string {
+	return limitOffset(limit, offset)
+}
+
+func (d *PostgreSQL) BooleanLiteral(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// NullsFirst 为 false，PostgreSQL 把 NULL 当作比任何值都大
+func (d *PostgreSQL) NullsFirst() bool {
+	return false
+}
+
+// FullTextMatch 使用 websearch_to_tsquery，支持引号短语、"or" 和 "-排除词"；
+// 在相同的 to_tsvector 表达式上建立表达式索引即可走索引
+func (d *PostgreSQL) FullTextMatch(columns []string, language, placeholder string) string {
+	return fmt.Sprintf("%s @@ %s", d.textSearchVector(columns, language), d.textSearchQuery(language, placeholder))
+}
+
+func (d *PostgreSQL) FullTextRank(columns []string, language, placeholder string) string {
+	return fmt.Sprintf("ts_rank(%s, %s)", d.textSearchVector(columns, language), d.textSearchQuery(language, placeholder))
+}
+
+func (d *PostgreSQL) textSearchVector(columns []string, language string) string {
+	return fmt.Sprintf("to_tsvector(%s, %s)", textSearchConfig(language), concatColumns(d, columns))
+}
+
+func (d *PostgreSQL) textSearchQuery(language, placeholder string) string {
+	return fmt.Sprintf("websearch_to_tsquery(%s, %s)", textSearchConfig(language), placeholder)
+}
+
+func (d *PostgreSQL) QuoteAlias(name string) string {
+	return quoteAlias(name, `"`)
+}
+
+// JSONExtractText 中间的键使用 ->，最后一个键使用 ->>
+func (d *PostgreSQL) JSONExtractText(column string, path []string) string {
+	last := len(path) - 1
+	return column + postgresJSONPath(path[:last], "->") + postgresJSONPath(path[last:], "->>")
+}
+
+// JSONContains 使用 @>，jsonb 数组包含单个元素时也匹配；json 列先转换为 jsonb
+func (d *PostgreSQL) JSONContains(column string, path []string, placeholder string) string {
+	return fmt.Sprintf("(%s%s)::jsonb @> CAST(%s AS jsonb)", column, postgresJSONPath(path, "->"), placeholder)
+}
+
+// postgresJSONPath 对每个路径段应用 operator，数组下标为整数，键为文本字面量
+func postgresJSONPath(path []string, operator string) string {
+	var builder strings.Builder
+	for _, segment := range path {
+		builder.WriteString(operator)
+		if isArrayIndex(segment) {
+			builder.WriteString(segment)
+			continue
+		}
+		builder.WriteString("'" + strings.ReplaceAll(segment, "'", "''") + "'")
+	}
+	return builder.String()
+}
+
+// ArrayLiteral 给每个元素加引号，字面量适用于任意元素类型的数组；NULL 元素不加引号
+func (d *PostgreSQL) ArrayLiteral(values []interface{}) (string, error) {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	elements := make([]string, len(values))
+	for i, value := range values {
+		switch value.(type) {
+		case nil:
+			elements[i] = "NULL"
+			continue
+		case []interface{}, map[string]interface{}:
+			return "", fmt.Errorf("nested array elements are not supported")
+		}
+		elements[i] = `"` + escaper.Replace(fmt.Sprint(value)) + `"`
+	}
+	return "{" + strings.Join(elements, ",") + "}", nil
+}
+
+// ParseArray 解析一维数组的文本输出，元素以字符串返回
+func (d *PostgreSQL) ParseArray(value string) ([]interface{}, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 || value[0] != '{' || value[len(value)-1] != '}' {
+		return nil, false
+	}
+	values := []interface{}{}
+	body := value[1 : len(value)-1]
+	if body == "" {
+		return values, true
+	}
+
+	var element strings.Builder
+	quoted, inQuotes, escaped := false, false, false
+	flush := func() {
+		text := element.String()
+		if !quoted && strings.EqualFold(strings.TrimSpace(text), "NULL") {
+			values = append(values, nil)
+		} else if quoted {
+			values = append(values, text)
+		} else {
+			values = append(values, strings.TrimSpace(text))
+		}
+		element.Reset()
+		quoted = false
+	}
+	for _, char := range body {
+		switch {
+		case escaped:
+			element.WriteRune(char)
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '"':
+			inQuotes = !inQuotes
+			quoted = true
+		case char == '{' && !inQuotes:
+			// 多维数组保持文本
+			return nil, false
+		case char == ',' && !inQuotes:
+			flush()
+		default:
+			element.WriteRune(char)
+		}
+	}
+	if inQuotes || escaped {
+		return nil, false
+	}
+	flush()
+	return values, true
+}
+
+// ArrayContains 使用 @>，未声明类型的字面量采用列的类型
+func (d *PostgreSQL) ArrayContains(column, placeholder string) string {
+	return fmt.Sprintf("%s @> %s", column, placeholder)
+}
+
+func (d *PostgreSQL) ArrayOverlaps(column, placeholder string) string {
+	return fmt.Sprintf("%s && %s", column, placeholder)
+}
+
+// textSearchConfig 以 regconfig 字面量返回全文检索配置，默认为 "simple"
+func textSearchConfig(language string) string {
+	if language == "" {
+		language = "simple"
+	}
+	return "'" + strings.ReplaceAll(language, "'", "''") + "'::regconfig"
+}
@@ -0,0 +1,86 @@
+package dialect
+
+import "fmt"
+
+type SQLite struct{}
+
+func (d *SQLite) Name() string {
+	return "sqlite"
+}
+
+func (d *SQLite) Placeholder(index int) string {
+	return "?"
+}
+
+func (d *SQLite) QuoteIdentifier(name string) string {
+	return quoteIdentifier(name, `"`)
+}
+
+// CaseInsensitiveLike 返回 LIKE，ASCII 字符不区分大小写
+func (d *SQLite) CaseInsensitiveLike() string {
+	return "LIKE"
+}
+
+// InsertIDStrategy 使用 RETURNING，SQLite 3.35 起可用
+func (d *SQLite) InsertIDStrategy() InsertIDStrategy {
+	return InsertIDReturning
+}
+
+func (d *SQLite) ReturningClause(column string) string {
+	return " RETURNING " + d.QuoteIdentifier(column)
+}
+
+func (d *SQLite) LimitOffset(limit, offset int) string {
+	return limitOffset(limit, offset)
+}
+
+func (d *SQLite) BooleanLiteral(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+// NullsFirst 为 true，SQLite 把 NULL 当作比任何值都小
+func (d *SQLite) NullsFirst() bool {
+	return true
+}
+
+// FullTextMatch 退化为不区分大小写的子串匹配，SQLite 不使用 FTS 表时没有全文索引
+func (d *SQLite) FullTextMatch(columns []string, language, placeholder string) string {
+	return fmt.Sprintf("instr(lower(%s), lower(%s)) > 0", concatColumns(d, columns), placeholder)
+}
+
+func (d *SQLite) FullTextRank(columns []string, language, placeholder string) string {
+	return ""
+}
+
+func (d *SQLite) QuoteAlias(name string) string {
+	return quoteAlias(name, `"`)
+}
+
+func (d *SQLite) JSONExtractText(column string, path []string) string {
+	return fmt.Sprintf("json_extract(%s, %s)", column, jsonPathLiteral(path))
+}
+
+// JSONContains 用 json_each 遍历 path 上的值，标量值本身作为唯一的元素返回
+func (d *SQLite) JSONContains(column string, path []string, placeholder string) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s, %s) WHERE json_each.value = json_extract(%s, '$'))", column, jsonPathLiteral(path), placeholder)
+}
+
+// ArrayLiteral 以 JSON 数组保存数组，SQLite 没有数组类型
+func (d *SQLite) ArrayLiteral(values []interface{}) (string, error) {
+	return jsonArrayLiteral(values)
+}
+
+func (d *SQLite) ParseArray(value string) ([]interface{}, bool) {
+	return parseJSONArray(value)
+}
+
+func (d *SQLite) ArrayContains(column, placeholder string) string {
+	return fmt.Sprintf("NOT EXISTS (SELECT 1 FROM json_each(%s) AS wanted WHERE wanted.value NOT IN (SELECT value FROM json_each(%s)))", placeholder, column)
+}
+
+func (d *SQLite) ArrayOverlaps(column, placeholder string) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s) AS held WHERE held.value IN (SELECT value FROM json_each(%s)))", column, placeholder)
+}
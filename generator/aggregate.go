@@ -0,0 +1,124 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/dialect"
+	"github.com/otkinlife/crud-generator/types"
+)
+
+// ErrInvalidAggregate 统计查询的分组列或统计列不在配置允许的范围内
+var ErrInvalidAggregate = errors.New("invalid aggregate")
+
+// MetricKey 返回统计项的结果列名，例如 count 或 sum_amount
+func MetricKey(metric types.Metric) string {
+	if metric.Field == "" {
+		return string(metric.Function)
+	}
+	return string(metric.Function) + "_" + metric.Field
+}
+
+// ParseMetrics 解析 "count,sum:amount,avg:amount" 形式的统计项列表
+func ParseMetrics(value string) []types.Metric {
+	var metrics []types.Metric
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		function, field, _ := strings.Cut(part, ":")
+		metrics = append(metrics, types.Metric{
+			Function: types.AggregateFunction(strings.ToLower(strings.TrimSpace(function))),
+			Field:    strings.TrimSpace(field),
+		})
+	}
+	return metrics
+}
+
+// AggregateColumns 按配置的统计设置校验 groupBy 和 metrics，返回查询列、已引用的分组列和统计项的结果列名；
+// 没有统计项时统计记录数
+func AggregateColumns(d dialect.Dialect, config *types.Config, groupBy []string, metrics []types.Metric) (string, []string, []string, error) {
+	var settings types.AggregateConfig
+	var hiddenFields []string
+	if config != nil && config.QueryConfig != nil {
+		if config.QueryConfig.Aggregate != nil {
+			settings = *config.QueryConfig.Aggregate
+		}
+		hiddenFields = config.QueryConfig.HiddenFields
+	}
+	hidden := func(field string) bool {
+		for _, hiddenField := range hiddenFields {
+			if hiddenField == field {
+				return true
+			}
+		}
+		return false
+	}
+
+	var selected, groupColumns, keys []string
+	for _, field := range groupBy {
+		allowed := false
+		for _, allowedField := range settings.GroupBy {
+			if allowedField == field {
+				allowed = true
+				break
+			}
+		}
+		if !allowed || hidden(field) {
+			return "", nil, nil, fmt.Errorf("%w: cannot group by field '%s'", ErrInvalidAggregate, field)
+		}
+		column := d.QuoteIdentifier(field)
+		selected = append(selected, column)
+		groupColumns = append(groupColumns, column)
+	}
+
+	if len(metrics) == 0 {
+		metrics = []types.Metric{{Function: types.AggregateCount}}
+	}
+	seen := make(map[string]bool)
+	for _, metric := range metrics {
+		key := MetricKey(metric)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+
+		switch metric.Function {
+		case types.AggregateCount, types.AggregateSum, types.AggregateAvg, types.AggregateMin, types.AggregateMax:
+		default:
+			return "", nil, nil, fmt.Errorf("%w: unknown function '%s'", ErrInvalidAggregate, metric.Function)
+		}
+
+		if metric.Field == "" {
+			if metric.Function != types.AggregateCount {
+				return "", nil, nil, fmt.Errorf("%w: function '%s' needs a field", ErrInvalidAggregate, metric.Function)
+			}
+			selected = append(selected, fmt.Sprintf("COUNT(*) AS %s", d.QuoteIdentifier(key)))
+			continue
+		}
+
+		if !metricAllowed(settings.Metrics, metric) || hidden(metric.Field) {
+			return "", nil, nil, fmt.Errorf("%w: function '%s' is not allowed on field '%s'", ErrInvalidAggregate, metric.Function, metric.Field)
+		}
+		selected = append(selected, fmt.Sprintf("%s(%s) AS %s", strings.ToUpper(string(metric.Function)), d.QuoteIdentifier(metric.Field), d.QuoteIdentifier(key)))
+	}
+
+	return strings.Join(selected, ", "), groupColumns, keys, nil
+}
+
+func metricAllowed(metricFields []types.MetricField, metric types.Metric) bool {
+	for _, field := range metricFields {
+		if field.Field != metric.Field {
+			continue
+		}
+		for _, function := range field.Functions {
+			if function == metric.Function {
+				return true
+			}
+		}
+	}
+	return false
+}
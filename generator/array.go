@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/dialect"
+	"github.com/otkinlife/crud-generator/types"
+)
+
+// ArrayValues 返回数组值的元素，数组值可以是列表、JSON 数组或逗号分隔的字符串
+func ArrayValues(value interface{}) []interface{} {
+	if text, ok := value.(string); ok && strings.HasPrefix(strings.TrimSpace(text), "[") {
+		var values []interface{}
+		if err := json.Unmarshal([]byte(text), &values); err == nil {
+			return values
+		}
+	}
+	return filterValues(value)
+}
+
+// ArrayCondition 生成数组列 contains / overlaps 搜索的条件和绑定到 placeholder 的数组字面量，value 没有元素时 ok 为 false
+func ArrayCondition(d dialect.Dialect, searchField types.SearchField, value interface{}, placeholder string) (string, interface{}, bool, error) {
+	values := ArrayValues(value)
+	if len(values) == 0 {
+		return "", nil, false, nil
+	}
+	literal, err := d.ArrayLiteral(values)
+	if err != nil {
+		return "", nil, false, fmt.Errorf("invalid value of field '%s': %w", searchField.Field, err)
+	}
+
+	column := d.QuoteIdentifier(searchField.Field)
+	switch searchField.Type {
+	case types.SearchTypeContains:
+		return d.ArrayContains(column, placeholder), literal, true, nil
+	case types.SearchTypeOverlaps:
+		return d.ArrayOverlaps(column, placeholder), literal, true, nil
+	}
+	return "", nil, false, fmt.Errorf("search type '%s' is not an array search", searchField.Type)
+}
+
+// DecodeArray 解析以文本读取的数组列，并按列的元素类型把元素转换为整数、浮点数或布尔值，其他元素保持文本；
+// value 不是数组时 ok 为 false
+func DecodeArray(d dialect.Dialect, value string, elementType types.PostgreSQLType) ([]interface{}, bool) {
+	values, ok := d.ParseArray(value)
+	if !ok {
+		return nil, false
+	}
+	for i, element := range values {
+		text, isText := element.(string)
+		if !isText {
+			continue
+		}
+		switch elementType {
+		case types.PostgreSQLTypeInteger, types.PostgreSQLTypeBigint, types.PostgreSQLTypeSmallint:
+			if number, err := strconv.ParseInt(text, 10, 64); err == nil {
+				values[i] = number
+			}
+		case types.PostgreSQLTypeNumeric, types.PostgreSQLTypeReal, types.PostgreSQLTypeDouble:
+			if number, err := strconv.ParseFloat(text, 64); err == nil {
+				values[i] = number
+			}
+		case types.PostgreSQLTypeBoolean:
+			switch strings.ToLower(text) {
+			case "t", "true":
+				values[i] = true
+			case "f", "false":
+				values[i] = false
+			}
+		}
+	}
+	return values, true
+}
@@ -4,21 +4,29 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/otkinlife/crud-generator/dialect"
 	"github.com/otkinlife/crud-generator/types"
 )
 
 type CRUDGenerator struct {
-	schema *types.TableSchema
-	config *types.Config
+	schema  *types.TableSchema
+	config  *types.Config
+	dialect dialect.Dialect
 }
 
-func NewCRUDGenerator(schema *types.TableSchema, config *types.Config) *CRUDGenerator {
+func NewCRUDGenerator(schema *types.TableSchema, config *types.Config, d dialect.Dialect) *CRUDGenerator {
 	return &CRUDGenerator{
-		schema: schema,
-		config: config,
+		schema:  schema,
+		config:  config,
+		dialect: d,
 	}
 }
 
+// Dialect 返回生成语句使用的方言
+func (g *CRUDGenerator) Dialect() dialect.Dialect {
+	return g.dialect
+}
+
 func (g *CRUDGenerator) GenerateInsert(data map[string]interface{}) (string, []interface{}, error) {
 	if len(data) == 0 {
 		return "", nil, fmt.Errorf("no data provided for insert")
@@ -35,12 +43,12 @@ func (g *CRUDGenerator) GenerateInsert(data map[string]interface{}) (string, []i
 	}
 
 	for fieldName, value := range data {
-		if _, exists := fieldMap[fieldName]; !exists {
+		if field, exists := fieldMap[fieldName]; !exists || field.ReadOnly {
 			continue
 		}
 
-		fields = append(fields, fieldName)
-		placeholders = append(placeholders, fmt.Sprintf("$%d", argIndex))
+		fields = append(fields, g.dialect.QuoteIdentifier(fieldName))
+		placeholders = append(placeholders, g.dialect.Placeholder(argIndex))
 		values = append(values, value)
 		argIndex++
 	}
@@ -49,15 +57,15 @@ func (g *CRUDGenerator) GenerateInsert(data map[string]interface{}) (string, []i
 		return "", nil, fmt.Errorf("no valid fields found for insert")
 	}
 
-	primaryKeyField := g.getPrimaryKeyField()
+	primaryKey := g.PrimaryKey()
 	var returningClause string
-	if primaryKeyField != nil {
-		returningClause = fmt.Sprintf(" RETURNING %s", primaryKeyField.Name)
+	if len(primaryKey) == 1 && g.dialect.InsertIDStrategy() == dialect.InsertIDReturning {
+		returningClause = g.dialect.ReturningClause(primaryKey[0])
 	}
 
 	query := fmt.Sprintf(
 		"INSERT INTO %s (%s) VALUES (%s)%s",
-		g.schema.TableName,
+		g.dialect.QuoteIdentifier(g.schema.TableName),
 		strings.Join(fields, ", "),
 		strings.Join(placeholders, ", "),
 		returningClause,
@@ -71,9 +79,15 @@ func (g *CRUDGenerator) GenerateUpdate(id interface{}, data map[string]interface
 		return "", nil, fmt.Errorf("no data provided for update")
 	}
 
-	primaryKeyField := g.getPrimaryKeyField()
-	if primaryKeyField == nil {
-		return "", nil, fmt.Errorf("no primary key field found")
+	primaryKey := g.PrimaryKey()
+	key, err := ResolveRecordKey(primaryKey, id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	isKeyColumn := make(map[string]bool)
+	for _, column := range primaryKey {
+		isKeyColumn[column] = true
 	}
 
 	var setParts []string
@@ -88,22 +102,20 @@ func (g *CRUDGenerator) GenerateUpdate(id interface{}, data map[string]interface
 	updatableFields := make(map[string]bool)
 	if g.config.UpdateConfig != nil && len(g.config.UpdateConfig.UpdatableFields) > 0 {
 		for _, field := range g.config.UpdateConfig.UpdatableFields {
-			updatableFields[field] = true
+			updatableFields[field.Field] = true
 		}
 	} else {
 		for fieldName := range fieldMap {
-			if fieldName != primaryKeyField.Name {
-				updatableFields[fieldName] = true
-			}
+			updatableFields[fieldName] = true
 		}
 	}
 
 	for fieldName, value := range data {
-		if fieldName == primaryKeyField.Name {
+		if isKeyColumn[fieldName] {
 			continue
 		}
 
-		if _, exists := fieldMap[fieldName]; !exists {
+		if field, exists := fieldMap[fieldName]; !exists || field.ReadOnly {
 			continue
 		}
 
@@ -111,7 +123,7 @@ func (g *CRUDGenerator) GenerateUpdate(id interface{}, data map[string]interface
 			continue
 		}
 
-		setParts = append(setParts, fmt.Sprintf("%s = $%d", fieldName, argIndex))
+		setParts = append(setParts, fmt.Sprintf("%s = %s", g.dialect.QuoteIdentifier(fieldName), g.dialect.Placeholder(argIndex)))
 		values = append(values, value)
 		argIndex++
 	}
@@ -120,39 +132,94 @@ func (g *CRUDGenerator) GenerateUpdate(id interface{}, data map[string]interface
 		return "", nil, fmt.Errorf("no valid fields found for update")
 	}
 
-	values = append(values, id)
+	whereClause, keyValues := g.keyCondition(primaryKey, key, argIndex)
+	values = append(values, keyValues...)
 
 	query := fmt.Sprintf(
-		"UPDATE %s SET %s WHERE %s = $%d",
-		g.schema.TableName,
+		"UPDATE %s SET %s WHERE %s",
+		g.dialect.QuoteIdentifier(g.schema.TableName),
 		strings.Join(setParts, ", "),
-		primaryKeyField.Name,
-		argIndex,
+		whereClause,
 	)
 
 	return query, values, nil
 }
 
 func (g *CRUDGenerator) GenerateDelete(id interface{}) (string, []interface{}, error) {
-	primaryKeyField := g.getPrimaryKeyField()
-	if primaryKeyField == nil {
-		return "", nil, fmt.Errorf("no primary key field found")
+	primaryKey := g.PrimaryKey()
+	key, err := ResolveRecordKey(primaryKey, id)
+	if err != nil {
+		return "", nil, err
 	}
 
+	whereClause, values := g.keyCondition(primaryKey, key, 1)
+
 	query := fmt.Sprintf(
-		"DELETE FROM %s WHERE %s = $1",
-		g.schema.TableName,
-		primaryKeyField.Name,
+		"DELETE FROM %s WHERE %s",
+		g.dialect.QuoteIdentifier(g.schema.TableName),
+		whereClause,
 	)
 
-	return query, []interface{}{id}, nil
+	return query, values, nil
 }
 
-func (g *CRUDGenerator) getPrimaryKeyField() *types.TableField {
-	for _, field := range g.schema.Fields {
-		if field.PrimaryKey {
-			return &field
+// GenerateSelect 生成按主键读取单条记录的查询，查询的列见 RecordColumns
+func (g *CRUDGenerator) GenerateSelect(id interface{}) (string, []interface{}, error) {
+	primaryKey := g.PrimaryKey()
+	key, err := ResolveRecordKey(primaryKey, id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	columns, err := RecordColumns(g.config, primaryKey, schemaColumns(g.schema))
+	if err != nil {
+		return "", nil, err
+	}
+
+	whereClause, values := g.keyCondition(primaryKey, key, 1)
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE %s",
+		SelectList(g.dialect, columns),
+		g.dialect.QuoteIdentifier(g.schema.TableName),
+		whereClause,
+	)
+
+	return query, values, nil
+}
+
+// RecordColumns 返回单条记录查询的列：展示字段、主键和可更新字段，保证记录既能展示也能编辑；
+// 未配置展示字段时返回 tableColumns 的全部列。隐藏字段始终不查询，没有任何限制时返回 nil 表示全部列
+func RecordColumns(config *types.Config, primaryKey, tableColumns []string) ([]string, error) {
+	var columns []string
+	if config != nil && config.QueryConfig != nil && len(config.QueryConfig.DisplayFields) > 0 {
+		for _, field := range config.QueryConfig.DisplayFields {
+			columns = appendColumn(columns, field.Field)
+		}
+		for _, column := range primaryKey {
+			columns = appendColumn(columns, column)
 		}
+		if config.UpdateConfig != nil {
+			for _, field := range config.UpdateConfig.UpdatableFields {
+				columns = appendColumn(columns, field.Field)
+			}
+		}
+	}
+	return projectColumns(columns, tableColumns, nil, nil, HiddenFields(config))
+}
+
+// PrimaryKey 返回定位记录的列：配置的主键或表结构中的主键
+func (g *CRUDGenerator) PrimaryKey() []string {
+	return PrimaryKeyColumns(g.schema, g.config.PrimaryKey)
+}
+
+// keyCondition 按主键列生成 "a = $n AND b = $n+1" 形式的条件
+func (g *CRUDGenerator) keyCondition(primaryKey []string, key map[string]interface{}, argIndex int) (string, []interface{}) {
+	conditions := make([]string, len(primaryKey))
+	values := make([]interface{}, len(primaryKey))
+	for i, column := range primaryKey {
+		conditions[i] = fmt.Sprintf("%s = %s", g.dialect.QuoteIdentifier(column), g.dialect.Placeholder(argIndex+i))
+		values[i] = key[column]
 	}
-	return nil
+	return strings.Join(conditions, " AND "), values
 }
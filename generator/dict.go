@@ -4,17 +4,20 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/otkinlife/crud-generator/dialect"
 	"github.com/otkinlife/crud-generator/types"
 	"gorm.io/gorm"
 )
 
 type DictProvider struct {
-	db *gorm.DB
+	db      *gorm.DB
+	dialect dialect.Dialect
 }
 
-func NewDictProvider(db *gorm.DB) *DictProvider {
+func NewDictProvider(db *gorm.DB, d dialect.Dialect) *DictProvider {
 	return &DictProvider{
-		db: db,
+		db:      db,
+		dialect: d,
 	}
 }
 
@@ -23,7 +26,8 @@ func (d *DictProvider) GetDictValues(dictSource *types.DictSource) ([]types.Dict
 		return nil, fmt.Errorf("dict source cannot be nil")
 	}
 
-	query := fmt.Sprintf("SELECT DISTINCT %s FROM %s", dictSource.Field, dictSource.Table)
+	field := d.dialect.QuoteIdentifier(dictSource.Field)
+	query := fmt.Sprintf("SELECT DISTINCT %s FROM %s", field, d.dialect.QuoteIdentifier(dictSource.Table))
 
 	if dictSource.Where != "" {
 		query += " WHERE " + dictSource.Where
@@ -34,7 +38,7 @@ func (d *DictProvider) GetDictValues(dictSource *types.DictSource) ([]types.Dict
 		order = string(types.SortOrderASC)
 	}
 
-	query += fmt.Sprintf(" ORDER BY %s %s", dictSource.Field, order)
+	query += fmt.Sprintf(" ORDER BY %s %s", field, order)
 
 	rows, err := d.db.Raw(query).Rows()
 	if err != nil {
@@ -56,7 +60,7 @@ func (d *DictProvider) GetDictValues(dictSource *types.DictSource) ([]types.Dict
 		}
 
 		items = append(items, types.DictItem{
-			Value: value,
+			Value: label,
 			Label: label,
 		})
 	}
@@ -77,11 +81,12 @@ func (d *DictProvider) GetDictValuesWithLabels(dictSource *types.DictSource, lab
 		return d.GetDictValues(dictSource)
 	}
 
+	field := d.dialect.QuoteIdentifier(dictSource.Field)
 	query := fmt.Sprintf(
 		"SELECT DISTINCT %s, %s FROM %s",
-		dictSource.Field,
-		labelField,
-		dictSource.Table,
+		field,
+		d.dialect.QuoteIdentifier(labelField),
+		d.dialect.QuoteIdentifier(dictSource.Table),
 	)
 
 	if dictSource.Where != "" {
@@ -93,7 +98,7 @@ func (d *DictProvider) GetDictValuesWithLabels(dictSource *types.DictSource, lab
 		order = string(types.SortOrderASC)
 	}
 
-	query += fmt.Sprintf(" ORDER BY %s %s", dictSource.Field, order)
+	query += fmt.Sprintf(" ORDER BY %s %s", field, order)
 
 	rows, err := d.db.Raw(query).Rows()
 	if err != nil {
@@ -115,7 +120,7 @@ func (d *DictProvider) GetDictValuesWithLabels(dictSource *types.DictSource, lab
 		}
 
 		items = append(items, types.DictItem{
-			Value: value,
+			Value: fmt.Sprintf("%v", value),
 			Label: labelStr,
 		})
 	}
@@ -159,34 +164,12 @@ func (d *DictProvider) ValidateDictSource(dictSource *types.DictSource) error {
 		return fmt.Errorf("dict source field cannot be empty")
 	}
 
-	tableExistsQuery := `
-		SELECT EXISTS (
-			SELECT FROM information_schema.tables 
-			WHERE table_name = ?
-		)
-	`
-
-	var exists bool
-	if err := d.db.Raw(tableExistsQuery, dictSource.Table).Scan(&exists).Error; err != nil {
-		return fmt.Errorf("failed to check if table exists: %w", err)
-	}
-
-	if !exists {
+	// Migrator 按连接的数据库类型查询系统表，PostgreSQL、MySQL 和 SQLite 均适用
+	if !d.db.Migrator().HasTable(dictSource.Table) {
 		return fmt.Errorf("table %s does not exist", dictSource.Table)
 	}
 
-	columnExistsQuery := `
-		SELECT EXISTS (
-			SELECT FROM information_schema.columns 
-			WHERE table_name = ? AND column_name = ?
-		)
-	`
-
-	if err := d.db.Raw(columnExistsQuery, dictSource.Table, dictSource.Field).Scan(&exists).Error; err != nil {
-		return fmt.Errorf("failed to check if column exists: %w", err)
-	}
-
-	if !exists {
+	if !d.db.Migrator().HasColumn(dictSource.Table, dictSource.Field) {
 		return fmt.Errorf("column %s does not exist in table %s", dictSource.Field, dictSource.Table)
 	}
 
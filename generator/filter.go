@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/dialect"
+	"github.com/otkinlife/crud-generator/types"
+)
+
+// ErrInvalidFilter 过滤条件使用了字段不允许的操作符，或取值无法使用
+var ErrInvalidFilter = errors.New("invalid filter")
+
+var comparisonOperators = map[types.FilterOperator]string{
+	types.FilterOpEq:  "=",
+	types.FilterOpNe:  "<>",
+	types.FilterOpGt:  ">",
+	types.FilterOpGte: ">=",
+	types.FilterOpLt:  "<",
+	types.FilterOpLte: "<=",
+}
+
+// likeEscape 转义过滤值中的 LIKE 通配符，各数据库通用
+const likeEscape = "!"
+
+// CheckFilter 检查过滤条件的操作符是否为字段允许的操作符
+func CheckFilter(filterFields []types.FilterField, filter types.Filter) error {
+	for _, field := range filterFields {
+		if field.Field != filter.Field {
+			continue
+		}
+		for _, operator := range field.Operators {
+			if operator == filter.Operator {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: operator '%s' is not allowed on field '%s'", ErrInvalidFilter, filter.Operator, filter.Field)
+	}
+	return fmt.Errorf("%w: field '%s' cannot be filtered", ErrInvalidFilter, filter.Field)
+}
+
+// FilterCondition 生成列或 JSON 路径上的过滤条件，placeholder 返回下一个参数的占位符
+func FilterCondition(d dialect.Dialect, filter types.Filter, placeholder func() string) (string, []interface{}, error) {
+	column := ColumnExpression(d, filter.Field)
+
+	switch filter.Operator {
+	case types.FilterOpEq, types.FilterOpNe, types.FilterOpGt, types.FilterOpGte, types.FilterOpLt, types.FilterOpLte:
+		if filter.Value == nil {
+			return "", nil, fmt.Errorf("%w: operator '%s' on field '%s' needs a value", ErrInvalidFilter, filter.Operator, filter.Field)
+		}
+		return fmt.Sprintf("%s %s %s", column, comparisonOperators[filter.Operator], placeholder()), []interface{}{filter.Value}, nil
+
+	case types.FilterOpIn, types.FilterOpNotIn:
+		values := filterValues(filter.Value)
+		if len(values) == 0 {
+			return "", nil, fmt.Errorf("%w: operator '%s' on field '%s' needs at least one value", ErrInvalidFilter, filter.Operator, filter.Field)
+		}
+		placeholders := make([]string, len(values))
+		for i := range values {
+			placeholders[i] = placeholder()
+		}
+		operator := "IN"
+		if filter.Operator == types.FilterOpNotIn {
+			operator = "NOT IN"
+		}
+		return fmt.Sprintf("%s %s (%s)", column, operator, strings.Join(placeholders, ", ")), values, nil
+
+	case types.FilterOpPrefix, types.FilterOpSuffix, types.FilterOpContains:
+		if filter.Value == nil {
+			return "", nil, fmt.Errorf("%w: operator '%s' on field '%s' needs a value", ErrInvalidFilter, filter.Operator, filter.Field)
+		}
+		escaper := strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_")
+		pattern := escaper.Replace(fmt.Sprint(filter.Value))
+		switch filter.Operator {
+		case types.FilterOpPrefix:
+			pattern += "%"
+		case types.FilterOpSuffix:
+			pattern = "%" + pattern
+		default:
+			pattern = "%" + pattern + "%"
+		}
+		return fmt.Sprintf("%s %s %s ESCAPE '%s'", column, d.CaseInsensitiveLike(), placeholder(), likeEscape), []interface{}{pattern}, nil
+
+	case types.FilterOpIsNull:
+		return fmt.Sprintf("%s IS NULL", column), nil, nil
+
+	case types.FilterOpNotNull:
+		return fmt.Sprintf("%s IS NOT NULL", column), nil, nil
+
+	case types.FilterOpBetween:
+		values := filterValues(filter.Value)
+		if len(values) != 2 {
+			return "", nil, fmt.Errorf("%w: operator 'between' on field '%s' needs two values", ErrInvalidFilter, filter.Field)
+		}
+		return fmt.Sprintf("%s BETWEEN %s AND %s", column, placeholder(), placeholder()), values, nil
+	}
+
+	return "", nil, fmt.Errorf("%w: unknown operator '%s'", ErrInvalidFilter, filter.Operator)
+}
+
+// maxFilterDepth 组合过滤条件的最大嵌套层数
+const maxFilterDepth = 16
+
+// FilterTreeCondition 把组合过滤条件编译为参数化的条件，每个叶子条件都必须是 filterFields 允许的；
+// placeholder 返回下一个参数的占位符
+func FilterTreeCondition(d dialect.Dialect, filterFields []types.FilterField, node *types.FilterNode, placeholder func() string) (string, []interface{}, error) {
+	return filterTreeCondition(d, filterFields, node, placeholder, 1)
+}
+
+func filterTreeCondition(d dialect.Dialect, filterFields []types.FilterField, node *types.FilterNode, placeholder func() string, depth int) (string, []interface{}, error) {
+	if depth > maxFilterDepth {
+		return "", nil, fmt.Errorf("%w: the expression is nested deeper than %d levels", ErrInvalidFilter, maxFilterDepth)
+	}
+
+	kinds := 0
+	for _, set := range []bool{len(node.And) > 0, len(node.Or) > 0, node.Not != nil, node.Field != ""} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return "", nil, fmt.Errorf("%w: a node needs exactly one of and, or, not or field", ErrInvalidFilter)
+	}
+
+	switch {
+	case node.Field != "":
+		if err := CheckFilter(filterFields, node.Filter); err != nil {
+			return "", nil, err
+		}
+		return FilterCondition(d, node.Filter, placeholder)
+
+	case node.Not != nil:
+		condition, args, err := filterTreeCondition(d, filterFields, node.Not, placeholder, depth+1)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT (" + condition + ")", args, nil
+	}
+
+	children, separator := node.And, " AND "
+	if len(node.Or) > 0 {
+		children, separator = node.Or, " OR "
+	}
+
+	conditions := make([]string, len(children))
+	var args []interface{}
+	for i := range children {
+		condition, childArgs, err := filterTreeCondition(d, filterFields, &children[i], placeholder, depth+1)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions[i] = "(" + condition + ")"
+		args = append(args, childArgs...)
+	}
+	return strings.Join(conditions, separator), args, nil
+}
+
+// filterValues 返回列表操作符的取值，字符串按逗号分隔
+func filterValues(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return v
+	case []string:
+		values := make([]interface{}, len(v))
+		for i, item := range v {
+			values[i] = item
+		}
+		return values
+	case string:
+		var values []interface{}
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		return values
+	}
+	return []interface{}{value}
+}
@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/dialect"
+)
+
+// jsonPathSegment 匹配 JSON 路径中的键或数组下标
+var jsonPathSegment = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// ParseJSONPath 把指向 JSON 列中取值的字段（如 attrs.color 或 meta->tags->0）拆分为列和列下的路径；
+// 普通列，以及路径中含有字母、数字和下划线以外字符的字段，路径为 nil
+func ParseJSONPath(field string) (string, []string) {
+	separator := "."
+	if strings.Contains(field, "->") {
+		separator = "->"
+		field = strings.ReplaceAll(field, "->>", "->")
+	} else if !strings.Contains(field, ".") {
+		return field, nil
+	}
+
+	parts := strings.Split(field, separator)
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+		if !jsonPathSegment.MatchString(parts[i]) {
+			return field, nil
+		}
+	}
+	return parts[0], parts[1:]
+}
+
+// IsJSONPath 判断字段是否指向 JSON 列中的取值
+func IsJSONPath(field string) bool {
+	_, path := ParseJSONPath(field)
+	return len(path) > 0
+}
+
+// ColumnExpression 返回字段的 SQL 表达式：已引用的列，或以文本读取的 JSON 路径上的值
+func ColumnExpression(d dialect.Dialect, field string) string {
+	column, path := ParseJSONPath(field)
+	if len(path) == 0 {
+		return d.QuoteIdentifier(field)
+	}
+	return d.JSONExtractText(d.QuoteIdentifier(column), path)
+}
+
+// JSONContainsCondition 返回字段的 JSON 路径上的值等于 placeholder 绑定的 JSON 文档，或者是包含该文档的数组的条件；
+// 普通列 ok 为 false
+func JSONContainsCondition(d dialect.Dialect, field, placeholder string) (string, bool) {
+	column, path := ParseJSONPath(field)
+	if len(path) == 0 {
+		return "", false
+	}
+	return d.JSONContains(d.QuoteIdentifier(column), path, placeholder), true
+}
+
+// fieldColumn 返回字段所在的列，JSON 路径返回其 JSON 列
+func fieldColumn(field string) string {
+	column, _ := ParseJSONPath(field)
+	return column
+}
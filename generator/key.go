@@ -0,0 +1,159 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/types"
+)
+
+// ErrInvalidRecordKey 记录主键与表的主键列不匹配
+var ErrInvalidRecordKey = errors.New("invalid record key")
+
+// ErrRecordNotFound 没有与主键匹配的记录
+var ErrRecordNotFound = errors.New("record not found")
+
+// RecordKeySegment 是 URL 路径中仍然转义的记录主键：单列主键的值，或编码后的联合主键（见 EncodeRecordKey）
+type RecordKeySegment string
+
+// PrimaryKeyColumns 返回配置的主键，未配置时返回表结构中声明的主键
+func PrimaryKeyColumns(schema *types.TableSchema, configured []string) []string {
+	if len(configured) > 0 {
+		return configured
+	}
+	if schema == nil {
+		return nil
+	}
+	if len(schema.PrimaryKey) > 0 {
+		return schema.PrimaryKey
+	}
+
+	var columns []string
+	for _, field := range schema.Fields {
+		if field.PrimaryKey {
+			columns = append(columns, field.Name)
+		}
+	}
+	return columns
+}
+
+// ResolveRecordKey 把记录主键转换为各主键列的取值。id 可以是列名到取值的映射、单列主键的值，
+// 或编码后的联合主键路径段（见 EncodeRecordKey）
+func ResolveRecordKey(primaryKey []string, id interface{}) (map[string]interface{}, error) {
+	if len(primaryKey) == 0 {
+		return nil, fmt.Errorf("%w: the table has no primary key", ErrInvalidRecordKey)
+	}
+
+	key := make(map[string]interface{}, len(primaryKey))
+
+	switch value := id.(type) {
+	case nil:
+		return nil, fmt.Errorf("%w: the key is empty", ErrInvalidRecordKey)
+	case map[string]interface{}:
+		for _, column := range primaryKey {
+			columnValue, exists := value[column]
+			if !exists {
+				return nil, fmt.Errorf("%w: missing value for primary key column '%s'", ErrInvalidRecordKey, column)
+			}
+			key[column] = keyValue(columnValue)
+		}
+	case RecordKeySegment:
+		if len(primaryKey) == 1 {
+			unescaped, err := url.PathUnescape(string(value))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidRecordKey, err)
+			}
+			key[primaryKey[0]] = keyValue(unescaped)
+			break
+		}
+		if err := decodeCompositeKey(key, primaryKey, string(value)); err != nil {
+			return nil, err
+		}
+	case string:
+		if len(primaryKey) == 1 {
+			key[primaryKey[0]] = keyValue(value)
+			break
+		}
+		if err := decodeCompositeKey(key, primaryKey, value); err != nil {
+			return nil, err
+		}
+	default:
+		if len(primaryKey) > 1 {
+			return nil, fmt.Errorf("%w: a composite key (%s) needs a value for each column",
+				ErrInvalidRecordKey, strings.Join(primaryKey, ", "))
+		}
+		key[primaryKey[0]] = value
+	}
+
+	return key, nil
+}
+
+// RecordKeyOf 返回记录的主键：单列主键返回其值，联合主键返回列名到取值的映射
+func RecordKeyOf(primaryKey []string, record map[string]interface{}) interface{} {
+	switch len(primaryKey) {
+	case 0:
+		return record["id"]
+	case 1:
+		return record[primaryKey[0]]
+	}
+
+	key := make(map[string]interface{}, len(primaryKey))
+	for _, column := range primaryKey {
+		key[column] = record[column]
+	}
+	return key
+}
+
+// EncodeRecordKey 把联合主键的取值编码为一个 URL 路径段：每个值分别转义，按主键顺序用逗号连接
+func EncodeRecordKey(values ...interface{}) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = url.PathEscape(fmt.Sprint(value))
+	}
+	return strings.Join(parts, ",")
+}
+
+// DecodeRecordKey 把编码后的主键路径段拆分为各列的取值
+func DecodeRecordKey(segment string) ([]string, error) {
+	parts := strings.Split(segment, ",")
+	values := make([]string, len(parts))
+	for i, part := range parts {
+		value, err := url.PathUnescape(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRecordKey, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// decodeCompositeKey 解析编码后的联合主键路径段，把各列的取值写入 key
+func decodeCompositeKey(key map[string]interface{}, primaryKey []string, segment string) error {
+	values, err := DecodeRecordKey(segment)
+	if err != nil {
+		return err
+	}
+	if len(values) != len(primaryKey) {
+		return fmt.Errorf("%w: expected %d values for (%s), got %d",
+			ErrInvalidRecordKey, len(primaryKey), strings.Join(primaryKey, ", "), len(values))
+	}
+	for i, column := range primaryKey {
+		key[column] = keyValue(values[i])
+	}
+	return nil
+}
+
+// keyValue 从 URL 读取的主键值是整数写法时转换为整数
+func keyValue(value interface{}) interface{} {
+	str, ok := value.(string)
+	if !ok {
+		return value
+	}
+	if number, err := strconv.ParseInt(str, 10, 64); err == nil && strconv.FormatInt(number, 10) == str {
+		return number
+	}
+	return str
+}
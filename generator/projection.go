@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/dialect"
+	"github.com/otkinlife/crud-generator/types"
+)
+
+// ErrInvalidField 请求的字段是隐藏字段或不在返回结果中
+var ErrInvalidField = errors.New("invalid field")
+
+// HiddenFields 返回始终不查询的列
+func HiddenFields(config *types.Config) []string {
+	if config == nil || config.QueryConfig == nil {
+		return nil
+	}
+	return config.QueryConfig.HiddenFields
+}
+
+// ListColumns 返回列表查询的列：展示字段加主键，未配置展示字段时为 tableColumns 的全部列。
+// requested 进一步限定返回的列，主键始终保留以便定位记录；隐藏字段始终不查询，没有任何限制时返回 nil 表示全部列
+func ListColumns(config *types.Config, primaryKey, tableColumns, requested []string) ([]string, error) {
+	var columns []string
+	if config != nil && config.QueryConfig != nil && len(config.QueryConfig.DisplayFields) > 0 {
+		for _, field := range config.QueryConfig.DisplayFields {
+			columns = appendColumn(columns, field.Field)
+		}
+		for _, column := range primaryKey {
+			columns = appendColumn(columns, column)
+		}
+	}
+	return projectColumns(columns, tableColumns, requested, primaryKey, HiddenFields(config))
+}
+
+// projectColumns 把 columns（为空时为 tableColumns 的全部列）限定为 requested 加 keep，再移除隐藏字段
+func projectColumns(columns, tableColumns, requested, keep, hidden []string) ([]string, error) {
+	if len(requested) == 0 && len(hidden) == 0 {
+		return columns, nil
+	}
+	if len(columns) == 0 {
+		columns = tableColumns
+	}
+
+	isHidden := make(map[string]bool, len(hidden))
+	for _, column := range hidden {
+		isHidden[column] = true
+	}
+
+	if len(requested) > 0 {
+		allowed := make(map[string]bool, len(columns))
+		for _, column := range columns {
+			allowed[column] = true
+		}
+
+		var narrowed []string
+		for _, field := range requested {
+			// 不知道表的全部列时只能拒绝隐藏字段
+			if isHidden[fieldColumn(field)] || (len(columns) > 0 && !allowed[field]) {
+				return nil, fmt.Errorf("%w: field '%s' cannot be selected", ErrInvalidField, field)
+			}
+			narrowed = appendColumn(narrowed, field)
+		}
+		for _, column := range keep {
+			narrowed = appendColumn(narrowed, column)
+		}
+		columns = narrowed
+	}
+
+	if len(hidden) == 0 {
+		return columns, nil
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("cannot hide columns, the columns of the table are unknown")
+	}
+
+	visible := make([]string, 0, len(columns))
+	for _, column := range columns {
+		// JSON 路径随其所在的列一起隐藏
+		if !isHidden[fieldColumn(column)] {
+			visible = append(visible, column)
+		}
+	}
+	return visible, nil
+}
+
+func appendColumn(columns []string, column string) []string {
+	if column == "" {
+		return columns
+	}
+	for _, existing := range columns {
+		if existing == column {
+			return columns
+		}
+	}
+	return append(columns, column)
+}
+
+// schemaColumns 返回表结构中的列名
+func schemaColumns(schema *types.TableSchema) []string {
+	if schema == nil {
+		return nil
+	}
+	columns := make([]string, len(schema.Fields))
+	for i, field := range schema.Fields {
+		columns[i] = field.Name
+	}
+	return columns
+}
+
+// SelectList 返回已引用的查询列列表，columns 为空时返回 "*"；JSON 路径以文本查询，
+// 并以字段名作为列名，例如 "attrs"->>'color' AS "attrs.color"
+func SelectList(d dialect.Dialect, columns []string) string {
+	if len(columns) == 0 {
+		return "*"
+	}
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		if IsJSONPath(column) {
+			quoted[i] = ColumnExpression(d, column) + " AS " + d.QuoteAlias(column)
+			continue
+		}
+		quoted[i] = d.QuoteIdentifier(column)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -1,27 +1,36 @@
 package generator
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/otkinlife/crud-generator/dialect"
 	"github.com/otkinlife/crud-generator/types"
 )
 
 type QueryGenerator struct {
-	schema *types.TableSchema
-	config *types.Config
+	schema  *types.TableSchema
+	config  *types.Config
+	dialect dialect.Dialect
 }
 
-func NewQueryGenerator(schema *types.TableSchema, config *types.Config) *QueryGenerator {
+func NewQueryGenerator(schema *types.TableSchema, config *types.Config, d dialect.Dialect) *QueryGenerator {
 	return &QueryGenerator{
-		schema: schema,
-		config: config,
+		schema:  schema,
+		config:  config,
+		dialect: d,
 	}
 }
 
 func (g *QueryGenerator) GenerateQuery(params types.QueryParams) (string, string, []interface{}, error) {
-	baseQuery := fmt.Sprintf("SELECT * FROM %s", g.schema.TableName)
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", g.schema.TableName)
+	tableName := g.dialect.QuoteIdentifier(g.schema.TableName)
+	columns, err := ListColumns(g.config, PrimaryKeyColumns(g.schema, g.config.PrimaryKey), schemaColumns(g.schema), params.Fields)
+	if err != nil {
+		return "", "", nil, err
+	}
+	baseQuery := fmt.Sprintf("SELECT %s FROM %s", SelectList(g.dialect, columns), tableName)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)
 
 	var whereConditions []string
 	var args []interface{}
@@ -37,6 +46,24 @@ func (g *QueryGenerator) GenerateQuery(params types.QueryParams) (string, string
 		argIndex = newArgIndex
 	}
 
+	if len(params.Filters) > 0 {
+		conditions, filterArgs, err := g.buildFilterConditions(params.Filters, &argIndex)
+		if err != nil {
+			return "", "", nil, err
+		}
+		whereConditions = append(whereConditions, conditions...)
+		args = append(args, filterArgs...)
+	}
+
+	if params.Where != nil {
+		condition, whereArgs, err := g.buildFilterTreeCondition(params.Where, &argIndex)
+		if err != nil {
+			return "", "", nil, err
+		}
+		whereConditions = append(whereConditions, "("+condition+")")
+		args = append(args, whereArgs...)
+	}
+
 	var whereClause string
 	if len(whereConditions) > 0 {
 		whereClause = " WHERE " + strings.Join(whereConditions, " AND ")
@@ -46,16 +73,20 @@ func (g *QueryGenerator) GenerateQuery(params types.QueryParams) (string, string
 
 	var orderClause string
 	if len(params.Sort) > 0 && g.config.QueryConfig != nil {
-		//orderClause, err := g.buildOrderClause(params.Sort)
-		//if err != nil {
-		//	return "", "", nil, fmt.Errorf("failed to build order clause: %w", err)
-		//}
+		var err error
+		orderClause, err = g.buildOrderClause(params.Sort)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("failed to build order clause: %w", err)
+		}
+	} else if g.config.QueryConfig != nil && len(g.config.QueryConfig.DefaultSort) > 0 {
+		// 配置的默认排序不受可排序字段限制
+		orderClause = g.orderClause(g.config.QueryConfig.DefaultSort)
 	}
 
 	var limitClause string
 	if g.config.QueryConfig != nil && g.config.QueryConfig.Pagination {
 		offset := (params.Page - 1) * params.PageSize
-		limitClause = fmt.Sprintf(" LIMIT %d OFFSET %d", params.PageSize, offset)
+		limitClause = g.dialect.LimitOffset(params.PageSize, offset)
 	}
 
 	fullQuery := baseQuery + whereClause + orderClause + limitClause
@@ -103,34 +134,45 @@ func (g *QueryGenerator) buildSearchConditions(search map[string]interface{}, st
 func (g *QueryGenerator) buildFieldCondition(fieldName string, value interface{}, searchField types.SearchField, argIndex int) (string, []interface{}, int, error) {
 	var condition string
 	var args []interface{}
+	column := ColumnExpression(g.dialect, fieldName)
 
 	switch searchField.Type {
 	case types.SearchTypeFuzzy:
 		strValue := fmt.Sprintf("%v", value)
 		if strValue != "" {
-			condition = fmt.Sprintf("%s ILIKE $%d", fieldName, argIndex)
+			condition = fmt.Sprintf("%s %s %s", column, g.dialect.CaseInsensitiveLike(), g.dialect.Placeholder(argIndex))
 			args = append(args, "%"+strValue+"%")
 			argIndex++
 		}
 
 	case types.SearchTypeExact:
-		condition = fmt.Sprintf("%s = $%d", fieldName, argIndex)
+		condition = fmt.Sprintf("%s = %s", column, g.dialect.Placeholder(argIndex))
 		args = append(args, value)
 		argIndex++
+		// JSON 路径上的数组包含该值时也匹配
+		if contains, ok := JSONContainsCondition(g.dialect, fieldName, g.dialect.Placeholder(argIndex)); ok {
+			document, err := json.Marshal(value)
+			if err != nil {
+				return "", nil, argIndex, fmt.Errorf("failed to encode search value: %w", err)
+			}
+			condition = fmt.Sprintf("(%s OR %s)", condition, contains)
+			args = append(args, string(document))
+			argIndex++
+		}
 
 	case types.SearchTypeMulti:
 		if valueSlice, ok := value.([]interface{}); ok && len(valueSlice) > 0 {
 			placeholders := make([]string, len(valueSlice))
 			for i, v := range valueSlice {
-				placeholders[i] = fmt.Sprintf("$%d", argIndex)
+				placeholders[i] = g.dialect.Placeholder(argIndex)
 				args = append(args, v)
 				argIndex++
 			}
-			condition = fmt.Sprintf("%s IN (%s)", fieldName, strings.Join(placeholders, ", "))
+			condition = fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ", "))
 		}
 
 	case types.SearchTypeSingle:
-		condition = fmt.Sprintf("%s = $%d", fieldName, argIndex)
+		condition = fmt.Sprintf("%s = %s", column, g.dialect.Placeholder(argIndex))
 		args = append(args, value)
 		argIndex++
 
@@ -139,13 +181,13 @@ func (g *QueryGenerator) buildFieldCondition(fieldName string, value interface{}
 			var rangeConds []string
 
 			if minVal, exists := rangeMap["min"]; exists && minVal != nil {
-				rangeConds = append(rangeConds, fmt.Sprintf("%s >= $%d", fieldName, argIndex))
+				rangeConds = append(rangeConds, fmt.Sprintf("%s >= %s", column, g.dialect.Placeholder(argIndex)))
 				args = append(args, minVal)
 				argIndex++
 			}
 
 			if maxVal, exists := rangeMap["max"]; exists && maxVal != nil {
-				rangeConds = append(rangeConds, fmt.Sprintf("%s <= $%d", fieldName, argIndex))
+				rangeConds = append(rangeConds, fmt.Sprintf("%s <= %s", column, g.dialect.Placeholder(argIndex)))
 				args = append(args, maxVal)
 				argIndex++
 			}
@@ -155,6 +197,25 @@ func (g *QueryGenerator) buildFieldCondition(fieldName string, value interface{}
 			}
 		}
 
+	case types.SearchTypeFulltext:
+		text := strings.TrimSpace(fmt.Sprintf("%v", value))
+		if text != "" {
+			condition = g.dialect.FullTextMatch(FullTextColumns(searchField), searchField.Language, g.dialect.Placeholder(argIndex))
+			args = append(args, text)
+			argIndex++
+		}
+
+	case types.SearchTypeContains, types.SearchTypeOverlaps:
+		arrayCondition, literal, ok, err := ArrayCondition(g.dialect, searchField, value, g.dialect.Placeholder(argIndex))
+		if err != nil {
+			return "", nil, argIndex, err
+		}
+		if ok {
+			condition = arrayCondition
+			args = append(args, literal)
+			argIndex++
+		}
+
 	default:
 		return "", nil, argIndex, fmt.Errorf("unsupported search type: %s", searchField.Type)
 	}
@@ -162,36 +223,126 @@ func (g *QueryGenerator) buildFieldCondition(fieldName string, value interface{}
 	return condition, args, argIndex, nil
 }
 
+// FullTextColumns 返回全文检索字段检索的列
+func FullTextColumns(searchField types.SearchField) []string {
+	if len(searchField.Columns) > 0 {
+		return searchField.Columns
+	}
+	return []string{searchField.Field}
+}
+
+// buildFilterConditions 按配置的过滤字段校验过滤条件并生成条件
+func (g *QueryGenerator) buildFilterConditions(filters []types.Filter, argIndex *int) ([]string, []interface{}, error) {
+	var filterFields []types.FilterField
+	if g.config.QueryConfig != nil {
+		filterFields = g.config.QueryConfig.FilterFields
+	}
+
+	placeholder := func() string {
+		p := g.dialect.Placeholder(*argIndex)
+		*argIndex++
+		return p
+	}
+
+	var conditions []string
+	var args []interface{}
+	for _, filter := range filters {
+		if err := CheckFilter(filterFields, filter); err != nil {
+			return nil, nil, err
+		}
+		condition, filterArgs, err := FilterCondition(g.dialect, filter, placeholder)
+		if err != nil {
+			return nil, nil, err
+		}
+		conditions = append(conditions, condition)
+		args = append(args, filterArgs...)
+	}
+
+	return conditions, args, nil
+}
+
+// buildFilterTreeCondition 编译组合过滤条件，叶子条件的校验与 buildFilterConditions 相同
+func (g *QueryGenerator) buildFilterTreeCondition(node *types.FilterNode, argIndex *int) (string, []interface{}, error) {
+	var filterFields []types.FilterField
+	if g.config.QueryConfig != nil {
+		filterFields = g.config.QueryConfig.FilterFields
+	}
+
+	return FilterTreeCondition(g.dialect, filterFields, node, func() string {
+		p := g.dialect.Placeholder(*argIndex)
+		*argIndex++
+		return p
+	})
+}
+
 func (g *QueryGenerator) buildOrderClause(sorts []types.SortField) (string, error) {
 	if len(sorts) == 0 {
 		return "", nil
 	}
 
-	var orderParts []string
-	sortableFields := make(map[string]bool)
-
+	var sortableFields []string
 	if g.config.QueryConfig != nil {
-		for _, field := range g.config.QueryConfig.SortableFields {
-			sortableFields[field] = true
-		}
+		sortableFields = g.config.QueryConfig.SortableFields
+	}
+	if err := ValidateSort(sortableFields, sorts); err != nil {
+		return "", err
 	}
 
+	return g.orderClause(sorts), nil
+}
+
+// ValidateSort 按配置的可排序字段校验请求的排序字段：只能按列出的字段排序，列表为空时不能排序
+func ValidateSort(sortableFields []string, sorts []types.SortField) error {
 	for _, sort := range sorts {
-		if !sortableFields[sort.Field] {
-			return "", fmt.Errorf("field %s is not sortable", sort.Field)
+		allowed := false
+		for _, field := range sortableFields {
+			if field == sort.Field {
+				allowed = true
+				break
+			}
 		}
-
-		order := string(sort.Order)
-		if order == "" {
-			order = string(types.SortOrderASC)
+		if !allowed {
+			return fmt.Errorf("field '%s' is not sortable", sort.Field)
 		}
 
-		if order != string(types.SortOrderASC) && order != string(types.SortOrderDESC) {
-			return "", fmt.Errorf("invalid sort order: %s", order)
+		order := strings.ToUpper(string(sort.Order))
+		if order != "" && order != string(types.SortOrderASC) && order != string(types.SortOrderDESC) {
+			return fmt.Errorf("invalid sort order: %s", sort.Order)
 		}
+	}
+	return nil
+}
 
-		orderParts = append(orderParts, fmt.Sprintf("%s %s", sort.Field, order))
+// orderClause 生成无需校验的排序字段的 ORDER BY 子句
+func (g *QueryGenerator) orderClause(sorts []types.SortField) string {
+	orderParts := make([]string, len(sorts))
+	for i, sort := range sorts {
+		order := types.SortOrderASC
+		if strings.EqualFold(string(sort.Order), string(types.SortOrderDESC)) {
+			order = types.SortOrderDESC
+		}
+		orderParts[i] = fmt.Sprintf("%s %s", ColumnExpression(g.dialect, sort.Field), order)
 	}
+	return " ORDER BY " + strings.Join(orderParts, ", ")
+}
 
-	return " ORDER BY " + strings.Join(orderParts, ", "), nil
+// ParseSort 解析 "status,-created_at" 形式的排序表达式：字段用逗号分隔，
+// 前缀 "-" 表示降序，"+" 或没有前缀表示升序
+func ParseSort(expression string) []types.SortField {
+	var sorts []types.SortField
+	for _, part := range strings.Split(expression, ",") {
+		part = strings.TrimSpace(part)
+		order := types.SortOrderASC
+		switch {
+		case strings.HasPrefix(part, "-"):
+			order = types.SortOrderDESC
+			part = strings.TrimSpace(part[1:])
+		case strings.HasPrefix(part, "+"):
+			part = strings.TrimSpace(part[1:])
+		}
+		if part != "" {
+			sorts = append(sorts, types.SortField{Field: part, Order: order})
+		}
+	}
+	return sorts
 }
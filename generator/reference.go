@@ -0,0 +1,26 @@
+package generator
+
+import "github.com/otkinlife/crud-generator/types"
+
+// referenceLabelSuffix 追加在关联字段之后，作为记录和搜索中标签的名称
+const referenceLabelSuffix = "_label"
+
+// ReferenceLabelKey 返回记录中保存关联字段标签的键，例如 customer_id_label
+func ReferenceLabelKey(field string) string {
+	return field + referenceLabelSuffix
+}
+
+// ReferenceFields 返回关联其他表的展示字段
+func ReferenceFields(config *types.Config) []types.DisplayField {
+	if config == nil || config.QueryConfig == nil {
+		return nil
+	}
+
+	var fields []types.DisplayField
+	for _, field := range config.QueryConfig.DisplayFields {
+		if field.Reference != nil && field.Reference.LabelField != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
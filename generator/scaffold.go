@@ -0,0 +1,208 @@
+package generator
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/types"
+)
+
+// ScaffoldConfig 根据解析或读取的表结构生成默认的表配置
+func ScaffoldConfig(schema *types.TableSchema) *types.Config {
+	queryConfig := &types.QueryConfig{
+		Pagination: true,
+	}
+	createConfig := &types.CreateConfig{}
+	updateConfig := &types.UpdateConfig{}
+
+	for _, field := range schema.Fields {
+		label := scaffoldLabel(field)
+		helpText := scaffoldHelpText(field, label)
+
+		queryConfig.DisplayFields = append(queryConfig.DisplayFields, types.DisplayField{
+			Field:    field.Name,
+			Label:    label,
+			Sortable: isSortableType(field.Type),
+		})
+
+		if isSortableType(field.Type) {
+			queryConfig.SortableFields = append(queryConfig.SortableFields, field.Name)
+		}
+
+		if searchField, ok := scaffoldSearchField(field); ok {
+			queryConfig.SearchFields = append(queryConfig.SearchFields, searchField)
+		}
+
+		// 主键、自增和只读字段由数据库生成，不允许创建和更新
+		if field.PrimaryKey || field.AutoIncrement || field.ReadOnly {
+			continue
+		}
+
+		inputType := scaffoldInputType(field.Type)
+		options := scaffoldOptions(field)
+		required := field.NotNull && field.DefaultValue == nil
+		validation := scaffoldValidation(field)
+
+		createConfig.CreatableFields = append(createConfig.CreatableFields, types.CreatableField{
+			Field:      field.Name,
+			Label:      label,
+			HelpText:   helpText,
+			Type:       inputType,
+			Required:   required,
+			Validation: validation,
+			Options:    options,
+		})
+
+		updateConfig.UpdatableFields = append(updateConfig.UpdatableFields, types.UpdatableField{
+			Field:      field.Name,
+			Label:      label,
+			HelpText:   helpText,
+			Type:       inputType,
+			Required:   required,
+			Validation: validation,
+			Options:    options,
+		})
+	}
+
+	return &types.Config{
+		TableName:    schema.TableName,
+		QueryConfig:  queryConfig,
+		CreateConfig: createConfig,
+		UpdateConfig: updateConfig,
+	}
+}
+
+// scaffoldLabel 使用注释的第一句作为标签，例如 "状态：0 禁用，1 启用" 得到 "状态"
+func scaffoldLabel(field types.TableField) string {
+	comment := strings.TrimSpace(field.Comment)
+	if idx := strings.IndexAny(comment, ":：,，;；(（\n"); idx >= 0 {
+		comment = strings.TrimSpace(comment[:idx])
+	}
+	if comment != "" {
+		return comment
+	}
+	return field.Name
+}
+
+// scaffoldHelpText 注释比标签包含更多信息时作为表单帮助文本
+func scaffoldHelpText(field types.TableField, label string) string {
+	comment := strings.TrimSpace(field.Comment)
+	if comment == label {
+		return ""
+	}
+	return comment
+}
+
+func scaffoldSearchField(field types.TableField) (types.SearchField, bool) {
+	switch {
+	case field.Type == types.PostgreSQLTypeEnum:
+		return types.SearchField{
+			Field:      field.Name,
+			Type:       types.SearchTypeSingle,
+			DictSource: scaffoldDictSource(field.EnumValues),
+		}, true
+	case field.Type == types.PostgreSQLTypeArray:
+		return types.SearchField{Field: field.Name, Type: types.SearchTypeOverlaps}, true
+	case isTextType(field.Type):
+		return types.SearchField{Field: field.Name, Type: types.SearchTypeFuzzy}, true
+	case isNumericType(field.Type):
+		return types.SearchField{Field: field.Name, Type: types.SearchTypeRange}, true
+	case isDateType(field.Type):
+		return types.SearchField{Field: field.Name, Type: types.SearchTypeDateRange}, true
+	case field.Type == types.PostgreSQLTypeUUID, field.Type == types.PostgreSQLTypeInet,
+		field.Type == types.PostgreSQLTypeCidr, field.Type == types.PostgreSQLTypeMacaddr:
+		return types.SearchField{Field: field.Name, Type: types.SearchTypeExact}, true
+	}
+	return types.SearchField{}, false
+}
+
+func scaffoldInputType(fieldType types.PostgreSQLType) string {
+	switch {
+	case fieldType == types.PostgreSQLTypeText:
+		return "textarea"
+	case fieldType == types.PostgreSQLTypeJSON, fieldType == types.PostgreSQLTypeJSONB:
+		return "json"
+	case fieldType == types.PostgreSQLTypeArray:
+		return "tags"
+	case fieldType == types.PostgreSQLTypeBoolean:
+		return "checkbox"
+	case fieldType == types.PostgreSQLTypeEnum:
+		return "select"
+	case fieldType == types.PostgreSQLTypeDate:
+		return "date"
+	case isDateType(fieldType):
+		return "datetime"
+	case isNumericType(fieldType):
+		return "number"
+	}
+	return "text"
+}
+
+// scaffoldDictSource 枚举值写成 JSON 数组，单个值的枚举不会被当作按字段查询的字典
+func scaffoldDictSource(values []string) string {
+	encoded, _ := json.Marshal(values)
+	return string(encoded)
+}
+
+func scaffoldOptions(field types.TableField) []types.SelectOption {
+	var options []types.SelectOption
+	for _, value := range field.EnumValues {
+		options = append(options, types.SelectOption{Value: value, Label: value})
+	}
+	return options
+}
+
+func scaffoldValidation(field types.TableField) *types.FieldValidation {
+	if !isTextType(field.Type) {
+		return nil
+	}
+
+	validation := &types.FieldValidation{}
+	if field.Length > 0 {
+		maxLength := field.Length
+		validation.MaxLength = &maxLength
+	}
+	if field.NotNull && field.DefaultValue == nil {
+		minLength := 1
+		validation.MinLength = &minLength
+	}
+
+	if validation.MaxLength == nil && validation.MinLength == nil {
+		return nil
+	}
+	return validation
+}
+
+func isTextType(fieldType types.PostgreSQLType) bool {
+	switch fieldType {
+	case types.PostgreSQLTypeText, types.PostgreSQLTypeVarchar, types.PostgreSQLTypeChar, types.PostgreSQLTypeCitext:
+		return true
+	}
+	return false
+}
+
+func isNumericType(fieldType types.PostgreSQLType) bool {
+	switch fieldType {
+	case types.PostgreSQLTypeInteger, types.PostgreSQLTypeBigint, types.PostgreSQLTypeSmallint,
+		types.PostgreSQLTypeNumeric, types.PostgreSQLTypeReal, types.PostgreSQLTypeDouble, types.PostgreSQLTypeMoney:
+		return true
+	}
+	return false
+}
+
+func isDateType(fieldType types.PostgreSQLType) bool {
+	switch fieldType {
+	case types.PostgreSQLTypeDate, types.PostgreSQLTypeTimestamp, types.PostgreSQLTypeTimestampTZ:
+		return true
+	}
+	return false
+}
+
+func isSortableType(fieldType types.PostgreSQLType) bool {
+	switch fieldType {
+	case types.PostgreSQLTypeJSON, types.PostgreSQLTypeJSONB, types.PostgreSQLTypeBytea, types.PostgreSQLTypeArray,
+		types.PostgreSQLTypeTSVector:
+		return false
+	}
+	return true
+}
@@ -2,19 +2,28 @@ package crudgen
 
 import (
 	"embed"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/otkinlife/crud-generator/generator"
 	"github.com/otkinlife/crud-generator/middleware"
+	"github.com/otkinlife/crud-generator/types"
 )
 
 //go:embed webui/*
 var webuiFS embed.FS
 
+// filterParamPattern matches filter[field][operator] and filter[field] query parameters
+var filterParamPattern = regexp.MustCompile(`^filter\[([^\]]+)\](?:\[([^\]]+)\])?$`)
+
 // registerAPIRoutes registers all API routes
 func (cg *CRUDGenerator) registerAPIRoutes(router *gin.Engine) {
 	// Apply global middlewares first
@@ -60,6 +69,7 @@ func (cg *CRUDGenerator) registerAPIRoutes(router *gin.Engine) {
 		api.GET("/connections", cg.handleListConnections)
 		api.POST("/connections/:id/test", cg.handleTestConnection)
 		api.POST("/connections/:id/test-table", cg.handleTestConnectionWithTable)
+		api.GET("/connections/:id/tables/:table", cg.handleGetTableInfo)
 
 		// Table configurations
 		configs := api.Group("/configs")
@@ -67,11 +77,13 @@ func (cg *CRUDGenerator) registerAPIRoutes(router *gin.Engine) {
 		{
 			configs.GET("", cg.handleListConfigs)
 			configs.POST("", cg.handleCreateConfig)
+			configs.POST("/scaffold", cg.handleScaffoldConfig)
 			configs.GET("/by-name/:name", cg.handleGetConfigByName)
 			configs.GET("/:id", cg.handleGetConfig)
 			configs.PUT("/:id", cg.handleUpdateConfig)
 			configs.DELETE("/:id", cg.handleDeleteConfig)
 			configs.POST("/:id/test", cg.handleTestConfigConnection)
+			configs.GET("/:id/drift", cg.handleConfigDrift)
 		}
 
 		// CRUD operations
@@ -79,10 +91,22 @@ func (cg *CRUDGenerator) registerAPIRoutes(router *gin.Engine) {
 		cg.applyRouteMiddlewares(crudRoutes, "/crud")
 		{
 			crudRoutes.GET("/list", cg.handleCRUDList)
+			crudRoutes.GET("/aggregate", cg.handleCRUDAggregate)
+			crudRoutes.GET("/facets", cg.handleCRUDFacets)
+			crudRoutes.GET("/get/:id", cg.handleCRUDGet)
+			crudRoutes.GET("/get/:id/children/:relation", cg.handleCRUDChildren)
+			crudRoutes.GET("/get", cg.handleCRUDGet)
 			crudRoutes.POST("/create", cg.handleCRUDCreate)
 			crudRoutes.PUT("/update/:id", cg.handleCRUDUpdate)
+			crudRoutes.PUT("/update", cg.handleCRUDUpdate)
 			crudRoutes.DELETE("/delete/:id", cg.handleCRUDDelete)
+			crudRoutes.DELETE("/delete", cg.handleCRUDDelete)
 			crudRoutes.GET("/dict/:field", cg.handleCRUDDict)
+			crudRoutes.GET("/views", cg.handleListViews)
+			crudRoutes.POST("/views", cg.handleCreateView)
+			crudRoutes.GET("/views/:name", cg.handleGetView)
+			crudRoutes.PUT("/views/:name", cg.handleUpdateView)
+			crudRoutes.DELETE("/views/:name", cg.handleDeleteView)
 		}
 	}
 }
@@ -328,13 +352,39 @@ func (cg *CRUDGenerator) handleTestConnectionWithTable(c *gin.Context) {
 		return
 	}
 
-	// TODO: Test table access
+	if err := cg.services.ConfigService.TestConnectionWithTable(connectionID, request.TableName); err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
 	c.JSON(200, APIResponse{
 		Success: true,
 		Message: "Table access test successful",
 	})
 }
 
+func (cg *CRUDGenerator) handleGetTableInfo(c *gin.Context) {
+	connectionID := c.Param("id")
+	tableName := c.Param("table")
+
+	info, err := cg.GetTableInfo(connectionID, tableName)
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Data:    info,
+	})
+}
+
 // Config handlers
 func (cg *CRUDGenerator) handleListConfigs(c *gin.Context) {
 	connectionID := c.Query("connection_id")
@@ -379,6 +429,31 @@ func (cg *CRUDGenerator) handleCreateConfig(c *gin.Context) {
 	})
 }
 
+func (cg *CRUDGenerator) handleScaffoldConfig(c *gin.Context) {
+	var request ConfigScaffoldRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	config, err := cg.services.ConfigService.ScaffoldConfig(request.ConnectionID, request.TableName, request.CreateStatement)
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Data:    config,
+	})
+}
+
 func (cg *CRUDGenerator) handleGetConfigByName(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
@@ -514,11 +589,181 @@ func (cg *CRUDGenerator) handleTestConfigConnection(c *gin.Context) {
 	})
 }
 
+func (cg *CRUDGenerator) handleConfigDrift(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   "Invalid ID",
+		})
+		return
+	}
+
+	report, err := cg.DetectDrift(uint(id))
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Data:    report,
+	})
+}
+
 // CRUD operation handlers
 func (cg *CRUDGenerator) handleCRUDList(c *gin.Context) {
 	configName := c.Param("config_name")
 
-	// Parse query parameters
+	params, err := parseListParams(c)
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	result, err := cg.services.CRUDService.List(configName, params)
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// handleCRUDChildren lists the child records of a relation, taking the same parameters as list
+func (cg *CRUDGenerator) handleCRUDChildren(c *gin.Context) {
+	configName := c.Param("config_name")
+
+	params, err := parseListParams(c)
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	id, err := recordKey(c)
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	result, err := cg.services.CRUDService.ListChildren(configName, id, c.Param("relation"), params)
+	if err != nil {
+		status := 400
+		switch {
+		case errors.Is(err, ErrRecordNotFound), errors.Is(err, ErrRelationNotFound):
+			status = 404
+		}
+		c.JSON(status, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// handleCRUDAggregate groups and measures records: group_by=status,region and metrics=count,sum:amount,
+// with the same search and filter parameters as list
+func (cg *CRUDGenerator) handleCRUDAggregate(c *gin.Context) {
+	configName := c.Param("config_name")
+
+	listParams, err := parseListParams(c)
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	params := &AggregateParams{
+		Search:  listParams.Search,
+		Filters: listParams.Filters,
+		Where:   listParams.Where,
+	}
+	for _, field := range strings.Split(c.Query("group_by"), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			params.GroupBy = append(params.GroupBy, field)
+		}
+	}
+	for _, metric := range generator.ParseMetrics(c.Query("metrics")) {
+		params.Metrics = append(params.Metrics, Metric{Function: string(metric.Function), Field: metric.Field})
+	}
+
+	result, err := cg.services.CRUDService.Aggregate(configName, params)
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// handleCRUDFacets counts the records per dictionary item of the dict-backed search fields, with the
+// same search and filter parameters as list; fields=status,region narrows the counted fields
+func (cg *CRUDGenerator) handleCRUDFacets(c *gin.Context) {
+	configName := c.Param("config_name")
+
+	listParams, err := parseListParams(c)
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	result, err := cg.services.CRUDService.Facets(configName, &FacetParams{
+		Search:  listParams.Search,
+		Filters: listParams.Filters,
+		Where:   listParams.Where,
+		Fields:  listParams.Fields,
+	})
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// parseListParams reads paging, cursor, fields, filter, search and sort parameters of a list request
+func parseListParams(c *gin.Context) (*QueryParams, error) {
 	params := &QueryParams{}
 
 	if pageStr := c.Query("page"); pageStr != "" {
@@ -540,10 +785,48 @@ func (cg *CRUDGenerator) handleCRUDList(c *gin.Context) {
 		params.PageSize = 20
 	}
 
-	// Parse search parameters
+	// Cursor pagination is enabled by the cursor parameter, empty for the first page
+	if cursor, exists := c.GetQuery("cursor"); exists {
+		params.CursorMode = true
+		params.Cursor = cursor
+		params.SkipCount, _ = strconv.ParseBool(c.Query("skip_count"))
+	}
+
+	// fields=name,email narrows the returned columns
+	if fieldsParam := c.Query("fields"); fieldsParam != "" {
+		for _, field := range strings.Split(fieldsParam, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				params.Fields = append(params.Fields, field)
+			}
+		}
+	}
+
+	// where holds a JSON filter expression with and/or/not groups
+	if whereParam := c.Query("where"); whereParam != "" {
+		var where FilterNode
+		if err := json.Unmarshal([]byte(whereParam), &where); err != nil {
+			return nil, fmt.Errorf("Invalid where parameter: %w", err)
+		}
+		params.Where = &where
+	}
+
+	// Parse search parameters, filter[field][operator] parameters are filters
 	searchParams := make(map[string]interface{})
 	for key, values := range c.Request.URL.Query() {
-		if len(values) > 0 && !contains([]string{"page", "page_size", "sort", "order"}, key) {
+		if matches := filterParamPattern.FindStringSubmatch(key); matches != nil {
+			operator := matches[2]
+			if operator == "" {
+				operator = "eq"
+			}
+			// a repeated parameter gives the values of in, not_in and between
+			var value interface{} = values
+			if len(values) == 1 {
+				value = values[0]
+			}
+			params.Filters = append(params.Filters, Filter{Field: matches[1], Operator: operator, Value: value})
+			continue
+		}
+		if len(values) > 0 && !contains([]string{"page", "page_size", "sort", "order", "cursor", "skip_count", "where", "fields", "group_by", "metrics"}, key) {
 			searchParams[key] = values[0]
 		}
 	}
@@ -551,33 +834,24 @@ func (cg *CRUDGenerator) handleCRUDList(c *gin.Context) {
 		params.Search = searchParams
 	}
 
-	// Parse sort parameters
-	if sortField := c.Query("sort"); sortField != "" {
-		sortOrder := SortOrderASC
-		if order := c.Query("order"); order != "" && strings.ToLower(order) == "desc" {
-			sortOrder = SortOrderDESC
-		}
-		params.Sort = []SortField{
-			{
-				Field: sortField,
+	// Parse sort parameters: sort=status,-created_at sorts by several fields, "-" meaning descending.
+	// A single field without prefix may still take its direction from order=desc.
+	if sortParam := c.Query("sort"); sortParam != "" {
+		sorts := generator.ParseSort(sortParam)
+		explicitOrder := strings.ContainsAny(sortParam, ",+") || strings.HasPrefix(strings.TrimSpace(sortParam), "-")
+		for _, sort := range sorts {
+			sortOrder := SortOrderASC
+			if sort.Order == types.SortOrderDESC || (!explicitOrder && strings.ToLower(c.Query("order")) == "desc") {
+				sortOrder = SortOrderDESC
+			}
+			params.Sort = append(params.Sort, SortField{
+				Field: sort.Field,
 				Order: sortOrder,
-			},
+			})
 		}
 	}
 
-	result, err := cg.services.CRUDService.List(configName, params)
-	if err != nil {
-		c.JSON(400, APIResponse{
-			Success: false,
-			Error:   err.Error(),
-		})
-		return
-	}
-
-	c.JSON(200, APIResponse{
-		Success: true,
-		Data:    result,
-	})
+	return params, nil
 }
 
 func (cg *CRUDGenerator) handleCRUDCreate(c *gin.Context) {
@@ -615,16 +889,49 @@ func (cg *CRUDGenerator) handleCRUDCreate(c *gin.Context) {
 	})
 }
 
-func (cg *CRUDGenerator) handleCRUDUpdate(c *gin.Context) {
+func (cg *CRUDGenerator) handleCRUDGet(c *gin.Context) {
 	configName := c.Param("config_name")
-	idStr := c.Param("id")
 
-	// Try to convert ID to integer, if fails use as string
-	var id interface{}
-	if idInt, err := strconv.Atoi(idStr); err == nil {
-		id = idInt
-	} else {
-		id = idStr
+	id, err := recordKey(c)
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	record, err := cg.services.CRUDService.Get(configName, id)
+	if err != nil {
+		status := 500
+		switch {
+		case errors.Is(err, ErrRecordNotFound):
+			status = 404
+		case errors.Is(err, ErrInvalidRecordKey):
+			status = 400
+		}
+		c.JSON(status, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Data:    record,
+	})
+}
+
+func (cg *CRUDGenerator) handleCRUDUpdate(c *gin.Context) {
+	configName := c.Param("config_name")
+	id, err := recordKey(c)
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
 	}
 
 	var data map[string]interface{}
@@ -661,14 +968,13 @@ func (cg *CRUDGenerator) handleCRUDUpdate(c *gin.Context) {
 
 func (cg *CRUDGenerator) handleCRUDDelete(c *gin.Context) {
 	configName := c.Param("config_name")
-	idStr := c.Param("id")
-
-	// Try to convert ID to integer, if fails use as string
-	var id interface{}
-	if idInt, err := strconv.Atoi(idStr); err == nil {
-		id = idInt
-	} else {
-		id = idStr
+	id, err := recordKey(c)
+	if err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
 	}
 
 	result, err := cg.services.CRUDService.Delete(configName, id)
@@ -680,12 +986,52 @@ func (cg *CRUDGenerator) handleCRUDDelete(c *gin.Context) {
 		return
 	}
 
+	if !result.Success {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Data:    result,
+		})
+		return
+	}
+
 	c.JSON(200, APIResponse{
 		Success: true,
 		Data:    result,
 	})
 }
 
+// recordKey reads the key of the addressed record: the :id path segment (the value of a
+// single column key or an encoded composite key), or one query parameter per key column
+func recordKey(c *gin.Context) (interface{}, error) {
+	if c.Param("id") != "" {
+		return generator.RecordKeySegment(rawPathParam(c, "id")), nil
+	}
+
+	key := make(map[string]interface{})
+	for column, values := range c.Request.URL.Query() {
+		if len(values) > 0 {
+			key[column] = values[0]
+		}
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("record key is required")
+	}
+	return key, nil
+}
+
+// rawPathParam returns a path parameter as it appears in the request, still escaped:
+// c.Param is unescaped, which turns an escaped comma inside a key value into a separator
+func rawPathParam(c *gin.Context, name string) string {
+	pattern := strings.Split(c.FullPath(), "/")
+	path := strings.Split(c.Request.URL.EscapedPath(), "/")
+	for i, segment := range pattern {
+		if segment == ":"+name && i < len(path) {
+			return path[i]
+		}
+	}
+	return url.PathEscape(c.Param(name))
+}
+
 func (cg *CRUDGenerator) handleCRUDDict(c *gin.Context) {
 	configName := c.Param("config_name")
 	field := c.Param("field")
@@ -705,6 +1051,132 @@ func (cg *CRUDGenerator) handleCRUDDict(c *gin.Context) {
 	})
 }
 
+// viewOwner identifies the owner of saved views: Config.ViewOwner, or the user set by middleware.JWTAuth
+func (cg *CRUDGenerator) viewOwner(c *gin.Context) string {
+	if cg.config.ViewOwner != nil {
+		return cg.config.ViewOwner(c)
+	}
+	if value, exists := c.Get("user_info"); exists {
+		if userInfo, ok := value.(middleware.UserInfo); ok {
+			return userInfo.UserID
+		}
+	}
+	return ""
+}
+
+// viewErrorStatus maps a saved view error to its HTTP status
+func viewErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrViewNotFound):
+		return 404
+	case errors.Is(err, ErrViewNotOwned), errors.Is(err, ErrViewOwnerRequired):
+		return 403
+	case errors.Is(err, ErrViewExists):
+		return 409
+	}
+	return 400
+}
+
+// handleListViews lists the views of the user and the views shared by others
+func (cg *CRUDGenerator) handleListViews(c *gin.Context) {
+	views, err := cg.services.CRUDService.ListViews(c.Param("config_name"), cg.viewOwner(c))
+	if err != nil {
+		c.JSON(500, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Data:    views,
+	})
+}
+
+func (cg *CRUDGenerator) handleGetView(c *gin.Context) {
+	view, err := cg.services.CRUDService.GetView(c.Param("config_name"), c.Param("name"), cg.viewOwner(c))
+	if err != nil {
+		c.JSON(viewErrorStatus(err), APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Data:    view,
+	})
+}
+
+func (cg *CRUDGenerator) handleCreateView(c *gin.Context) {
+	var view SavedView
+	if err := c.ShouldBindJSON(&view); err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   "Invalid JSON data: " + err.Error(),
+		})
+		return
+	}
+
+	created, err := cg.services.CRUDService.CreateView(c.Param("config_name"), cg.viewOwner(c), &view)
+	if err != nil {
+		c.JSON(viewErrorStatus(err), APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(201, APIResponse{
+		Success: true,
+		Data:    created,
+		Message: "View created successfully",
+	})
+}
+
+func (cg *CRUDGenerator) handleUpdateView(c *gin.Context) {
+	var view SavedView
+	if err := c.ShouldBindJSON(&view); err != nil {
+		c.JSON(400, APIResponse{
+			Success: false,
+			Error:   "Invalid JSON data: " + err.Error(),
+		})
+		return
+	}
+
+	updated, err := cg.services.CRUDService.UpdateView(c.Param("config_name"), c.Param("name"), cg.viewOwner(c), &view)
+	if err != nil {
+		c.JSON(viewErrorStatus(err), APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Data:    updated,
+		Message: "View updated successfully",
+	})
+}
+
+func (cg *CRUDGenerator) handleDeleteView(c *gin.Context) {
+	if err := cg.services.CRUDService.DeleteView(c.Param("config_name"), c.Param("name"), cg.viewOwner(c)); err != nil {
+		c.JSON(viewErrorStatus(err), APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, APIResponse{
+		Success: true,
+		Message: "View deleted successfully",
+	})
+}
+
 // Helper function
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
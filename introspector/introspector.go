@@ -0,0 +1,96 @@
+package introspector
+
+import (
+	"fmt"
+
+	"github.com/otkinlife/crud-generator/types"
+	"gorm.io/gorm"
+)
+
+// Introspector 从数据库系统目录读取表的实时结构
+type Introspector interface {
+	IntrospectTable(tableName string) (*types.TableSchema, error)
+	EstimateRowCount(tableName string) (int64, error)
+}
+
+// NewIntrospector 按连接的数据库方言返回对应的读取器
+func NewIntrospector(db *gorm.DB) (Introspector, error) {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return NewPostgreSQLIntrospector(db), nil
+	case "mysql":
+		return NewMySQLIntrospector(db), nil
+	case "sqlite":
+		return NewSQLiteIntrospector(db), nil
+	default:
+		return nil, fmt.Errorf("unsupported database type: %s", db.Dialector.Name())
+	}
+}
+
+type keyColumn struct {
+	ConstraintName string
+	ConstraintType string
+	ColumnName     string
+}
+
+// loadKeyConstraints 返回表的主键列和唯一约束列，
+// schemaExpr 是表示当前模式的 SQL 表达式，例如 current_schema()
+func loadKeyConstraints(db *gorm.DB, schemaExpr, tableName string) ([]string, [][]string, error) {
+	query := fmt.Sprintf(`
+		SELECT tc.constraint_name AS constraint_name,
+		       tc.constraint_type AS constraint_type,
+		       kcu.column_name AS column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+		  ON kcu.constraint_name = tc.constraint_name
+		 AND kcu.table_schema = tc.table_schema
+		 AND kcu.table_name = tc.table_name
+		WHERE tc.table_schema = %s
+		  AND tc.table_name = ?
+		  AND tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE')
+		ORDER BY tc.constraint_name, kcu.ordinal_position`, schemaExpr)
+
+	var rows []keyColumn
+	if err := db.Raw(query, tableName).Scan(&rows).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to query key constraints: %w", err)
+	}
+
+	var primaryKey []string
+	var uniqueKeys [][]string
+	uniqueIndex := make(map[string]int)
+	for _, row := range rows {
+		if row.ConstraintType == "PRIMARY KEY" {
+			primaryKey = append(primaryKey, row.ColumnName)
+			continue
+		}
+		idx, exists := uniqueIndex[row.ConstraintName]
+		if !exists {
+			idx = len(uniqueKeys)
+			uniqueIndex[row.ConstraintName] = idx
+			uniqueKeys = append(uniqueKeys, nil)
+		}
+		uniqueKeys[idx] = append(uniqueKeys[idx], row.ColumnName)
+	}
+
+	return primaryKey, uniqueKeys, nil
+}
+
+// applyKeyConstraints 把键约束记录到表结构中，并标记主键字段和单列唯一字段
+func applyKeyConstraints(schema *types.TableSchema, primaryKey []string, uniqueKeys [][]string) {
+	schema.PrimaryKey = primaryKey
+	schema.UniqueKeys = uniqueKeys
+
+	for i := range schema.Fields {
+		for _, column := range primaryKey {
+			if schema.Fields[i].Name == column {
+				schema.Fields[i].PrimaryKey = true
+				schema.Fields[i].NotNull = true
+			}
+		}
+		for _, columns := range uniqueKeys {
+			if len(columns) == 1 && schema.Fields[i].Name == columns[0] {
+				schema.Fields[i].Unique = true
+			}
+		}
+	}
+}
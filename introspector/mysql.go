@@ -0,0 +1,125 @@
+package introspector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/parser"
+	"github.com/otkinlife/crud-generator/types"
+	"gorm.io/gorm"
+)
+
+type MySQLIntrospector struct {
+	db     *gorm.DB
+	parser *parser.MySQLParser
+}
+
+func NewMySQLIntrospector(db *gorm.DB) *MySQLIntrospector {
+	return &MySQLIntrospector{
+		db:     db,
+		parser: parser.NewMySQLParser(),
+	}
+}
+
+// MySQLColumn IntrospectTable 查询 information_schema.columns 得到的一行
+type MySQLColumn struct {
+	ColumnName    string
+	ColumnType    string
+	IsNullable    string
+	ColumnDefault *string
+	Extra         string
+	ColumnComment string
+}
+
+func (i *MySQLIntrospector) IntrospectTable(tableName string) (*types.TableSchema, error) {
+	var schemaName string
+	if err := i.db.Raw("SELECT DATABASE()").Scan(&schemaName).Error; err != nil {
+		return nil, fmt.Errorf("failed to get current database: %w", err)
+	}
+
+	// MySQL 8 返回的 information_schema 列名是大写的，因此使用别名
+	query := `
+		SELECT column_name AS column_name,
+		       column_type AS column_type,
+		       is_nullable AS is_nullable,
+		       column_default AS column_default,
+		       extra AS extra,
+		       column_comment AS column_comment
+		FROM information_schema.columns
+		WHERE table_schema = DATABASE()
+		  AND table_name = ?
+		ORDER BY ordinal_position`
+
+	var columns []MySQLColumn
+	if err := i.db.Raw(query, tableName).Scan(&columns).Error; err != nil {
+		return nil, fmt.Errorf("failed to query columns: %w", err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table '%s' not found in database '%s'", tableName, schemaName)
+	}
+
+	schema := &types.TableSchema{
+		TableName: tableName,
+		Schema:    schemaName,
+	}
+
+	for _, column := range columns {
+		field, err := i.ConvertColumn(column)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert column '%s': %w", column.ColumnName, err)
+		}
+		schema.Fields = append(schema.Fields, field)
+	}
+
+	primaryKey, uniqueKeys, err := loadKeyConstraints(i.db, "DATABASE()", tableName)
+	if err != nil {
+		return nil, err
+	}
+	applyKeyConstraints(schema, primaryKey, uniqueKeys)
+
+	commentQuery := `
+		SELECT table_comment AS table_comment
+		FROM information_schema.tables
+		WHERE table_schema = DATABASE()
+		  AND table_name = ?`
+	if err := i.db.Raw(commentQuery, tableName).Scan(&schema.Comment).Error; err != nil {
+		return nil, fmt.Errorf("failed to query table comment: %w", err)
+	}
+
+	return schema, nil
+}
+
+// ConvertColumn 把系统目录中的一行转换为字段：解析 column_type、自增、默认值和注释
+func (i *MySQLIntrospector) ConvertColumn(column MySQLColumn) (types.TableField, error) {
+	field, err := i.parser.ParseColumnType(column.ColumnType)
+	if err != nil {
+		return types.TableField{}, err
+	}
+
+	field.Name = column.ColumnName
+	field.NotNull = column.IsNullable == "NO"
+	field.DefaultValue = column.ColumnDefault
+	field.AutoIncrement = strings.Contains(strings.ToLower(column.Extra), "auto_increment")
+	field.Comment = column.ColumnComment
+
+	return field, nil
+}
+
+func (i *MySQLIntrospector) EstimateRowCount(tableName string) (int64, error) {
+	query := `
+		SELECT table_rows AS table_rows
+		FROM information_schema.tables
+		WHERE table_schema = DATABASE()
+		  AND table_name = ?`
+
+	var count *int64
+	if err := i.db.Raw(query, tableName).Scan(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to estimate row count: %w", err)
+	}
+
+	if count == nil {
+		return 0, nil
+	}
+
+	return *count, nil
+}
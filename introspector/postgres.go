@@ -0,0 +1,207 @@
+package introspector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/parser"
+	"github.com/otkinlife/crud-generator/types"
+	"gorm.io/gorm"
+)
+
+type PostgreSQLIntrospector struct {
+	db     *gorm.DB
+	parser *parser.PostgreSQLParser
+}
+
+func NewPostgreSQLIntrospector(db *gorm.DB) *PostgreSQLIntrospector {
+	return &PostgreSQLIntrospector{
+		db:     db,
+		parser: parser.NewPostgreSQLParser(),
+	}
+}
+
+// PostgreSQLColumn IntrospectTable 查询 information_schema.columns 得到的一行
+type PostgreSQLColumn struct {
+	ColumnName             string
+	DataType               string
+	UdtName                string
+	CharacterMaximumLength *int
+	NumericPrecision       *int
+	NumericScale           *int
+	IsNullable             string
+	ColumnDefault          *string
+	IsIdentity             *string
+	IdentityGeneration     *string
+	IsGenerated            *string
+	ColumnType             string
+	ColumnComment          *string
+	EnumValues             *string
+}
+
+func (i *PostgreSQLIntrospector) IntrospectTable(tableName string) (*types.TableSchema, error) {
+	var schemaName string
+	if err := i.db.Raw("SELECT current_schema()").Scan(&schemaName).Error; err != nil {
+		return nil, fmt.Errorf("failed to get current schema: %w", err)
+	}
+
+	query := `
+		SELECT c.column_name,
+		       c.data_type,
+		       c.udt_name,
+		       c.character_maximum_length,
+		       c.numeric_precision,
+		       c.numeric_scale,
+		       c.is_nullable,
+		       c.column_default,
+		       c.is_identity,
+		       c.identity_generation,
+		       c.is_generated,
+		       format_type(a.atttypid, a.atttypmod) AS column_type,
+		       col_description(a.attrelid, a.attnum) AS column_comment,
+		       (SELECT json_agg(e.enumlabel ORDER BY e.enumsortorder)
+		          FROM pg_catalog.pg_enum e
+		         WHERE e.enumtypid = a.atttypid) AS enum_values
+		FROM information_schema.columns c
+		JOIN pg_catalog.pg_namespace n ON n.nspname = c.table_schema
+		JOIN pg_catalog.pg_class t ON t.relnamespace = n.oid AND t.relname = c.table_name
+		JOIN pg_catalog.pg_attribute a ON a.attrelid = t.oid AND a.attname = c.column_name
+		WHERE c.table_schema = current_schema()
+		  AND c.table_name = ?
+		ORDER BY c.ordinal_position`
+
+	var columns []PostgreSQLColumn
+	if err := i.db.Raw(query, tableName).Scan(&columns).Error; err != nil {
+		return nil, fmt.Errorf("failed to query columns: %w", err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table '%s' not found in schema '%s'", tableName, schemaName)
+	}
+
+	schema := &types.TableSchema{
+		TableName: tableName,
+		Schema:    schemaName,
+	}
+
+	for _, column := range columns {
+		field, err := i.ConvertColumn(column)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert column '%s': %w", column.ColumnName, err)
+		}
+		schema.Fields = append(schema.Fields, field)
+	}
+
+	primaryKey, uniqueKeys, err := loadKeyConstraints(i.db, "current_schema()", tableName)
+	if err != nil {
+		return nil, err
+	}
+	applyKeyConstraints(schema, primaryKey, uniqueKeys)
+
+	var tableComment *string
+	commentQuery := `
+		SELECT obj_description(t.oid, 'pg_class')
+		FROM pg_catalog.pg_class t
+		JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+		WHERE n.nspname = current_schema()
+		  AND t.relname = ?`
+	if err := i.db.Raw(commentQuery, tableName).Scan(&tableComment).Error; err != nil {
+		return nil, fmt.Errorf("failed to query table comment: %w", err)
+	}
+	if tableComment != nil {
+		schema.Comment = *tableComment
+	}
+
+	return schema, nil
+}
+
+// ConvertColumn 把系统目录中的一行转换为字段：枚举值、由 udt_name 得到的数组元素类型、
+// 标识列、serial 列和生成列、默认值和注释
+func (i *PostgreSQLIntrospector) ConvertColumn(column PostgreSQLColumn) (types.TableField, error) {
+	var field types.TableField
+
+	switch {
+	case column.EnumValues != nil:
+		field.Type = types.PostgreSQLTypeEnum
+		if err := json.Unmarshal([]byte(*column.EnumValues), &field.EnumValues); err != nil {
+			return types.TableField{}, fmt.Errorf("invalid enum labels: %w", err)
+		}
+	case column.DataType == "ARRAY":
+		// 数组的 udt_name 是元素类型加下划线前缀，例如 _int4
+		element, err := i.parser.ParseColumnType(strings.TrimPrefix(column.UdtName, "_"))
+		if err != nil {
+			return types.TableField{}, err
+		}
+		field.Type = types.PostgreSQLTypeArray
+		field.ElementType = element.Type
+	case column.DataType == "USER-DEFINED":
+		parsed, err := i.parser.ParseColumnType(column.UdtName)
+		if err != nil {
+			return types.TableField{}, err
+		}
+		field = parsed
+	default:
+		parsed, err := i.parser.ParseColumnType(column.DataType)
+		if err != nil {
+			return types.TableField{}, err
+		}
+		field = parsed
+	}
+
+	field.Name = column.ColumnName
+	field.RawType = column.ColumnType
+	field.NotNull = column.IsNullable == "NO"
+	field.DefaultValue = column.ColumnDefault
+
+	switch field.Type {
+	case types.PostgreSQLTypeVarchar, types.PostgreSQLTypeChar:
+		if column.CharacterMaximumLength != nil {
+			field.Length = *column.CharacterMaximumLength
+		}
+	case types.PostgreSQLTypeNumeric:
+		if column.NumericPrecision != nil {
+			field.Precision = *column.NumericPrecision
+		}
+		if column.NumericScale != nil {
+			field.Scale = *column.NumericScale
+		}
+	}
+
+	if column.IsIdentity != nil && *column.IsIdentity == "YES" {
+		field.AutoIncrement = true
+		field.ReadOnly = column.IdentityGeneration != nil && *column.IdentityGeneration == "ALWAYS"
+	}
+	if column.IsGenerated != nil && *column.IsGenerated == "ALWAYS" {
+		field.ReadOnly = true
+	}
+	if column.ColumnDefault != nil && strings.HasPrefix(*column.ColumnDefault, "nextval(") {
+		field.AutoIncrement = true
+	}
+
+	if column.ColumnComment != nil {
+		field.Comment = *column.ColumnComment
+	}
+
+	return field, nil
+}
+
+func (i *PostgreSQLIntrospector) EstimateRowCount(tableName string) (int64, error) {
+	query := `
+		SELECT c.reltuples::bigint
+		FROM pg_catalog.pg_class c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = current_schema()
+		  AND c.relname = ?`
+
+	var count int64
+	if err := i.db.Raw(query, tableName).Scan(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to estimate row count: %w", err)
+	}
+
+	// 从未 ANALYZE 过的表 reltuples 为 -1
+	if count < 0 {
+		count = 0
+	}
+
+	return count, nil
+}
@@ -0,0 +1,186 @@
+package introspector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/parser"
+	"github.com/otkinlife/crud-generator/types"
+	"gorm.io/gorm"
+)
+
+type SQLiteIntrospector struct {
+	db     *gorm.DB
+	parser *parser.SQLiteParser
+}
+
+func NewSQLiteIntrospector(db *gorm.DB) *SQLiteIntrospector {
+	return &SQLiteIntrospector{
+		db:     db,
+		parser: parser.NewSQLiteParser(),
+	}
+}
+
+type sqliteColumn struct {
+	Name      string
+	Type      string
+	NotNull   int
+	DfltValue *string
+	PK        int
+	Hidden    int
+}
+
+type sqliteIndex struct {
+	Name   string
+	Origin string
+}
+
+type sqliteForeignKey struct {
+	ID       int
+	Table    string
+	From     string
+	To       *string
+	OnUpdate string
+	OnDelete string
+}
+
+func (i *SQLiteIntrospector) IntrospectTable(tableName string) (*types.TableSchema, error) {
+	// pragma_table_xinfo 也会列出生成列，hidden 标志不为 0
+	query := `
+		SELECT name AS name,
+		       type AS type,
+		       "notnull" AS not_null,
+		       dflt_value AS dflt_value,
+		       pk AS pk,
+		       hidden AS hidden
+		FROM pragma_table_xinfo(?)
+		ORDER BY cid`
+
+	var columns []sqliteColumn
+	if err := i.db.Raw(query, tableName).Scan(&columns).Error; err != nil {
+		return nil, fmt.Errorf("failed to query columns: %w", err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table '%s' not found", tableName)
+	}
+
+	schema := &types.TableSchema{
+		TableName: tableName,
+		Schema:    "main",
+	}
+
+	var primaryKey []string
+	pkPositions := make(map[int]string)
+	for _, column := range columns {
+		field, err := i.parser.ParseColumnType(column.Type)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert column '%s': %w", column.Name, err)
+		}
+
+		field.Name = column.Name
+		field.NotNull = column.NotNull == 1
+		field.DefaultValue = column.DfltValue
+		field.ReadOnly = column.Hidden == 2 || column.Hidden == 3
+		if column.PK > 0 {
+			pkPositions[column.PK] = column.Name
+		}
+
+		schema.Fields = append(schema.Fields, field)
+	}
+	for position := 1; position <= len(pkPositions); position++ {
+		primaryKey = append(primaryKey, pkPositions[position])
+	}
+
+	// INTEGER PRIMARY KEY 列是 rowid 的别名
+	if len(primaryKey) == 1 {
+		for idx := range schema.Fields {
+			if schema.Fields[idx].Name == primaryKey[0] && strings.EqualFold(schema.Fields[idx].RawType, "integer") {
+				schema.Fields[idx].AutoIncrement = true
+			}
+		}
+	}
+
+	uniqueKeys, err := i.loadUniqueKeys(tableName)
+	if err != nil {
+		return nil, err
+	}
+	applyKeyConstraints(schema, primaryKey, uniqueKeys)
+
+	foreignKeys, err := i.loadForeignKeys(tableName)
+	if err != nil {
+		return nil, err
+	}
+	schema.ForeignKeys = foreignKeys
+
+	return schema, nil
+}
+
+// loadUniqueKeys 返回表的唯一约束和唯一索引的列
+func (i *SQLiteIntrospector) loadUniqueKeys(tableName string) ([][]string, error) {
+	var indexes []sqliteIndex
+	query := `SELECT name AS name, origin AS origin FROM pragma_index_list(?) WHERE "unique" = 1 AND origin <> 'pk'`
+	if err := i.db.Raw(query, tableName).Scan(&indexes).Error; err != nil {
+		return nil, fmt.Errorf("failed to query unique indexes: %w", err)
+	}
+
+	var uniqueKeys [][]string
+	for _, index := range indexes {
+		var columns []string
+		if err := i.db.Raw(`SELECT name FROM pragma_index_info(?) ORDER BY seqno`, index.Name).Scan(&columns).Error; err != nil {
+			return nil, fmt.Errorf("failed to query columns of index '%s': %w", index.Name, err)
+		}
+		if len(columns) > 0 {
+			uniqueKeys = append(uniqueKeys, columns)
+		}
+	}
+
+	return uniqueKeys, nil
+}
+
+func (i *SQLiteIntrospector) loadForeignKeys(tableName string) ([]types.ForeignKey, error) {
+	query := `
+		SELECT id AS id,
+		       "table" AS "table",
+		       "from" AS "from",
+		       "to" AS "to",
+		       on_update AS on_update,
+		       on_delete AS on_delete
+		FROM pragma_foreign_key_list(?)
+		ORDER BY id, seq`
+
+	var rows []sqliteForeignKey
+	if err := i.db.Raw(query, tableName).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query foreign keys: %w", err)
+	}
+
+	var foreignKeys []types.ForeignKey
+	positions := make(map[int]int)
+	for _, row := range rows {
+		idx, exists := positions[row.ID]
+		if !exists {
+			idx = len(foreignKeys)
+			positions[row.ID] = idx
+			foreignKeys = append(foreignKeys, types.ForeignKey{
+				ReferencedTable: row.Table,
+				OnDelete:        row.OnDelete,
+				OnUpdate:        row.OnUpdate,
+			})
+		}
+		foreignKeys[idx].Columns = append(foreignKeys[idx].Columns, row.From)
+		// 外键隐式引用主键时 "to" 为 NULL
+		if row.To != nil {
+			foreignKeys[idx].ReferencedColumns = append(foreignKeys[idx].ReferencedColumns, *row.To)
+		}
+	}
+
+	return foreignKeys, nil
+}
+
+// EstimateRowCount 直接统计行数，SQLite 除非执行过 ANALYZE，否则没有行数统计
+func (i *SQLiteIntrospector) EstimateRowCount(tableName string) (int64, error) {
+	var count int64
+	if err := i.db.Table(tableName).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count rows: %w", err)
+	}
+	return count, nil
+}
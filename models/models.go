@@ -6,12 +6,12 @@ import (
 
 type DatabaseConfig struct {
 	Name             string                 `json:"name"`
-	DbType           string                 `json:"db_type" validate:"required,oneof=postgresql mysql"`
-	Host             string                 `json:"host" validate:"required"`
-	Port             int                    `json:"port" validate:"required,min=1,max=65535"`
+	DbType           string                 `json:"db_type" validate:"required,oneof=postgresql mysql sqlite"`
+	Host             string                 `json:"host" validate:"required_unless=DbType sqlite"`
+	Port             int                    `json:"port" validate:"required_unless=DbType sqlite,max=65535"`
 	DatabaseName     string                 `json:"database_name" validate:"required"`
-	Username         string                 `json:"username" validate:"required"`
-	Password         string                 `json:"password" validate:"required"`
+	Username         string                 `json:"username" validate:"required_unless=DbType sqlite"`
+	Password         string                 `json:"password" validate:"required_unless=DbType sqlite"`
 	SSLMode          string                 `json:"ssl_mode"`
 	ConnectionParams map[string]interface{} `json:"connection_params"`
 	MaxOpenConns     int                    `json:"max_open_conns"`
@@ -27,13 +27,19 @@ type TableConfiguration struct {
 	ConnectionID    string `json:"connection_id" gorm:"size:100;not null;index" validate:"required"` // 改为字符串，引用JSON中的key
 	Name            string `json:"name" gorm:"size:100;not null" validate:"required,min=2,max=100"`
 	DBTableName     string `json:"table_name" gorm:"column:table_name;size:100;not null" validate:"required"`
-	CreateStatement string `json:"create_statement" gorm:"type:text;not null" validate:"required"`
+	CreateStatement string `json:"create_statement" gorm:"type:text;not null"`
+	PrimaryKey      string `json:"primary_key" gorm:"size:255"` // 主键列，多个用逗号分隔；为空时使用表结构中的主键
 
 	// 查询配置
 	QueryPagination     bool   `json:"query_pagination" gorm:"default:true"`
 	QueryDisplayFields  string `json:"query_display_fields" gorm:"type:text"`  // 展示字段配置
 	QuerySearchFields   string `json:"query_search_fields" gorm:"type:text"`   // 搜索字段配置
 	QuerySortableFields string `json:"query_sortable_fields" gorm:"type:text"` // 可排序字段配置
+	QueryDefaultSort    string `json:"query_default_sort" gorm:"size:255"`     // 默认排序，例如 "status,-created_at"
+	QueryFilterFields   string `json:"query_filter_fields" gorm:"type:text"`   // 过滤字段及允许的操作符配置
+	QueryHiddenFields   string `json:"query_hidden_fields" gorm:"type:text"`   // 永不返回的字段，如密码哈希
+	QueryRelations      string `json:"query_relations" gorm:"type:text"`       // 子表关系配置
+	QueryAggregate      string `json:"query_aggregate" gorm:"type:text"`       // 统计接口允许的分组字段和指标
 
 	// 创建配置
 	CreateCreatableFields string `json:"create_creatable_fields" gorm:"type:text"` // 可创建字段配置
@@ -61,6 +67,27 @@ func (TableConfiguration) TableName() string {
 	return "table_configurations"
 }
 
+// SavedView 保存的列表视图：搜索条件、排序、显示列和每页条数
+type SavedView struct {
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	ConfigName string `json:"config_name" gorm:"size:100;not null;index"` // 所属表配置名称
+	Name       string `json:"name" gorm:"size:100;not null"`
+	Owner      string `json:"owner" gorm:"size:100;index"` // 创建者，未启用认证时为空
+	Shared     bool   `json:"shared" gorm:"default:false"` // 共享视图对所有人可见，私有视图只对创建者可见
+	Search     string `json:"search" gorm:"type:text"`     // 搜索参数，JSON 对象
+	Filter     string `json:"filter" gorm:"type:text"`     // 高级筛选条件树，JSON
+	Sort       string `json:"sort" gorm:"size:255"`        // 排序，例如 "status,-created_at"
+	Fields     string `json:"fields" gorm:"type:text"`     // 显示列，JSON 数组，为空时显示全部展示字段
+	PageSize   int    `json:"page_size" gorm:"default:0"`  // 每页条数，0 使用页面默认值
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (SavedView) TableName() string {
+	return "saved_views"
+}
+
 type ConfigDetails struct {
 	ID                    uint      `json:"id"`
 	ConnectionID          string    `json:"connection_id"`
@@ -69,10 +96,16 @@ type ConfigDetails struct {
 	Name                  string    `json:"name"`
 	TableName             string    `json:"table_name"`
 	CreateStatement       string    `json:"create_statement"`
+	PrimaryKey            string    `json:"primary_key"`
 	QueryPagination       bool      `json:"query_pagination"`
 	QueryDisplayFields    string    `json:"query_display_fields"`
 	QuerySearchFields     string    `json:"query_search_fields"`
 	QuerySortableFields   string    `json:"query_sortable_fields"`
+	QueryDefaultSort      string    `json:"query_default_sort"`
+	QueryFilterFields     string    `json:"query_filter_fields"`
+	QueryHiddenFields     string    `json:"query_hidden_fields"`
+	QueryRelations        string    `json:"query_relations"`
+	QueryAggregate        string    `json:"query_aggregate"`
 	CreateCreatableFields string    `json:"create_creatable_fields"`
 	CreateValidationRules string    `json:"create_validation_rules"`
 	CreateDefaultValues   string    `json:"create_default_values"`
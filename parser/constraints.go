@@ -0,0 +1,190 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/otkinlife/crud-generator/types"
+)
+
+// parseTableConstraint 把表级约束（如 "CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users (id)"）
+// 记录到表结构中，普通索引定义忽略
+func parseTableConstraint(definition string, schema *types.TableSchema) {
+	tokens := splitTopLevel(definition, unicode.IsSpace)
+
+	var name string
+	if len(tokens) > 1 && strings.EqualFold(tokens[0], "CONSTRAINT") {
+		if isConstraintKeyword(tokens[1]) {
+			tokens = tokens[1:]
+		} else {
+			name = unquoteIdentifier(tokens[1])
+			tokens = tokens[2:]
+		}
+	}
+	if len(tokens) == 0 {
+		return
+	}
+
+	switch strings.ToUpper(tokens[0]) {
+	case "PRIMARY":
+		schema.PrimaryKey = parseIndexColumns(strings.Join(tokens, " "))
+	case "UNIQUE":
+		if columns := parseIndexColumns(strings.Join(tokens, " ")); len(columns) > 0 {
+			schema.UniqueKeys = append(schema.UniqueKeys, columns)
+		}
+	case "FOREIGN":
+		// FOREIGN KEY [索引名] (列) REFERENCES ...
+		for i := 1; i < len(tokens); i++ {
+			if strings.Contains(tokens[i], "(") {
+				foreignKey := types.ForeignKey{
+					Name:    name,
+					Columns: parseIndexColumns(tokens[i]),
+				}
+				for j := i + 1; j < len(tokens); j++ {
+					if strings.EqualFold(tokens[j], "REFERENCES") {
+						parseReferences(&foreignKey, tokens[j+1:])
+						break
+					}
+				}
+				if foreignKey.ReferencedTable != "" {
+					schema.ForeignKeys = append(schema.ForeignKeys, foreignKey)
+				}
+				break
+			}
+		}
+	case "CHECK":
+		if len(tokens) > 1 {
+			if expression, _, ok := extractParenthesized(tokens[1]); ok {
+				schema.Checks = append(schema.Checks, types.CheckConstraint{
+					Name:       name,
+					Expression: strings.TrimSpace(expression),
+				})
+			}
+		}
+	}
+}
+
+// parseInlineConstraints 记录列定义上声明的约束，例如 "REFERENCES users (id) ON DELETE CASCADE"
+// 或 "CHECK (age > 0)"；tokens 是列类型之后的词
+func parseInlineConstraints(column string, tokens []string, schema *types.TableSchema) {
+	var name string
+
+	for i := 0; i < len(tokens); i++ {
+		switch strings.ToUpper(tokens[i]) {
+		case "DEFAULT", "COMMENT", "COLLATE":
+			// 跳过默认值，避免误当作关键字
+			i++
+		case "CONSTRAINT":
+			if i+1 < len(tokens) {
+				name = unquoteIdentifier(tokens[i+1])
+				i++
+			}
+		case "PRIMARY":
+			schema.PrimaryKey = []string{column}
+			name = ""
+		case "KEY":
+			// MySQL 列定义中单独的 KEY 表示 PRIMARY KEY
+			if i == 0 || !isConstraintKeyword(tokens[i-1]) {
+				schema.PrimaryKey = []string{column}
+			}
+		case "UNIQUE":
+			schema.UniqueKeys = append(schema.UniqueKeys, []string{column})
+			name = ""
+		case "REFERENCES":
+			foreignKey := types.ForeignKey{
+				Name:    name,
+				Columns: []string{column},
+			}
+			i += parseReferences(&foreignKey, tokens[i+1:])
+			if foreignKey.ReferencedTable != "" {
+				schema.ForeignKeys = append(schema.ForeignKeys, foreignKey)
+			}
+			name = ""
+		case "CHECK":
+			if i+1 < len(tokens) {
+				if expression, _, ok := extractParenthesized(tokens[i+1]); ok {
+					schema.Checks = append(schema.Checks, types.CheckConstraint{
+						Name:       name,
+						Expression: strings.TrimSpace(expression),
+					})
+					i++
+				}
+			}
+			name = ""
+		}
+	}
+}
+
+// parseReferences 从 REFERENCES 之后的词中读取被引用的表、列和级联动作，返回读取的词数
+func parseReferences(foreignKey *types.ForeignKey, tokens []string) int {
+	if len(tokens) == 0 {
+		return 0
+	}
+
+	target := tokens[0]
+	consumed := 1
+	if idx := strings.Index(target, "("); idx > 0 {
+		foreignKey.ReferencedColumns = parseIndexColumns(target[idx:])
+		target = target[:idx]
+	} else if consumed < len(tokens) && strings.HasPrefix(tokens[consumed], "(") {
+		foreignKey.ReferencedColumns = parseIndexColumns(tokens[consumed])
+		consumed++
+	}
+	foreignKey.ReferencedTable = unquoteQualifiedName(target)
+
+	for consumed+1 < len(tokens) && strings.EqualFold(tokens[consumed], "ON") {
+		event := strings.ToUpper(tokens[consumed+1])
+		if (event != "DELETE" && event != "UPDATE") || consumed+2 >= len(tokens) {
+			break
+		}
+
+		action := strings.ToUpper(tokens[consumed+2])
+		consumed += 3
+		if (action == "SET" || action == "NO") && consumed < len(tokens) {
+			action += " " + strings.ToUpper(tokens[consumed])
+			consumed++
+		}
+
+		if event == "DELETE" {
+			foreignKey.OnDelete = action
+		} else {
+			foreignKey.OnUpdate = action
+		}
+	}
+
+	return consumed
+}
+
+func isConstraintKeyword(token string) bool {
+	switch strings.ToUpper(token) {
+	case "PRIMARY", "UNIQUE", "FOREIGN", "CHECK":
+		return true
+	}
+	return false
+}
+
+// unquoteQualifiedName 去掉 "public"."users" 形式名称中每一段的引号
+func unquoteQualifiedName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = unquoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// applyKeyConstraints 标记属于主键或单列唯一键的字段
+func applyKeyConstraints(schema *types.TableSchema) {
+	for i := range schema.Fields {
+		for _, column := range schema.PrimaryKey {
+			if schema.Fields[i].Name == column {
+				schema.Fields[i].PrimaryKey = true
+				schema.Fields[i].NotNull = true
+			}
+		}
+		for _, columns := range schema.UniqueKeys {
+			if len(columns) == 1 && schema.Fields[i].Name == columns[0] {
+				schema.Fields[i].Unique = true
+			}
+		}
+	}
+}
@@ -0,0 +1,489 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/otkinlife/crud-generator/types"
+)
+
+type MySQLParser struct{}
+
+func NewMySQLParser() *MySQLParser {
+	return &MySQLParser{}
+}
+
+// ParseCreateStatement 解析 DDL 脚本中的第一条 CREATE TABLE 语句
+func (p *MySQLParser) ParseCreateStatement(createSQL string) (*types.TableSchema, error) {
+	return readScript(createSQL, "", p.parseCreateTable)
+}
+
+// ParseScript 解析 tableName 的 CREATE TABLE 语句以及脚本中引用该表的其他语句
+func (p *MySQLParser) ParseScript(script, tableName string) (*types.TableSchema, error) {
+	return readScript(script, tableName, p.parseCreateTable)
+}
+
+func (p *MySQLParser) parseCreateTable(createSQL string) (*types.TableSchema, error) {
+	createSQL = strings.TrimSpace(createSQL)
+
+	tableNameRegex := regexp.MustCompile("(?i)CREATE\\s+(?:TEMPORARY\\s+)?TABLE\\s+(?:IF\\s+NOT\\s+EXISTS\\s+)?(?:(?:`[^`]+`|\\w+)\\.)?(`[^`]+`|\\w+)")
+	loc := tableNameRegex.FindStringSubmatchIndex(createSQL)
+	if loc == nil {
+		return nil, fmt.Errorf("cannot extract table name from CREATE statement")
+	}
+
+	tableName := unquoteIdentifier(createSQL[loc[2]:loc[3]])
+
+	fieldsContent, tableOptions, ok := extractParenthesized(createSQL[loc[1]:])
+	if !ok {
+		return nil, fmt.Errorf("cannot extract fields from CREATE statement")
+	}
+
+	schema := &types.TableSchema{
+		TableName: tableName,
+		Comment:   p.parseTableComment(tableOptions),
+	}
+
+	for _, definition := range splitTopLevel(fieldsContent, func(r rune) bool { return r == ',' }) {
+		definition = strings.TrimSpace(definition)
+		if definition == "" {
+			continue
+		}
+
+		tokens := splitTopLevel(definition, unicode.IsSpace)
+		switch p.definitionKind(tokens) {
+		case "constraint":
+			parseTableConstraint(definition, schema)
+		case "index":
+			if index := parseIndexDefinition(definition); len(index.Columns) > 0 {
+				schema.Indexes = append(schema.Indexes, index)
+			}
+		default:
+			field, err := p.parseField(tokens)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse field '%s': %w", definition, err)
+			}
+			parseInlineConstraints(field.Name, tokens[2:], schema)
+			schema.Fields = append(schema.Fields, field)
+		}
+	}
+
+	applyKeyConstraints(schema)
+
+	return schema, nil
+}
+
+// parseTableComment 从表选项中读取 COMMENT，例如 "ENGINE=InnoDB COMMENT='users'"
+func (p *MySQLParser) parseTableComment(tableOptions string) string {
+	tokens := splitTopLevel(tableOptions, func(r rune) bool { return r == '=' || r == ';' || unicode.IsSpace(r) })
+	for i := 0; i+1 < len(tokens); i++ {
+		if strings.EqualFold(tokens[i], "COMMENT") {
+			return unquoteString(tokens[i+1])
+		}
+	}
+	return ""
+}
+
+// definitionKind 判断表定义中的元素是约束、索引还是列
+func (p *MySQLParser) definitionKind(tokens []string) string {
+	if len(tokens) == 0 {
+		return "index"
+	}
+
+	keyword := strings.ToUpper(tokens[0])
+	if keyword == "CONSTRAINT" {
+		// CONSTRAINT [约束名] PRIMARY KEY | UNIQUE | FOREIGN KEY | CHECK
+		rest := tokens[1:]
+		if len(rest) > 0 && !isConstraintKeyword(rest[0]) {
+			rest = rest[1:]
+		}
+		if len(rest) == 0 {
+			return "index"
+		}
+		return p.definitionKind(rest)
+	}
+
+	switch keyword {
+	case "PRIMARY", "UNIQUE", "FOREIGN", "CHECK":
+		return "constraint"
+	case "KEY", "INDEX", "FULLTEXT", "SPATIAL":
+		return "index"
+	}
+
+	return "column"
+}
+
+func (p *MySQLParser) parseField(tokens []string) (types.TableField, error) {
+	if len(tokens) < 2 {
+		return types.TableField{}, fmt.Errorf("invalid field definition: %s", strings.Join(tokens, " "))
+	}
+
+	field := types.TableField{
+		Name: unquoteIdentifier(tokens[0]),
+	}
+
+	typeStr := tokens[1]
+	i := 2
+	// 类型中可能有空白，例如 "int (11)" 和 "double precision"
+	if i < len(tokens) && strings.HasPrefix(tokens[i], "(") {
+		typeStr += tokens[i]
+		i++
+	}
+	if strings.EqualFold(typeStr, "double") && i < len(tokens) && strings.EqualFold(tokens[i], "precision") {
+		i++
+	}
+
+	for ; i < len(tokens); i++ {
+		switch strings.ToUpper(tokens[i]) {
+		case "UNSIGNED":
+			field.Unsigned = true
+		case "NOT":
+			if i+1 < len(tokens) && strings.EqualFold(tokens[i+1], "NULL") {
+				field.NotNull = true
+				i++
+			}
+		case "DEFAULT":
+			if i+1 < len(tokens) {
+				defaultVal := tokens[i+1]
+				field.DefaultValue = &defaultVal
+				i++
+			}
+		case "AUTO_INCREMENT":
+			field.AutoIncrement = true
+		case "PRIMARY", "KEY":
+			field.PrimaryKey = true
+			field.NotNull = true
+			if i+1 < len(tokens) && strings.EqualFold(tokens[i+1], "KEY") {
+				i++
+			}
+		case "UNIQUE":
+			field.Unique = true
+			if i+1 < len(tokens) && strings.EqualFold(tokens[i+1], "KEY") {
+				i++
+			}
+		case "COMMENT":
+			if i+1 < len(tokens) {
+				field.Comment = unquoteString(tokens[i+1])
+				i++
+			}
+		case "ON":
+			// ON UPDATE CURRENT_TIMESTAMP 子句
+			i += 2
+		case "CHARACTER", "CHARSET", "COLLATE":
+			if strings.EqualFold(tokens[i], "CHARACTER") {
+				i++
+			}
+			i++
+		case "REFERENCES", "CHECK":
+			i = len(tokens)
+		}
+	}
+
+	mysqlType, length, precision, scale, enumValues, err := p.parseDataType(typeStr, field.Unsigned)
+	if err != nil {
+		return types.TableField{}, fmt.Errorf("failed to parse data type '%s': %w", typeStr, err)
+	}
+
+	field.Type = mysqlType
+	field.RawType = typeStr
+	field.Length = length
+	field.Precision = precision
+	field.Scale = scale
+	field.EnumValues = enumValues
+
+	return field, nil
+}
+
+// ParseColumnType 把 "int(10) unsigned" 这样的列类型转换为表字段
+func (p *MySQLParser) ParseColumnType(typeStr string) (types.TableField, error) {
+	tokens := splitTopLevel(strings.TrimSpace(typeStr), unicode.IsSpace)
+	if len(tokens) == 0 {
+		return types.TableField{}, fmt.Errorf("empty column type")
+	}
+
+	field := types.TableField{}
+	for _, token := range tokens[1:] {
+		if strings.EqualFold(token, "unsigned") {
+			field.Unsigned = true
+		}
+	}
+
+	mysqlType, length, precision, scale, enumValues, err := p.parseDataType(tokens[0], field.Unsigned)
+	if err != nil {
+		return types.TableField{}, err
+	}
+
+	field.Type = mysqlType
+	field.Length = length
+	field.Precision = precision
+	field.Scale = scale
+	field.EnumValues = enumValues
+	field.RawType = typeStr
+
+	return field, nil
+}
+
+func (p *MySQLParser) parseDataType(typeStr string, unsigned bool) (types.PostgreSQLType, int, int, int, []string, error) {
+	typeStr = strings.TrimSpace(typeStr)
+
+	typeRegex := regexp.MustCompile(`(?s)^(\w+)\s*(?:\((.*)\))?$`)
+	matches := typeRegex.FindStringSubmatch(typeStr)
+	if matches == nil {
+		return "", 0, 0, 0, nil, fmt.Errorf("unsupported MySQL type: %s", typeStr)
+	}
+
+	baseType := strings.ToLower(matches[1])
+	args := strings.TrimSpace(matches[2])
+
+	var sizes []int
+	if baseType != "enum" && baseType != "set" && args != "" {
+		for _, part := range strings.Split(args, ",") {
+			size, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return "", 0, 0, 0, nil, fmt.Errorf("invalid type arguments: %s", typeStr)
+			}
+			sizes = append(sizes, size)
+		}
+	}
+	size := func(i, fallback int) int {
+		if i < len(sizes) {
+			return sizes[i]
+		}
+		return fallback
+	}
+
+	switch baseType {
+	case "bool", "boolean":
+		return types.PostgreSQLTypeBoolean, 0, 0, 0, nil, nil
+	case "tinyint":
+		if size(0, 0) == 1 {
+			return types.PostgreSQLTypeBoolean, 0, 0, 0, nil, nil
+		}
+		return types.PostgreSQLTypeSmallint, 0, 0, 0, nil, nil
+	case "smallint", "year":
+		if unsigned {
+			return types.PostgreSQLTypeInteger, 0, 0, 0, nil, nil
+		}
+		return types.PostgreSQLTypeSmallint, 0, 0, 0, nil, nil
+	case "mediumint":
+		return types.PostgreSQLTypeInteger, 0, 0, 0, nil, nil
+	case "int", "integer":
+		if unsigned {
+			return types.PostgreSQLTypeBigint, 0, 0, 0, nil, nil
+		}
+		return types.PostgreSQLTypeInteger, 0, 0, 0, nil, nil
+	case "bigint":
+		if unsigned {
+			return types.PostgreSQLTypeNumeric, 0, 20, 0, nil, nil
+		}
+		return types.PostgreSQLTypeBigint, 0, 0, 0, nil, nil
+	case "decimal", "dec", "numeric", "fixed":
+		return types.PostgreSQLTypeNumeric, 0, size(0, 10), size(1, 0), nil, nil
+	case "float":
+		if size(0, 0) > 24 {
+			return types.PostgreSQLTypeDouble, 0, 0, 0, nil, nil
+		}
+		return types.PostgreSQLTypeReal, 0, 0, 0, nil, nil
+	case "double", "real":
+		return types.PostgreSQLTypeDouble, 0, 0, 0, nil, nil
+	case "bit":
+		if size(0, 1) == 1 {
+			return types.PostgreSQLTypeBoolean, 0, 0, 0, nil, nil
+		}
+		return types.PostgreSQLTypeBigint, 0, 0, 0, nil, nil
+	case "char":
+		return types.PostgreSQLTypeChar, size(0, 1), 0, 0, nil, nil
+	case "varchar":
+		return types.PostgreSQLTypeVarchar, size(0, 0), 0, 0, nil, nil
+	case "tinytext", "text", "mediumtext", "longtext", "set":
+		return types.PostgreSQLTypeText, 0, 0, 0, nil, nil
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+		return types.PostgreSQLTypeBytea, size(0, 0), 0, 0, nil, nil
+	case "date":
+		return types.PostgreSQLTypeDate, 0, 0, 0, nil, nil
+	case "datetime", "timestamp":
+		return types.PostgreSQLTypeTimestamp, 0, 0, 0, nil, nil
+	case "time":
+		return types.PostgreSQLTypeTime, 0, 0, 0, nil, nil
+	case "json":
+		return types.PostgreSQLTypeJSON, 0, 0, 0, nil, nil
+	case "enum":
+		var values []string
+		for _, value := range splitTopLevel(args, func(r rune) bool { return r == ',' }) {
+			values = append(values, unquoteString(strings.TrimSpace(value)))
+		}
+		return types.PostgreSQLTypeEnum, 0, 0, 0, values, nil
+	}
+
+	// 其他类型（geometry、point 等空间类型）与 PostgreSQL 的自定义类型一样原样保留，RawType 为原始类型
+	return types.PostgreSQLTypeUserDefined, 0, 0, 0, nil, nil
+}
+
+// extractParenthesized 返回第一个完整括号块中的内容及其之后的文本
+func extractParenthesized(s string) (string, string, bool) {
+	start := strings.Index(s, "(")
+	if start < 0 {
+		return "", "", false
+	}
+
+	var depth int
+	var quoteChar rune
+	escaped := false
+
+	for i, char := range s[start:] {
+		if quoteChar != 0 {
+			switch {
+			case escaped:
+				escaped = false
+			case char == '\\' && quoteChar != '`':
+				escaped = true
+			case char == quoteChar:
+				quoteChar = 0
+			}
+			continue
+		}
+
+		switch char {
+		case '\'', '"', '`':
+			quoteChar = char
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				end := start + i
+				return s[start+1 : end], s[end+1:], true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+// splitTopLevel 按引号和括号之外的分隔符拆分 s
+func splitTopLevel(s string, isSeparator func(rune) bool) []string {
+	var parts []string
+	var current strings.Builder
+	var depth int
+	var quoteChar rune
+	escaped := false
+
+	flush := func() {
+		if current.Len() > 0 {
+			parts = append(parts, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, char := range s {
+		if quoteChar != 0 {
+			current.WriteRune(char)
+			switch {
+			case escaped:
+				escaped = false
+			case char == '\\' && quoteChar != '`':
+				escaped = true
+			case char == quoteChar:
+				quoteChar = 0
+			}
+			continue
+		}
+
+		switch {
+		case char == '\'' || char == '"' || char == '`':
+			quoteChar = char
+			current.WriteRune(char)
+		case char == '(':
+			depth++
+			current.WriteRune(char)
+		case char == ')':
+			depth--
+			current.WriteRune(char)
+		case depth == 0 && isSeparator(char):
+			flush()
+		default:
+			current.WriteRune(char)
+		}
+	}
+	flush()
+
+	return parts
+}
+
+// parseIndexColumns 提取 "PRIMARY KEY (`a`, `b`(10) DESC)" 中的列名
+func parseIndexColumns(definition string) []string {
+	content, _, ok := extractParenthesized(definition)
+	if !ok {
+		return nil
+	}
+
+	var columns []string
+	for _, part := range splitTopLevel(content, func(r rune) bool { return r == ',' }) {
+		tokens := splitTopLevel(strings.TrimSpace(part), unicode.IsSpace)
+		if len(tokens) == 0 {
+			continue
+		}
+		name := tokens[0]
+		if idx := strings.Index(name, "("); idx > 0 && !strings.HasPrefix(name, "`") {
+			name = name[:idx]
+		} else if strings.HasPrefix(name, "`") {
+			if end := strings.LastIndex(name, "`"); end > 0 {
+				name = name[:end+1]
+			}
+		}
+		columns = append(columns, unquoteIdentifier(name))
+	}
+
+	return columns
+}
+
+func unquoteIdentifier(name string) string {
+	name = strings.TrimSpace(name)
+	if len(name) >= 2 {
+		first, last := name[0], name[len(name)-1]
+		if (first == '`' || first == '"') && first == last {
+			quote := string(first)
+			return strings.ReplaceAll(name[1:len(name)-1], quote+quote, quote)
+		}
+		if first == '[' && last == ']' {
+			return name[1 : len(name)-1]
+		}
+	}
+	return name
+}
+
+func unquoteString(value string) string {
+	if len(value) < 2 || (value[0] != '\'' && value[0] != '"') || value[len(value)-1] != value[0] {
+		return value
+	}
+
+	quote := value[0]
+	inner := value[1 : len(value)-1]
+
+	var result strings.Builder
+	for i := 0; i < len(inner); i++ {
+		char := inner[i]
+		switch {
+		case char == '\\' && i+1 < len(inner):
+			i++
+			switch inner[i] {
+			case 'n':
+				result.WriteByte('\n')
+			case 't':
+				result.WriteByte('\t')
+			default:
+				result.WriteByte(inner[i])
+			}
+		case char == quote && i+1 < len(inner) && inner[i+1] == quote:
+			result.WriteByte(quote)
+			i++
+		default:
+			result.WriteByte(char)
+		}
+	}
+
+	return result.String()
+}
@@ -0,0 +1,29 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/otkinlife/crud-generator/types"
+)
+
+// Parser 把 CREATE TABLE 语句解析为与数据库无关的 TableSchema
+type Parser interface {
+	ParseCreateStatement(createSQL string) (*types.TableSchema, error)
+	// ParseScript 解析包含 tableName 的 CREATE TABLE 语句及其索引、注释、枚举类型和 ALTER TABLE 约束的 DDL 脚本
+	ParseScript(script, tableName string) (*types.TableSchema, error)
+	ParseColumnType(typeStr string) (types.TableField, error)
+}
+
+// NewParser 按连接的数据库类型返回对应的解析器
+func NewParser(dbType string) (Parser, error) {
+	switch dbType {
+	case "postgresql", "postgres":
+		return NewPostgreSQLParser(), nil
+	case "mysql":
+		return NewMySQLParser(), nil
+	case "sqlite":
+		return NewSQLiteParser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported database type: %s", dbType)
+	}
+}
@@ -15,37 +15,45 @@ func NewPostgreSQLParser() *PostgreSQLParser {
 	return &PostgreSQLParser{}
 }
 
+// ParseCreateStatement 解析 DDL 脚本中的第一条 CREATE TABLE 语句
 func (p *PostgreSQLParser) ParseCreateStatement(createSQL string) (*types.TableSchema, error) {
+	return readScript(createSQL, "", p.parseCreateTable)
+}
+
+// ParseScript 解析 tableName 的 CREATE TABLE 语句以及脚本中引用该表的其他语句
+func (p *PostgreSQLParser) ParseScript(script, tableName string) (*types.TableSchema, error) {
+	return readScript(script, tableName, p.parseCreateTable)
+}
+
+func (p *PostgreSQLParser) parseCreateTable(createSQL string) (*types.TableSchema, error) {
 	createSQL = strings.TrimSpace(createSQL)
 
 	tableNameRegex := regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?([^\s(]+)`)
-	matches := tableNameRegex.FindStringSubmatch(createSQL)
-	if len(matches) < 2 {
+	loc := tableNameRegex.FindStringSubmatchIndex(createSQL)
+	if loc == nil {
 		return nil, fmt.Errorf("cannot extract table name from CREATE statement")
 	}
 
-	tableName := strings.Trim(matches[1], `"`)
+	tableName := strings.Trim(createSQL[loc[2]:loc[3]], `"`)
 
-	fieldsRegex := regexp.MustCompile(`(?i)\(\s*(.+)\s*\)`)
-	fieldsMatches := fieldsRegex.FindStringSubmatch(createSQL)
-	if len(fieldsMatches) < 2 {
+	fieldsContent, _, ok := extractParenthesized(createSQL[loc[1]:])
+	if !ok {
 		return nil, fmt.Errorf("cannot extract fields from CREATE statement")
 	}
 
-	fieldsContent := fieldsMatches[1]
-	fields, err := p.parseFields(fieldsContent)
-	if err != nil {
+	schema := &types.TableSchema{
+		TableName: tableName,
+	}
+	if err := p.parseFields(fieldsContent, schema); err != nil {
 		return nil, fmt.Errorf("failed to parse fields: %w", err)
 	}
 
-	return &types.TableSchema{
-		TableName: tableName,
-		Fields:    fields,
-	}, nil
+	applyKeyConstraints(schema)
+
+	return schema, nil
 }
 
-func (p *PostgreSQLParser) parseFields(fieldsContent string) ([]types.TableField, error) {
-	var fields []types.TableField
+func (p *PostgreSQLParser) parseFields(fieldsContent string, schema *types.TableSchema) error {
 	var currentField strings.Builder
 	var depth int
 	var inQuotes bool
@@ -73,13 +81,8 @@ func (p *PostgreSQLParser) parseFields(fieldsContent string) ([]types.TableField
 			currentField.WriteRune(char)
 		case ',':
 			if !inQuotes && depth == 0 {
-				fieldStr := strings.TrimSpace(currentField.String())
-				if fieldStr != "" && !p.isConstraint(fieldStr) {
-					field, err := p.parseField(fieldStr)
-					if err != nil {
-						return nil, fmt.Errorf("failed to parse field '%s': %w", fieldStr, err)
-					}
-					fields = append(fields, field)
+				if err := p.parseDefinition(currentField.String(), schema); err != nil {
+					return err
 				}
 				currentField.Reset()
 			} else {
@@ -90,16 +93,29 @@ func (p *PostgreSQLParser) parseFields(fieldsContent string) ([]types.TableField
 		}
 	}
 
-	fieldStr := strings.TrimSpace(currentField.String())
-	if fieldStr != "" && !p.isConstraint(fieldStr) {
-		field, err := p.parseField(fieldStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse field '%s': %w", fieldStr, err)
-		}
-		fields = append(fields, field)
+	return p.parseDefinition(currentField.String(), schema)
+}
+
+// parseDefinition 把列或表级约束加入表结构
+func (p *PostgreSQLParser) parseDefinition(fieldStr string, schema *types.TableSchema) error {
+	fieldStr = strings.TrimSpace(fieldStr)
+	if fieldStr == "" {
+		return nil
+	}
+
+	if p.isConstraint(fieldStr) {
+		parseTableConstraint(fieldStr, schema)
+		return nil
 	}
 
-	return fields, nil
+	field, err := p.parseField(fieldStr)
+	if err != nil {
+		return fmt.Errorf("failed to parse field '%s': %w", fieldStr, err)
+	}
+	parseInlineConstraints(field.Name, p.splitFieldDefinition(fieldStr)[2:], schema)
+	schema.Fields = append(schema.Fields, field)
+
+	return nil
 }
 
 func (p *PostgreSQLParser) isConstraint(fieldStr string) bool {
@@ -123,36 +139,55 @@ func (p *PostgreSQLParser) parseField(fieldStr string) (types.TableField, error)
 		return types.TableField{}, fmt.Errorf("invalid field definition: %s", fieldStr)
 	}
 
-	field := types.TableField{
-		Name: strings.Trim(parts[0], `"`),
+	// 类型可能由多个词组成，例如 "double precision" 或 "timestamp with time zone"
+	typeEnd := 2
+	for typeEnd < len(parts) && !isColumnConstraintKeyword(parts[typeEnd]) {
+		typeEnd++
 	}
+	typeStr := strings.Join(parts[1:typeEnd], " ")
 
-	typeStr := parts[1]
-
-	pgType, length, precision, scale, err := p.parseDataType(typeStr)
+	field, err := p.parseDataType(typeStr)
 	if err != nil {
 		return types.TableField{}, fmt.Errorf("failed to parse data type '%s': %w", typeStr, err)
 	}
+	field.Name = strings.Trim(parts[0], `"`)
 
-	field.Type = pgType
-	field.Length = length
-	field.Precision = precision
-	field.Scale = scale
-
-	constraintStr := strings.Join(parts[2:], " ")
+	constraintStr := strings.Join(parts[typeEnd:], " ")
 	field.NotNull = strings.Contains(strings.ToUpper(constraintStr), "NOT NULL")
 	field.PrimaryKey = strings.Contains(strings.ToUpper(constraintStr), "PRIMARY KEY")
 	field.Unique = strings.Contains(strings.ToUpper(constraintStr), "UNIQUE")
 
-	defaultRegex := regexp.MustCompile(`(?i)DEFAULT\s+([^,\s]+(?:\s+[^,\s]*)*?)(?:\s+(?:NOT\s+NULL|PRIMARY\s+KEY|UNIQUE|CHECK|REFERENCES)|$)`)
+	defaultRegex := regexp.MustCompile(`(?i)DEFAULT\s+([^,\s]+(?:\s+[^,\s]*)*?)(?:\s+(?:NOT\s+NULL|PRIMARY\s+KEY|UNIQUE|CHECK|REFERENCES|GENERATED)|$)`)
 	if matches := defaultRegex.FindStringSubmatch(constraintStr); len(matches) > 1 {
 		defaultVal := strings.TrimSpace(matches[1])
 		field.DefaultValue = &defaultVal
+		if strings.HasPrefix(strings.ToLower(defaultVal), "nextval(") {
+			field.AutoIncrement = true
+		}
+	}
+
+	// GENERATED ALWAYS AS IDENTITY、GENERATED BY DEFAULT AS IDENTITY、GENERATED ALWAYS AS (expr) STORED
+	generatedRegex := regexp.MustCompile(`(?i)GENERATED\s+(ALWAYS|BY\s+DEFAULT)\s+AS\s*(IDENTITY)?`)
+	if matches := generatedRegex.FindStringSubmatch(constraintStr); len(matches) > 0 {
+		if matches[2] != "" {
+			field.AutoIncrement = true
+			field.NotNull = true
+		}
+		field.ReadOnly = strings.EqualFold(matches[1], "ALWAYS")
 	}
 
 	return field, nil
 }
 
+// isColumnConstraintKeyword 判断一个词是否是列定义中约束部分的开头
+func isColumnConstraintKeyword(word string) bool {
+	switch strings.ToUpper(word) {
+	case "NOT", "NULL", "DEFAULT", "PRIMARY", "UNIQUE", "REFERENCES", "CHECK", "CONSTRAINT", "GENERATED", "COLLATE":
+		return true
+	}
+	return false
+}
+
 func (p *PostgreSQLParser) splitFieldDefinition(fieldStr string) []string {
 	var parts []string
 	var current strings.Builder
@@ -201,33 +236,87 @@ func (p *PostgreSQLParser) splitFieldDefinition(fieldStr string) []string {
 	return parts
 }
 
-func (p *PostgreSQLParser) parseDataType(typeStr string) (types.PostgreSQLType, int, int, int, error) {
-	typeStr = strings.ToLower(strings.TrimSpace(typeStr))
+// ParseColumnType 把 "character varying(255)" 这样的列类型转换为表字段
+func (p *PostgreSQLParser) ParseColumnType(typeStr string) (types.TableField, error) {
+	return p.parseDataType(typeStr)
+}
+
+// parseDataType 把列类型转换为表字段，解析器不认识的类型（如枚举类型和域）作为 PostgreSQLTypeUserDefined 保留
+func (p *PostgreSQLParser) parseDataType(typeStr string) (types.TableField, error) {
+	rawType := strings.TrimSpace(typeStr)
+	typeStr = strings.ToLower(strings.Join(strings.Fields(rawType), " "))
+	if typeStr == "" {
+		return types.TableField{}, fmt.Errorf("empty data type")
+	}
+
+	field := types.TableField{RawType: rawType}
+
+	arrayRegex := regexp.MustCompile(`^(.+?)\s*(?:(?:\[\d*\])+|\s+array(?:\[\d*\])?)$`)
+	if matches := arrayRegex.FindStringSubmatch(typeStr); len(matches) > 1 {
+		element, err := p.parseDataType(matches[1])
+		if err != nil {
+			return types.TableField{}, err
+		}
+		field.Type = types.PostgreSQLTypeArray
+		field.ElementType = element.Type
+		field.Length = element.Length
+		field.Precision = element.Precision
+		field.Scale = element.Scale
+		return field, nil
+	}
+
+	// 秒的小数精度不影响类型
+	timePrecisionRegex := regexp.MustCompile(`^(time|timestamp)\s*\(\s*\d+\s*\)`)
+	typeStr = timePrecisionRegex.ReplaceAllString(typeStr, "$1")
 
 	numericRegex := regexp.MustCompile(`^(numeric|decimal)\s*\(\s*(\d+)\s*(?:,\s*(\d+))?\s*\)$`)
 	if matches := numericRegex.FindStringSubmatch(typeStr); len(matches) > 1 {
-		precision, _ := strconv.Atoi(matches[2])
-		scale := 0
+		field.Type = types.PostgreSQLTypeNumeric
+		field.Precision, _ = strconv.Atoi(matches[2])
 		if len(matches) > 3 && matches[3] != "" {
-			scale, _ = strconv.Atoi(matches[3])
+			field.Scale, _ = strconv.Atoi(matches[3])
 		}
-		return types.PostgreSQLTypeNumeric, 0, precision, scale, nil
+		return field, nil
 	}
 
-	sizedRegex := regexp.MustCompile(`^(varchar|char|bit|varbit)\s*\(\s*(\d+)\s*\)$`)
+	sizedRegex := regexp.MustCompile(`^(varchar|character varying|char|character|bpchar|bit|bit varying|varbit)\s*\(\s*(\d+)\s*\)$`)
 	if matches := sizedRegex.FindStringSubmatch(typeStr); len(matches) > 1 {
-		length, _ := strconv.Atoi(matches[2])
+		field.Length, _ = strconv.Atoi(matches[2])
 		switch matches[1] {
-		case "varchar":
-			return types.PostgreSQLTypeVarchar, length, 0, 0, nil
-		case "char":
-			return types.PostgreSQLTypeChar, length, 0, 0, nil
+		case "varchar", "character varying":
+			field.Type = types.PostgreSQLTypeVarchar
+		case "char", "character", "bpchar":
+			field.Type = types.PostgreSQLTypeChar
+		case "bit":
+			field.Type = types.PostgreSQLTypeBit
+		default:
+			field.Type = types.PostgreSQLTypeVarbit
 		}
+		return field, nil
 	}
 
-	arrayRegex := regexp.MustCompile(`^(.+)\[\]$`)
-	if matches := arrayRegex.FindStringSubmatch(typeStr); len(matches) > 1 {
-		return types.PostgreSQLTypeArray, 0, 0, 0, nil
+	floatRegex := regexp.MustCompile(`^float\s*\(\s*(\d+)\s*\)$`)
+	if matches := floatRegex.FindStringSubmatch(typeStr); len(matches) > 1 {
+		if bits, _ := strconv.Atoi(matches[1]); bits <= 24 {
+			field.Type = types.PostgreSQLTypeReal
+		} else {
+			field.Type = types.PostgreSQLTypeDouble
+		}
+		return field, nil
+	}
+
+	serialMap := map[string]types.PostgreSQLType{
+		"serial":      types.PostgreSQLTypeInteger,
+		"serial4":     types.PostgreSQLTypeInteger,
+		"bigserial":   types.PostgreSQLTypeBigint,
+		"serial8":     types.PostgreSQLTypeBigint,
+		"smallserial": types.PostgreSQLTypeSmallint,
+		"serial2":     types.PostgreSQLTypeSmallint,
+	}
+	if pgType, exists := serialMap[typeStr]; exists {
+		field.Type = pgType
+		field.AutoIncrement = true
+		return field, nil
 	}
 
 	typeMap := map[string]types.PostgreSQLType{
@@ -244,16 +333,26 @@ func (p *PostgreSQLParser) parseDataType(typeStr string) (types.PostgreSQLType,
 		"float4":                      types.PostgreSQLTypeReal,
 		"double precision":            types.PostgreSQLTypeDouble,
 		"float8":                      types.PostgreSQLTypeDouble,
+		"float":                       types.PostgreSQLTypeDouble,
+		"money":                       types.PostgreSQLTypeMoney,
 		"text":                        types.PostgreSQLTypeText,
 		"varchar":                     types.PostgreSQLTypeVarchar,
 		"character varying":           types.PostgreSQLTypeVarchar,
 		"char":                        types.PostgreSQLTypeChar,
 		"character":                   types.PostgreSQLTypeChar,
+		"bpchar":                      types.PostgreSQLTypeChar,
+		"citext":                      types.PostgreSQLTypeCitext,
 		"bytea":                       types.PostgreSQLTypeBytea,
 		"boolean":                     types.PostgreSQLTypeBoolean,
 		"bool":                        types.PostgreSQLTypeBoolean,
+		"bit":                         types.PostgreSQLTypeBit,
+		"bit varying":                 types.PostgreSQLTypeVarbit,
+		"varbit":                      types.PostgreSQLTypeVarbit,
 		"date":                        types.PostgreSQLTypeDate,
 		"time":                        types.PostgreSQLTypeTime,
+		"time without time zone":      types.PostgreSQLTypeTime,
+		"timetz":                      types.PostgreSQLTypeTimeTZ,
+		"time with time zone":         types.PostgreSQLTypeTimeTZ,
 		"timestamp":                   types.PostgreSQLTypeTimestamp,
 		"timestamp without time zone": types.PostgreSQLTypeTimestamp,
 		"timestamptz":                 types.PostgreSQLTypeTimestampTZ,
@@ -262,11 +361,18 @@ func (p *PostgreSQLParser) parseDataType(typeStr string) (types.PostgreSQLType,
 		"json":                        types.PostgreSQLTypeJSON,
 		"jsonb":                       types.PostgreSQLTypeJSONB,
 		"uuid":                        types.PostgreSQLTypeUUID,
+		"inet":                        types.PostgreSQLTypeInet,
+		"cidr":                        types.PostgreSQLTypeCidr,
+		"macaddr":                     types.PostgreSQLTypeMacaddr,
+		"macaddr8":                    types.PostgreSQLTypeMacaddr,
+		"tsvector":                    types.PostgreSQLTypeTSVector,
 	}
 
 	if pgType, exists := typeMap[typeStr]; exists {
-		return pgType, 0, 0, 0, nil
+		field.Type = pgType
+		return field, nil
 	}
 
-	return "", 0, 0, 0, fmt.Errorf("unsupported PostgreSQL type: %s", typeStr)
+	field.Type = types.PostgreSQLTypeUserDefined
+	return field, nil
 }
@@ -0,0 +1,546 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/otkinlife/crud-generator/types"
+)
+
+// tokenKind DDL 脚本中词的类别
+type tokenKind int
+
+const (
+	tokenWord   tokenKind = iota // 关键字、未加引号的标识符或数字
+	tokenQuoted                  // 加引号的标识符："name"、`name` 或 [name]
+	tokenString                  // 字符串字面量：'text' 或 $tag$text$tag$
+	tokenGroup                   // 完整的括号块，包括括号
+	tokenSymbol                  // 其他字符，例如 "." "," "=" ";"
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	start int
+	end   int
+}
+
+// ddlStatement DDL 脚本中的一条语句
+type ddlStatement struct {
+	source string // 注释已替换为空白的脚本
+	tokens []token
+}
+
+var dollarTagRegex = regexp.MustCompile(`^\$(?:[A-Za-z_][A-Za-z0-9_]*)?\$`)
+
+// readScript 读取 DDL 脚本（如迁移脚本片段）：用 parseTable 解析目标表的 CREATE TABLE 语句（tableName 为空时取第一条），
+// 再把脚本中的 CREATE INDEX、COMMENT ON、CREATE TYPE ... AS ENUM 和 ALTER TABLE ... ADD 语句合并到表结构中
+func readScript(script, tableName string, parseTable func(createSQL string) (*types.TableSchema, error)) (*types.TableSchema, error) {
+	statements := splitStatements(script)
+
+	target := -1
+	var targetName []string
+	var createTables []int
+	for idx, statement := range statements {
+		name, ok := createTableName(statement.tokens)
+		if !ok {
+			continue
+		}
+		createTables = append(createTables, idx)
+		if target < 0 && (tableName == "" || sameTableName(name, splitQualifiedName(tableName))) {
+			target = idx
+			targetName = name
+		}
+	}
+	// 脚本只有一条 CREATE TABLE 语句时，即使表名不同也使用该语句
+	if target < 0 && len(createTables) == 1 {
+		target = createTables[0]
+		targetName, _ = createTableName(statements[target].tokens)
+	}
+	if target < 0 {
+		if tableName != "" {
+			return nil, fmt.Errorf("cannot find CREATE TABLE statement for table '%s'", tableName)
+		}
+		return nil, fmt.Errorf("cannot extract table name from CREATE statement")
+	}
+
+	schema, err := parseTable(statements[target].text(0))
+	if err != nil {
+		return nil, err
+	}
+
+	enumTypes := make(map[string][]string)
+	for idx, statement := range statements {
+		if idx == target {
+			continue
+		}
+
+		tokens := statement.tokens
+		switch {
+		case hasKeywords(tokens, 0, "CREATE", "TYPE"):
+			readEnumType(tokens, enumTypes)
+		case hasKeywords(tokens, 0, "CREATE"):
+			readCreateIndex(tokens, targetName, schema)
+		case hasKeywords(tokens, 0, "COMMENT", "ON"):
+			readComment(tokens, targetName, schema)
+		case hasKeywords(tokens, 0, "ALTER", "TABLE"):
+			readAlterTable(statement, targetName, schema)
+		}
+	}
+
+	applyEnumTypes(schema, enumTypes)
+	applyKeyConstraints(schema)
+
+	return schema, nil
+}
+
+// splitStatements 把脚本切分为词，并按顶层的分号拆分语句
+func splitStatements(script string) []ddlStatement {
+	source := stripComments(script)
+
+	var statements []ddlStatement
+	var current []token
+	for _, tok := range scanTokens(source) {
+		if tok.kind == tokenSymbol && tok.text == ";" {
+			if len(current) > 0 {
+				statements = append(statements, ddlStatement{source: source, tokens: current})
+			}
+			current = nil
+			continue
+		}
+		current = append(current, tok)
+	}
+	if len(current) > 0 {
+		statements = append(statements, ddlStatement{source: source, tokens: current})
+	}
+
+	return statements
+}
+
+// text 返回从第 i 个词开始的语句文本
+func (s ddlStatement) text(i int) string {
+	if i >= len(s.tokens) {
+		return ""
+	}
+	return s.source[s.tokens[i].start:s.tokens[len(s.tokens)-1].end]
+}
+
+// stripComments 把引号之外的 "--" 和 "/* */" 注释替换为空白，保持偏移量不变
+func stripComments(script string) string {
+	result := []byte(script)
+
+	for i := 0; i < len(script); {
+		if end := quotedEnd(script, i); end > 0 {
+			i = end
+			continue
+		}
+
+		var end int
+		switch {
+		case strings.HasPrefix(script[i:], "--"):
+			end = strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				end = len(script)
+			} else {
+				end += i
+			}
+		case strings.HasPrefix(script[i:], "/*"):
+			end = strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				end = len(script)
+			} else {
+				end += i + 4
+			}
+		default:
+			i++
+			continue
+		}
+
+		for j := i; j < end; j++ {
+			if result[j] != '\n' {
+				result[j] = ' '
+			}
+		}
+		i = end
+	}
+
+	return string(result)
+}
+
+// scanTokens 把不含注释的 SQL 切分为词
+func scanTokens(source string) []token {
+	var tokens []token
+
+	for i := 0; i < len(source); {
+		char := source[i]
+		start := i
+
+		switch {
+		case unicode.IsSpace(rune(char)):
+			i++
+			continue
+		case char == '(':
+			i = groupEnd(source, i)
+			tokens = append(tokens, token{kind: tokenGroup, text: source[start:i], start: start, end: i})
+		case quotedEnd(source, i) > 0:
+			i = quotedEnd(source, i)
+			kind := tokenQuoted
+			if char == '\'' || char == '$' {
+				kind = tokenString
+			}
+			tokens = append(tokens, token{kind: kind, text: source[start:i], start: start, end: i})
+		case isWordByte(char):
+			for i < len(source) && isWordByte(source[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: source[start:i], start: start, end: i})
+		default:
+			i++
+			tokens = append(tokens, token{kind: tokenSymbol, text: source[start:i], start: start, end: i})
+		}
+	}
+
+	return tokens
+}
+
+// quotedEnd 返回从 s[i] 开始的带引号字符串或标识符之后的位置，s[i] 不是引号时返回 -1；
+// 未闭合的引号延续到 s 的末尾
+func quotedEnd(s string, i int) int {
+	switch s[i] {
+	case '\'', '"', '`':
+		quote := s[i]
+		for j := i + 1; j < len(s); j++ {
+			switch {
+			case s[j] == '\\' && quote == '\'':
+				j++
+			case s[j] == quote:
+				if j+1 < len(s) && s[j+1] == quote {
+					j++
+					continue
+				}
+				return j + 1
+			}
+		}
+		return len(s)
+	case '[':
+		if end := strings.IndexByte(s[i:], ']'); end > 0 {
+			return i + end + 1
+		}
+	case '$':
+		if i > 0 && isWordByte(s[i-1]) {
+			return -1
+		}
+		if tag := dollarTagRegex.FindString(s[i:]); tag != "" {
+			if end := strings.Index(s[i+len(tag):], tag); end >= 0 {
+				return i + len(tag) + end + len(tag)
+			}
+			return len(s)
+		}
+	}
+	return -1
+}
+
+// groupEnd 返回从 s[i] 开始的括号块之后的位置
+func groupEnd(s string, i int) int {
+	depth := 0
+	for j := i; j < len(s); {
+		if end := quotedEnd(s, j); end > 0 {
+			j = end
+			continue
+		}
+		switch s[j] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return j + 1
+			}
+		}
+		j++
+	}
+	return len(s)
+}
+
+func isWordByte(char byte) bool {
+	return char == '_' || char >= 0x80 ||
+		(char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9')
+}
+
+// hasKeywords 判断从第 i 个词开始是否依次为给定的关键字
+func hasKeywords(tokens []token, i int, keywords ...string) bool {
+	if i+len(keywords) > len(tokens) {
+		return false
+	}
+	for k, keyword := range keywords {
+		if tokens[i+k].kind != tokenWord || !strings.EqualFold(tokens[i+k].text, keyword) {
+			return false
+		}
+	}
+	return true
+}
+
+// skipKeywords 跳过其后出现的可选关键字序列
+func skipKeywords(tokens []token, i int, sequences ...[]string) int {
+	for matched := true; matched; {
+		matched = false
+		for _, sequence := range sequences {
+			if hasKeywords(tokens, i, sequence...) {
+				i += len(sequence)
+				matched = true
+			}
+		}
+	}
+	return i
+}
+
+// readQualifiedName 读取第 i 个词开始的名称（可能带限定，如 "public"."users"），
+// 返回去掉引号的各段和下一个词的位置
+func readQualifiedName(tokens []token, i int) ([]string, int) {
+	var parts []string
+	for i < len(tokens) && (tokens[i].kind == tokenWord || tokens[i].kind == tokenQuoted) {
+		parts = append(parts, unquoteIdentifier(tokens[i].text))
+		i++
+		if i+1 < len(tokens) && tokens[i].kind == tokenSymbol && tokens[i].text == "." {
+			i++
+			continue
+		}
+		break
+	}
+	return parts, i
+}
+
+// splitQualifiedName 拆分 public.users 形式的名称并去掉引号
+func splitQualifiedName(name string) []string {
+	parts, _ := readQualifiedName(scanTokens(name), 0)
+	return parts
+}
+
+// sameTableName 比较两个表名，两者都带模式名时才比较模式名
+func sameTableName(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	if len(a) > 1 && len(b) > 1 {
+		return strings.EqualFold(strings.Join(a, "."), strings.Join(b, "."))
+	}
+	return strings.EqualFold(a[len(a)-1], b[len(b)-1])
+}
+
+// createTableName 返回 CREATE TABLE 语句的表名
+func createTableName(tokens []token) ([]string, bool) {
+	if !hasKeywords(tokens, 0, "CREATE") {
+		return nil, false
+	}
+
+	i := skipKeywords(tokens, 1,
+		[]string{"OR", "REPLACE"}, []string{"GLOBAL"}, []string{"LOCAL"},
+		[]string{"TEMP"}, []string{"TEMPORARY"}, []string{"UNLOGGED"})
+	if !hasKeywords(tokens, i, "TABLE") {
+		return nil, false
+	}
+	i = skipKeywords(tokens, i+1, []string{"IF", "NOT", "EXISTS"})
+
+	name, _ := readQualifiedName(tokens, i)
+	return name, len(name) > 0
+}
+
+// readEnumType 记录 CREATE TYPE name AS ENUM ('a', 'b')
+func readEnumType(tokens []token, enumTypes map[string][]string) {
+	name, i := readQualifiedName(tokens, 2)
+	if len(name) == 0 || !hasKeywords(tokens, i, "AS", "ENUM") || i+2 >= len(tokens) || tokens[i+2].kind != tokenGroup {
+		return
+	}
+
+	group := tokens[i+2].text
+	var values []string
+	for _, value := range splitTopLevel(group[1:len(group)-1], func(r rune) bool { return r == ',' }) {
+		values = append(values, unquoteString(strings.TrimSpace(value)))
+	}
+	enumTypes[strings.ToLower(name[len(name)-1])] = values
+}
+
+// readCreateIndex 记录目标表上的 CREATE [UNIQUE] INDEX [name] ON table (columns)
+func readCreateIndex(tokens []token, tableName []string, schema *types.TableSchema) {
+	i := 1
+	unique := hasKeywords(tokens, i, "UNIQUE")
+	i = skipKeywords(tokens, i, []string{"UNIQUE"}, []string{"FULLTEXT"}, []string{"SPATIAL"})
+	if !hasKeywords(tokens, i, "INDEX") {
+		return
+	}
+	i = skipKeywords(tokens, i+1, []string{"CONCURRENTLY"}, []string{"IF", "NOT", "EXISTS"})
+
+	var index types.Index
+	if !hasKeywords(tokens, i, "ON") {
+		var name []string
+		name, i = readQualifiedName(tokens, i)
+		if len(name) > 0 {
+			index.Name = name[len(name)-1]
+		}
+	}
+	// MySQL 允许 USING 出现在 ON 之前
+	i = skipIndexType(tokens, i)
+	if !hasKeywords(tokens, i, "ON") {
+		return
+	}
+
+	table, i := readQualifiedName(tokens, skipKeywords(tokens, i+1, []string{"ONLY"}))
+	if !sameTableName(table, tableName) {
+		return
+	}
+	i = skipIndexType(tokens, i)
+	if i >= len(tokens) || tokens[i].kind != tokenGroup {
+		return
+	}
+
+	index.Columns = parseIndexColumns(tokens[i].text)
+	index.Unique = unique
+	if len(index.Columns) == 0 {
+		return
+	}
+	schema.Indexes = append(schema.Indexes, index)
+
+	// 部分唯一索引不代表列的值唯一
+	partial := false
+	for _, tok := range tokens[i+1:] {
+		if tok.kind == tokenWord && strings.EqualFold(tok.text, "WHERE") {
+			partial = true
+		}
+	}
+	if unique && !partial {
+		schema.UniqueKeys = append(schema.UniqueKeys, index.Columns)
+	}
+}
+
+func skipIndexType(tokens []token, i int) int {
+	if hasKeywords(tokens, i, "USING") && i+1 < len(tokens) {
+		return i + 2
+	}
+	return i
+}
+
+// readComment 记录 COMMENT ON TABLE name IS '...' 和 COMMENT ON COLUMN table.column IS '...'
+func readComment(tokens []token, tableName []string, schema *types.TableSchema) {
+	if len(tokens) < 3 {
+		return
+	}
+	object := strings.ToUpper(tokens[2].text)
+	if object != "TABLE" && object != "COLUMN" {
+		return
+	}
+
+	name, i := readQualifiedName(tokens, 3)
+	if !hasKeywords(tokens, i, "IS") || i+1 >= len(tokens) {
+		return
+	}
+
+	var comment string
+	if tokens[i+1].kind == tokenString {
+		comment = unquoteString(tokens[i+1].text)
+	}
+
+	if object == "TABLE" {
+		if sameTableName(name, tableName) {
+			schema.Comment = comment
+		}
+		return
+	}
+
+	if len(name) < 2 || !sameTableName(name[:len(name)-1], tableName) {
+		return
+	}
+	for idx := range schema.Fields {
+		if schema.Fields[idx].Name == name[len(name)-1] {
+			schema.Fields[idx].Comment = comment
+		}
+	}
+}
+
+// readAlterTable 记录 ALTER TABLE 添加的约束、索引和注释
+func readAlterTable(statement ddlStatement, tableName []string, schema *types.TableSchema) {
+	tokens := statement.tokens
+	i := skipKeywords(tokens, 2, []string{"IF", "EXISTS"}, []string{"ONLY"})
+
+	name, i := readQualifiedName(tokens, i)
+	if !sameTableName(name, tableName) {
+		return
+	}
+
+	for _, action := range splitTopLevel(statement.text(i), func(r rune) bool { return r == ',' }) {
+		words := splitTopLevel(strings.TrimSpace(action), func(r rune) bool { return r == '=' || unicode.IsSpace(r) })
+		if len(words) < 2 {
+			continue
+		}
+
+		switch strings.ToUpper(words[0]) {
+		case "ADD":
+			definition := strings.Join(words[1:], " ")
+			switch keyword := strings.ToUpper(words[1]); {
+			case keyword == "CONSTRAINT" || isConstraintKeyword(keyword):
+				parseTableConstraint(definition, schema)
+			case keyword == "INDEX" || keyword == "KEY" || keyword == "FULLTEXT" || keyword == "SPATIAL":
+				if index := parseIndexDefinition(definition); len(index.Columns) > 0 {
+					schema.Indexes = append(schema.Indexes, index)
+				}
+			}
+		case "COMMENT":
+			// MySQL：ALTER TABLE t COMMENT = '...'
+			schema.Comment = unquoteString(words[len(words)-1])
+		}
+	}
+}
+
+// parseIndexDefinition 读取非唯一索引，例如 "KEY idx_user (user_id)" 或 "FULLTEXT INDEX (body)"
+func parseIndexDefinition(definition string) types.Index {
+	index := types.Index{Columns: parseIndexColumns(definition)}
+
+	for _, word := range splitTopLevel(definition, unicode.IsSpace) {
+		switch strings.ToUpper(word) {
+		case "INDEX", "KEY", "FULLTEXT", "SPATIAL":
+			continue
+		}
+		if name, _, found := strings.Cut(word, "("); found || !strings.HasPrefix(word, "(") {
+			index.Name = unquoteIdentifier(name)
+		}
+		break
+	}
+
+	return index
+}
+
+// applyEnumTypes 解析使用同一脚本中创建的枚举类型声明的列
+func applyEnumTypes(schema *types.TableSchema, enumTypes map[string][]string) {
+	if len(enumTypes) == 0 {
+		return
+	}
+
+	for idx := range schema.Fields {
+		field := &schema.Fields[idx]
+		isArray := field.Type == types.PostgreSQLTypeArray && field.ElementType == types.PostgreSQLTypeUserDefined
+		if field.Type != types.PostgreSQLTypeUserDefined && !isArray {
+			continue
+		}
+
+		typeName, _, _ := strings.Cut(field.RawType, "[")
+		if fields := strings.Fields(typeName); len(fields) > 0 && isArray && strings.EqualFold(fields[len(fields)-1], "array") {
+			typeName = strings.Join(fields[:len(fields)-1], " ")
+		}
+		name := splitQualifiedName(strings.TrimSpace(typeName))
+		if len(name) == 0 {
+			continue
+		}
+
+		values, exists := enumTypes[strings.ToLower(name[len(name)-1])]
+		if !exists {
+			continue
+		}
+		if isArray {
+			field.ElementType = types.PostgreSQLTypeEnum
+		} else {
+			field.Type = types.PostgreSQLTypeEnum
+		}
+		field.EnumValues = values
+	}
+}
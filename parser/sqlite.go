@@ -0,0 +1,219 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/otkinlife/crud-generator/types"
+)
+
+type SQLiteParser struct{}
+
+func NewSQLiteParser() *SQLiteParser {
+	return &SQLiteParser{}
+}
+
+// ParseCreateStatement 解析 DDL 脚本中的第一条 CREATE TABLE 语句
+func (p *SQLiteParser) ParseCreateStatement(createSQL string) (*types.TableSchema, error) {
+	return readScript(createSQL, "", p.parseCreateTable)
+}
+
+// ParseScript 解析 tableName 的 CREATE TABLE 语句以及脚本中引用该表的其他语句
+func (p *SQLiteParser) ParseScript(script, tableName string) (*types.TableSchema, error) {
+	return readScript(script, tableName, p.parseCreateTable)
+}
+
+func (p *SQLiteParser) parseCreateTable(createSQL string) (*types.TableSchema, error) {
+	createSQL = strings.TrimSpace(createSQL)
+
+	tableNameRegex := regexp.MustCompile("(?i)CREATE\\s+(?:TEMP\\s+|TEMPORARY\\s+)?TABLE\\s+(?:IF\\s+NOT\\s+EXISTS\\s+)?(?:(?:\"[^\"]+\"|`[^`]+`|\\[[^\\]]+\\]|\\w+)\\.)?(\"[^\"]+\"|`[^`]+`|\\[[^\\]]+\\]|\\w+)")
+	loc := tableNameRegex.FindStringSubmatchIndex(createSQL)
+	if loc == nil {
+		return nil, fmt.Errorf("cannot extract table name from CREATE statement")
+	}
+
+	tableName := unquoteIdentifier(createSQL[loc[2]:loc[3]])
+
+	fieldsContent, _, ok := extractParenthesized(createSQL[loc[1]:])
+	if !ok {
+		return nil, fmt.Errorf("cannot extract fields from CREATE statement")
+	}
+
+	schema := &types.TableSchema{
+		TableName: tableName,
+	}
+
+	for _, definition := range splitTopLevel(fieldsContent, func(r rune) bool { return r == ',' }) {
+		definition = strings.TrimSpace(definition)
+		if definition == "" {
+			continue
+		}
+
+		tokens := splitTopLevel(definition, unicode.IsSpace)
+		if strings.EqualFold(tokens[0], "CONSTRAINT") || isConstraintKeyword(tokens[0]) {
+			parseTableConstraint(definition, schema)
+			continue
+		}
+
+		field, typeEnd, err := p.parseField(tokens)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse field '%s': %w", definition, err)
+		}
+		parseInlineConstraints(field.Name, tokens[typeEnd:], schema)
+		schema.Fields = append(schema.Fields, field)
+	}
+
+	applyKeyConstraints(schema)
+
+	return schema, nil
+}
+
+// parseField 解析列定义，返回类型之后第一个词的位置
+func (p *SQLiteParser) parseField(tokens []string) (types.TableField, int, error) {
+	if len(tokens) == 0 {
+		return types.TableField{}, 0, fmt.Errorf("empty field definition")
+	}
+
+	// SQLite 允许列没有类型，类型也可以由多个词组成，例如 "unsigned big int"
+	typeEnd := 1
+	for typeEnd < len(tokens) && !isSQLiteColumnConstraint(tokens[typeEnd]) {
+		typeEnd++
+	}
+	typeStr := strings.Join(tokens[1:typeEnd], " ")
+
+	field, err := p.ParseColumnType(typeStr)
+	if err != nil {
+		return types.TableField{}, 0, fmt.Errorf("failed to parse data type '%s': %w", typeStr, err)
+	}
+	field.Name = unquoteIdentifier(tokens[0])
+
+	for i := typeEnd; i < len(tokens); i++ {
+		switch strings.ToUpper(tokens[i]) {
+		case "NOT":
+			if i+1 < len(tokens) && strings.EqualFold(tokens[i+1], "NULL") {
+				field.NotNull = true
+				i++
+			}
+		case "DEFAULT":
+			if i+1 < len(tokens) {
+				defaultVal := tokens[i+1]
+				field.DefaultValue = &defaultVal
+				i++
+			}
+		case "PRIMARY":
+			field.PrimaryKey = true
+			field.NotNull = true
+			// INTEGER PRIMARY KEY 列是 rowid 的别名
+			if strings.EqualFold(typeStr, "integer") {
+				field.AutoIncrement = true
+			}
+		case "AUTOINCREMENT":
+			field.AutoIncrement = true
+		case "UNIQUE":
+			field.Unique = true
+		case "COLLATE":
+			i++
+		case "AS":
+			// GENERATED ALWAYS AS (expr) 或简写 AS (expr)
+			field.ReadOnly = true
+		case "REFERENCES", "CHECK":
+			i = len(tokens)
+		}
+	}
+
+	return field, typeEnd, nil
+}
+
+// ParseColumnType 按 SQLite 的类型亲和性规则把声明的类型转换为表字段
+func (p *SQLiteParser) ParseColumnType(typeStr string) (types.TableField, error) {
+	rawType := strings.TrimSpace(typeStr)
+	field := types.TableField{RawType: rawType}
+
+	typeRegex := regexp.MustCompile(`(?s)^([A-Za-z_][\w ]*?)\s*(?:\((.*)\))?$`)
+	matches := typeRegex.FindStringSubmatch(rawType)
+	if rawType != "" && matches == nil {
+		return types.TableField{}, fmt.Errorf("unsupported SQLite type: %s", rawType)
+	}
+
+	var baseType string
+	var sizes []int
+	if matches != nil {
+		baseType = strings.ToLower(strings.Join(strings.Fields(matches[1]), " "))
+		if matches[2] != "" {
+			for _, part := range strings.Split(matches[2], ",") {
+				size, err := strconv.Atoi(strings.TrimSpace(part))
+				if err != nil {
+					return types.TableField{}, fmt.Errorf("invalid type arguments: %s", rawType)
+				}
+				sizes = append(sizes, size)
+			}
+		}
+	}
+	size := func(i int) int {
+		if i < len(sizes) {
+			return sizes[i]
+		}
+		return 0
+	}
+
+	switch {
+	case strings.Contains(baseType, "int"):
+		switch baseType {
+		case "bigint", "int8", "unsigned big int":
+			field.Type = types.PostgreSQLTypeBigint
+		case "smallint", "tinyint", "int2":
+			field.Type = types.PostgreSQLTypeSmallint
+		default:
+			field.Type = types.PostgreSQLTypeInteger
+		}
+	case strings.Contains(baseType, "char"), strings.Contains(baseType, "clob"), strings.Contains(baseType, "text"):
+		switch {
+		case strings.Contains(baseType, "varying") || strings.Contains(baseType, "varchar"):
+			field.Type = types.PostgreSQLTypeVarchar
+		case strings.Contains(baseType, "char") && size(0) > 0:
+			field.Type = types.PostgreSQLTypeChar
+		default:
+			field.Type = types.PostgreSQLTypeText
+		}
+		field.Length = size(0)
+	case baseType == "" || strings.Contains(baseType, "blob"):
+		field.Type = types.PostgreSQLTypeBytea
+	case strings.Contains(baseType, "real"), strings.Contains(baseType, "floa"), strings.Contains(baseType, "doub"):
+		field.Type = types.PostgreSQLTypeDouble
+	default:
+		switch baseType {
+		case "bool", "boolean":
+			field.Type = types.PostgreSQLTypeBoolean
+		case "date":
+			field.Type = types.PostgreSQLTypeDate
+		case "datetime", "timestamp":
+			field.Type = types.PostgreSQLTypeTimestamp
+		case "time":
+			field.Type = types.PostgreSQLTypeTime
+		case "json":
+			field.Type = types.PostgreSQLTypeJSON
+		case "jsonb":
+			field.Type = types.PostgreSQLTypeJSONB
+		case "uuid":
+			field.Type = types.PostgreSQLTypeUUID
+		default:
+			field.Type = types.PostgreSQLTypeNumeric
+			field.Precision = size(0)
+			field.Scale = size(1)
+		}
+	}
+
+	return field, nil
+}
+
+// isSQLiteColumnConstraint 判断一个词是否是列定义中约束部分的开头
+func isSQLiteColumnConstraint(word string) bool {
+	switch strings.ToUpper(word) {
+	case "CONSTRAINT", "PRIMARY", "NOT", "NULL", "UNIQUE", "CHECK", "DEFAULT", "COLLATE", "REFERENCES", "GENERATED", "AS":
+		return true
+	}
+	return false
+}
@@ -37,10 +37,16 @@ func (cs *ConfigService) CreateConfigFromStruct(config *TableConfig) error {
 		DBTableName:           config.TableName,
 		ConnectionID:          config.ConnectionID,
 		CreateStatement:       config.CreateStatement,
+		PrimaryKey:            config.PrimaryKey,
 		QueryPagination:       config.QueryPagination,
 		QueryDisplayFields:    config.QueryDisplayFields,
 		QuerySearchFields:     config.QuerySearchFields,
 		QuerySortableFields:   config.QuerySortableFields,
+		QueryDefaultSort:      config.QueryDefaultSort,
+		QueryFilterFields:     config.QueryFilterFields,
+		QueryHiddenFields:     config.QueryHiddenFields,
+		QueryRelations:        config.QueryRelations,
+		QueryAggregate:        config.QueryAggregate,
 		CreateCreatableFields: config.CreateCreatableFields,
 		CreateValidationRules: config.CreateValidationRules,
 		CreateDefaultValues:   config.CreateDefaultValues,
@@ -69,10 +75,16 @@ func (cs *ConfigService) GetConfigByNameAsStruct(name string) (*TableConfig, err
 		TableName:             internalConfig.DBTableName,
 		ConnectionID:          internalConfig.ConnectionID,
 		CreateStatement:       internalConfig.CreateStatement,
+		PrimaryKey:            internalConfig.PrimaryKey,
 		QueryPagination:       internalConfig.QueryPagination,
 		QueryDisplayFields:    internalConfig.QueryDisplayFields,
 		QuerySearchFields:     internalConfig.QuerySearchFields,
 		QuerySortableFields:   internalConfig.QuerySortableFields,
+		QueryDefaultSort:      internalConfig.QueryDefaultSort,
+		QueryFilterFields:     internalConfig.QueryFilterFields,
+		QueryHiddenFields:     internalConfig.QueryHiddenFields,
+		QueryRelations:        internalConfig.QueryRelations,
+		QueryAggregate:        internalConfig.QueryAggregate,
 		CreateCreatableFields: internalConfig.CreateCreatableFields,
 		CreateValidationRules: internalConfig.CreateValidationRules,
 		CreateDefaultValues:   internalConfig.CreateDefaultValues,
@@ -99,10 +111,16 @@ func (cs *ConfigService) GetConfigByIDAsStruct(id uint) (*TableConfig, error) {
 		TableName:             internalConfig.DBTableName,
 		ConnectionID:          internalConfig.ConnectionID,
 		CreateStatement:       internalConfig.CreateStatement,
+		PrimaryKey:            internalConfig.PrimaryKey,
 		QueryPagination:       internalConfig.QueryPagination,
 		QueryDisplayFields:    internalConfig.QueryDisplayFields,
 		QuerySearchFields:     internalConfig.QuerySearchFields,
 		QuerySortableFields:   internalConfig.QuerySortableFields,
+		QueryDefaultSort:      internalConfig.QueryDefaultSort,
+		QueryFilterFields:     internalConfig.QueryFilterFields,
+		QueryHiddenFields:     internalConfig.QueryHiddenFields,
+		QueryRelations:        internalConfig.QueryRelations,
+		QueryAggregate:        internalConfig.QueryAggregate,
 		CreateCreatableFields: internalConfig.CreateCreatableFields,
 		CreateValidationRules: internalConfig.CreateValidationRules,
 		CreateDefaultValues:   internalConfig.CreateDefaultValues,
@@ -130,10 +148,16 @@ func (cs *ConfigService) GetConfigsAsStruct(connectionID string) ([]*TableConfig
 			TableName:             internalConfig.TableName,
 			ConnectionID:          internalConfig.ConnectionID,
 			CreateStatement:       internalConfig.CreateStatement,
+			PrimaryKey:            internalConfig.PrimaryKey,
 			QueryPagination:       internalConfig.QueryPagination,
 			QueryDisplayFields:    internalConfig.QueryDisplayFields,
 			QuerySearchFields:     internalConfig.QuerySearchFields,
 			QuerySortableFields:   internalConfig.QuerySortableFields,
+			QueryDefaultSort:      internalConfig.QueryDefaultSort,
+			QueryFilterFields:     internalConfig.QueryFilterFields,
+			QueryHiddenFields:     internalConfig.QueryHiddenFields,
+			QueryRelations:        internalConfig.QueryRelations,
+			QueryAggregate:        internalConfig.QueryAggregate,
 			CreateCreatableFields: internalConfig.CreateCreatableFields,
 			CreateValidationRules: internalConfig.CreateValidationRules,
 			CreateDefaultValues:   internalConfig.CreateDefaultValues,
@@ -156,10 +180,16 @@ func (cs *ConfigService) UpdateConfigFromStruct(id uint, config *TableConfig) er
 		DBTableName:           config.TableName,
 		ConnectionID:          config.ConnectionID,
 		CreateStatement:       config.CreateStatement,
+		PrimaryKey:            config.PrimaryKey,
 		QueryPagination:       config.QueryPagination,
 		QueryDisplayFields:    config.QueryDisplayFields,
 		QuerySearchFields:     config.QuerySearchFields,
 		QuerySortableFields:   config.QuerySortableFields,
+		QueryDefaultSort:      config.QueryDefaultSort,
+		QueryFilterFields:     config.QueryFilterFields,
+		QueryHiddenFields:     config.QueryHiddenFields,
+		QueryRelations:        config.QueryRelations,
+		QueryAggregate:        config.QueryAggregate,
 		CreateCreatableFields: config.CreateCreatableFields,
 		CreateValidationRules: config.CreateValidationRules,
 		CreateDefaultValues:   config.CreateDefaultValues,
@@ -189,6 +219,115 @@ func (cs *ConfigService) TestConfigConnection(configID uint) error {
 	return cs.internal.TestConfigConnection(configID)
 }
 
+// GetTableInfo introspects a live table and converts it to the package struct
+func (cs *ConfigService) GetTableInfo(connectionID, tableName string) (*TableInfo, error) {
+	schema, rowCount, err := cs.internal.IntrospectTable(connectionID, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &TableInfo{
+		Name:     schema.TableName,
+		Schema:   schema.Schema,
+		Comment:  schema.Comment,
+		RowCount: rowCount,
+	}
+
+	for _, field := range schema.Fields {
+		column := ColumnInfo{
+			Name:            field.Name,
+			Type:            field.RawType,
+			Nullable:        !field.NotNull,
+			IsPrimaryKey:    field.PrimaryKey,
+			IsAutoIncrement: field.AutoIncrement,
+			Comment:         field.Comment,
+		}
+		if column.Type == "" {
+			column.Type = string(field.Type)
+		}
+		if field.DefaultValue != nil {
+			column.DefaultValue = *field.DefaultValue
+		}
+		info.Columns = append(info.Columns, column)
+	}
+
+	return info, nil
+}
+
+// ScaffoldConfig generates a default config for a table and converts to package struct.
+// The returned config is not saved.
+func (cs *ConfigService) ScaffoldConfig(connectionID, tableName, createStatement string) (*TableConfig, error) {
+	internalConfig, err := cs.internal.ScaffoldConfig(connectionID, tableName, createStatement)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert internal model to package struct
+	return &TableConfig{
+		Name:                  internalConfig.Name,
+		TableName:             internalConfig.DBTableName,
+		ConnectionID:          internalConfig.ConnectionID,
+		CreateStatement:       internalConfig.CreateStatement,
+		PrimaryKey:            internalConfig.PrimaryKey,
+		QueryPagination:       internalConfig.QueryPagination,
+		QueryDisplayFields:    internalConfig.QueryDisplayFields,
+		QuerySearchFields:     internalConfig.QuerySearchFields,
+		QuerySortableFields:   internalConfig.QuerySortableFields,
+		QueryDefaultSort:      internalConfig.QueryDefaultSort,
+		QueryFilterFields:     internalConfig.QueryFilterFields,
+		QueryHiddenFields:     internalConfig.QueryHiddenFields,
+		QueryRelations:        internalConfig.QueryRelations,
+		QueryAggregate:        internalConfig.QueryAggregate,
+		CreateCreatableFields: internalConfig.CreateCreatableFields,
+		CreateValidationRules: internalConfig.CreateValidationRules,
+		CreateDefaultValues:   internalConfig.CreateDefaultValues,
+		UpdateUpdatableFields: internalConfig.UpdateUpdatableFields,
+		UpdateValidationRules: internalConfig.UpdateValidationRules,
+		Description:           internalConfig.Description,
+		Tags:                  internalConfig.Tags,
+		IsActive:              internalConfig.IsActive,
+		Version:               internalConfig.Version,
+	}, nil
+}
+
+// TestConnectionWithTable tests a database connection and access to a table
+func (cs *ConfigService) TestConnectionWithTable(connectionID, tableName string) error {
+	return cs.internal.TestConnectionWithTable(connectionID, tableName)
+}
+
+// DetectDrift compares a config with the live table and converts the report to package struct
+func (cs *ConfigService) DetectDrift(configID uint) (*DriftReport, error) {
+	report, err := cs.internal.DetectDrift(configID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DriftReport{
+		ConfigID:         report.ConfigID,
+		ConfigName:       report.ConfigName,
+		TableName:        report.TableName,
+		HasDrift:         report.HasDrift,
+		MissingColumns:   make([]MissingColumn, len(report.MissingColumns)),
+		TypeMismatches:   make([]TypeMismatch, len(report.TypeMismatches)),
+		UnexposedColumns: report.UnexposedColumns,
+	}
+	for i, column := range report.MissingColumns {
+		result.MissingColumns[i] = MissingColumn{
+			Field:  column.Field,
+			Source: column.Source,
+		}
+	}
+	for i, mismatch := range report.TypeMismatches {
+		result.TypeMismatches[i] = TypeMismatch{
+			Field:        mismatch.Field,
+			DeclaredType: mismatch.DeclaredType,
+			LiveType:     mismatch.LiveType,
+		}
+	}
+
+	return result, nil
+}
+
 // CRUDService wraps the existing CRUD service for the package API
 type CRUDService struct {
 	internal *services.CRUDService
@@ -208,11 +347,33 @@ func NewCRUDService(dbManager *DatabaseManager) *CRUDService {
 
 // List performs a list operation
 func (cs *CRUDService) List(configName string, params *QueryParams) (*QueryResult, error) {
-	// Convert package params to internal params
+	result, err := cs.internal.List(configName, convertQueryParams(params))
+	if err != nil {
+		return nil, err
+	}
+	return convertQueryResult(result), nil
+}
+
+// ListChildren lists the child records of a relation
+func (cs *CRUDService) ListChildren(configName string, id interface{}, relation string, params *QueryParams) (*QueryResult, error) {
+	result, err := cs.internal.ListChildren(configName, id, relation, convertQueryParams(params))
+	if err != nil {
+		return nil, err
+	}
+	return convertQueryResult(result), nil
+}
+
+// convertQueryParams converts package params to internal params
+func convertQueryParams(params *QueryParams) *types.QueryParams {
 	internalParams := &types.QueryParams{
 		Page:     params.Page,
 		PageSize: params.PageSize,
 		Search:   params.Search,
+		Fields:   params.Fields,
+
+		CursorMode: params.CursorMode,
+		Cursor:     params.Cursor,
+		SkipCount:  params.SkipCount,
 	}
 
 	// Convert sort fields
@@ -236,21 +397,86 @@ func (cs *CRUDService) List(configName string, params *QueryParams) (*QueryResul
 		internalParams.Sort = internalSort
 	}
 
-	result, err := cs.internal.List(configName, internalParams)
-	if err != nil {
-		return nil, err
+	internalParams.Filters = convertFilters(params.Filters)
+	if params.Where != nil {
+		internalParams.Where = convertFilterNode(params.Where)
 	}
 
-	// Convert internal result to package result
+	return internalParams
+}
+
+// convertQueryResult converts an internal result to the package result
+func convertQueryResult(result *types.QueryResult) *QueryResult {
 	return &QueryResult{
 		Data:       result.Data,
 		Total:      result.Total,
 		Page:       result.Page,
 		PageSize:   result.PageSize,
 		TotalPages: result.TotalPages,
+		PrimaryKey: result.PrimaryKey,
+		NextCursor: result.NextCursor,
+		PrevCursor: result.PrevCursor,
+	}
+}
+
+// Aggregate groups and measures the records matching the search and filters
+func (cs *CRUDService) Aggregate(configName string, params *AggregateParams) (*AggregateResult, error) {
+	internalParams := &types.AggregateParams{
+		Search:  params.Search,
+		Filters: convertFilters(params.Filters),
+		GroupBy: params.GroupBy,
+	}
+	if params.Where != nil {
+		internalParams.Where = convertFilterNode(params.Where)
+	}
+	for _, metric := range params.Metrics {
+		internalParams.Metrics = append(internalParams.Metrics, types.Metric{
+			Function: types.AggregateFunction(metric.Function),
+			Field:    metric.Field,
+		})
+	}
+
+	result, err := cs.internal.Aggregate(configName, internalParams)
+	if err != nil {
+		return nil, err
+	}
+	return &AggregateResult{
+		GroupBy: result.GroupBy,
+		Metrics: result.Metrics,
+		Rows:    result.Rows,
 	}, nil
 }
 
+// Facets counts the records per dictionary item of the dict-backed search fields
+func (cs *CRUDService) Facets(configName string, params *FacetParams) (*FacetResult, error) {
+	internalParams := &types.FacetParams{
+		Search:  params.Search,
+		Filters: convertFilters(params.Filters),
+		Fields:  params.Fields,
+	}
+	if params.Where != nil {
+		internalParams.Where = convertFilterNode(params.Where)
+	}
+
+	result, err := cs.internal.Facets(configName, internalParams)
+	if err != nil {
+		return nil, err
+	}
+	facets := make([]Facet, len(result.Facets))
+	for i, facet := range result.Facets {
+		facets[i] = Facet{Field: facet.Field, Values: make([]FacetValue, len(facet.Values))}
+		for j, value := range facet.Values {
+			facets[i].Values[j] = FacetValue{Value: value.Value, Label: value.Label, Count: value.Count}
+		}
+	}
+	return &FacetResult{Facets: facets}, nil
+}
+
+// Get retrieves a single record by its key
+func (cs *CRUDService) Get(configName string, id interface{}) (map[string]interface{}, error) {
+	return cs.internal.Get(configName, id)
+}
+
 // Create creates a new record
 func (cs *CRUDService) Create(configName string, data map[string]interface{}) (*CRUDResult, error) {
 	result, err := cs.internal.Create(configName, data)
@@ -333,3 +559,138 @@ func (cs *CRUDService) GetDict(configName, field string) ([]DictItem, error) {
 
 	return dictItems, nil
 }
+
+// ListViews lists the views of owner and the views shared by other users
+func (cs *CRUDService) ListViews(configName, owner string) ([]SavedView, error) {
+	views, err := cs.internal.ListViews(configName, owner)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]SavedView, len(views))
+	for i := range views {
+		result[i] = *convertSavedView(&views[i])
+	}
+	return result, nil
+}
+
+// GetView retrieves a view by name
+func (cs *CRUDService) GetView(configName, name, owner string) (*SavedView, error) {
+	view, err := cs.internal.GetView(configName, name, owner)
+	if err != nil {
+		return nil, err
+	}
+	return convertSavedView(view), nil
+}
+
+// CreateView saves a view of owner
+func (cs *CRUDService) CreateView(configName, owner string, view *SavedView) (*SavedView, error) {
+	created, err := cs.internal.CreateView(configName, owner, convertViewParams(view))
+	if err != nil {
+		return nil, err
+	}
+	return convertSavedView(created), nil
+}
+
+// UpdateView replaces the settings of a view of owner
+func (cs *CRUDService) UpdateView(configName, name, owner string, view *SavedView) (*SavedView, error) {
+	updated, err := cs.internal.UpdateView(configName, name, owner, convertViewParams(view))
+	if err != nil {
+		return nil, err
+	}
+	return convertSavedView(updated), nil
+}
+
+// DeleteView deletes a view of owner
+func (cs *CRUDService) DeleteView(configName, name, owner string) error {
+	return cs.internal.DeleteView(configName, name, owner)
+}
+
+// convertViewParams converts the settings of a view to internal package format
+func convertViewParams(view *SavedView) *types.SavedView {
+	internal := &types.SavedView{
+		Name:     view.Name,
+		Shared:   view.Shared,
+		Search:   view.Search,
+		Sort:     view.Sort,
+		Fields:   view.Fields,
+		PageSize: view.PageSize,
+	}
+	if view.Where != nil {
+		internal.Where = convertFilterNode(view.Where)
+	}
+	return internal
+}
+
+// convertSavedView converts an internal view to the package view
+func convertSavedView(view *types.SavedView) *SavedView {
+	result := &SavedView{
+		ID:         view.ID,
+		ConfigName: view.ConfigName,
+		Name:       view.Name,
+		Owner:      view.Owner,
+		Shared:     view.Shared,
+		Search:     view.Search,
+		Sort:       view.Sort,
+		Fields:     view.Fields,
+		PageSize:   view.PageSize,
+		CreatedAt:  view.CreatedAt,
+		UpdatedAt:  view.UpdatedAt,
+	}
+	if view.Where != nil {
+		result.Where = filterNodeFromInternal(view.Where)
+	}
+	return result
+}
+
+// convertFilters converts filters from main package format to internal package format
+func convertFilters(filters []Filter) []types.Filter {
+	var internal []types.Filter
+	for _, filter := range filters {
+		internal = append(internal, types.Filter{
+			Field:    filter.Field,
+			Operator: types.FilterOperator(filter.Operator),
+			Value:    filter.Value,
+		})
+	}
+	return internal
+}
+
+// convertFilterNode converts a filter expression from main package format to internal package format
+func convertFilterNode(node *FilterNode) *types.FilterNode {
+	internal := &types.FilterNode{
+		Filter: types.Filter{
+			Field:    node.Field,
+			Operator: types.FilterOperator(node.Operator),
+			Value:    node.Value,
+		},
+	}
+	for i := range node.And {
+		internal.And = append(internal.And, *convertFilterNode(&node.And[i]))
+	}
+	for i := range node.Or {
+		internal.Or = append(internal.Or, *convertFilterNode(&node.Or[i]))
+	}
+	if node.Not != nil {
+		internal.Not = convertFilterNode(node.Not)
+	}
+	return internal
+}
+
+// filterNodeFromInternal converts a filter expression from internal package format to main package format
+func filterNodeFromInternal(internal *types.FilterNode) *FilterNode {
+	node := &FilterNode{
+		Field:    internal.Field,
+		Operator: string(internal.Operator),
+		Value:    internal.Value,
+	}
+	for i := range internal.And {
+		node.And = append(node.And, *filterNodeFromInternal(&internal.And[i]))
+	}
+	for i := range internal.Or {
+		node.Or = append(node.Or, *filterNodeFromInternal(&internal.Or[i]))
+	}
+	if internal.Not != nil {
+		node.Not = filterNodeFromInternal(internal.Not)
+	}
+	return node
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/dialect"
+	"github.com/otkinlife/crud-generator/generator"
+	"github.com/otkinlife/crud-generator/types"
+	"gorm.io/gorm/clause"
+)
+
+// Aggregate 按允许的分组字段统计记录，搜索和过滤条件与 List 相同
+func (s *CRUDService) Aggregate(configName string, params *types.AggregateParams) (*types.AggregateResult, error) {
+	config, err := s.GetConfigByName(configName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取对应的数据库连接
+	db, err := s.getBusinessDB(config.ConnectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	sqlDialect, err := dialect.FromDB(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select SQL dialect: %w", err)
+	}
+
+	// 隐藏字段不能分组或统计
+	projection, err := projectionConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	if config.QueryAggregate != "" {
+		projection.QueryConfig.Aggregate = &types.AggregateConfig{}
+		if err := json.Unmarshal([]byte(config.QueryAggregate), projection.QueryConfig.Aggregate); err != nil {
+			return nil, fmt.Errorf("failed to parse aggregate settings: %w", err)
+		}
+	}
+
+	selectList, groupColumns, metricKeys, err := generator.AggregateColumns(sqlDialect, projection, params.GroupBy, params.Metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	query, _, err := s.whereListConditions(db.Table(config.DBTableName), config, db, sqlDialect, projection, params.Search, params.Filters, params.Where)
+	if err != nil {
+		return nil, err
+	}
+	query = query.Select(selectList)
+	if len(groupColumns) > 0 {
+		// Group 会把参数整体作为标识符引用，分组列已经引用过
+		groupList := strings.Join(groupColumns, ", ")
+		query = query.Clauses(clause.GroupBy{Columns: []clause.Column{{Name: groupList, Raw: true}}}).Order(groupList)
+	}
+
+	var rows []map[string]interface{}
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate records: %w", err)
+	}
+	// MySQL 的分组值和 DECIMAL 结果为 []byte
+	unwrapExpressionValues(rows)
+	for _, row := range rows {
+		for key, value := range row {
+			if raw, ok := value.([]byte); ok {
+				row[key] = string(raw)
+			}
+		}
+	}
+
+	return &types.AggregateResult{
+		GroupBy: params.GroupBy,
+		Metrics: metricKeys,
+		Rows:    rows,
+	}, nil
+}
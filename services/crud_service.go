@@ -10,9 +10,12 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/otkinlife/crud-generator/database"
+	"github.com/otkinlife/crud-generator/dialect"
+	"github.com/otkinlife/crud-generator/generator"
 	"github.com/otkinlife/crud-generator/models"
 	"github.com/otkinlife/crud-generator/types"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CRUDService struct {
@@ -83,27 +86,34 @@ func (s *CRUDService) List(configName string, params *types.QueryParams) (*types
 	if err != nil {
 		return nil, err
 	}
+	return s.list(config, params, nil)
+}
 
+// list 查询记录列表，scope 为额外的等值条件（例如子表的外键），不受过滤字段配置的限制
+func (s *CRUDService) list(config *models.TableConfiguration, params *types.QueryParams, scope map[string]interface{}) (*types.QueryResult, error) {
 	// 获取对应的数据库连接
 	db, err := s.getBusinessDB(config.ConnectionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
 
-	// 解析展示字段配置
-	var displayFields []types.DisplayField
-	if config.QueryDisplayFields != "" {
-		if err := json.Unmarshal([]byte(config.QueryDisplayFields), &displayFields); err != nil {
-			return nil, fmt.Errorf("failed to parse display fields: %w", err)
-		}
+	sqlDialect, err := dialect.FromDB(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select SQL dialect: %w", err)
 	}
 
-	// 解析搜索字段配置
-	var searchFields []types.SearchField
-	if config.QuerySearchFields != "" {
-		if err := json.Unmarshal([]byte(config.QuerySearchFields), &searchFields); err != nil {
-			return nil, fmt.Errorf("failed to parse search fields: %w", err)
-		}
+	// 解析展示字段和隐藏字段配置
+	projection, err := projectionConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := s.tableSchema(config, db)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := parseEncodedFields(config, schema)
+	if err != nil {
+		return nil, err
 	}
 
 	// 解析排序字段配置
@@ -116,115 +126,96 @@ func (s *CRUDService) List(configName string, params *types.QueryParams) (*types
 
 	// 构建查询
 	query := db.Table(config.DBTableName)
+	for column, value := range scope {
+		query = query.Where(fmt.Sprintf("%s = ?", sqlDialect.QuoteIdentifier(column)), value)
+	}
 
-	// 应用搜索条件
-	if params.Search != nil && len(searchFields) > 0 {
-		for _, searchField := range searchFields {
-			if searchValue, exists := params.Search[searchField.Field]; exists && searchValue != nil {
-				switch searchField.Type {
-				case types.SearchTypeFuzzy:
-					query = query.Where(fmt.Sprintf("%s ILIKE ?", searchField.Field), fmt.Sprintf("%%%v%%", searchValue))
-				case types.SearchTypeExact:
-					query = query.Where(fmt.Sprintf("%s = ?", searchField.Field), searchValue)
-				case types.SearchTypeRange:
-					// 处理范围搜索：先尝试直接转换，然后尝试JSON解析
-					var rangeMap map[string]interface{}
-
-					if directMap, ok := searchValue.(map[string]interface{}); ok {
-						rangeMap = directMap
-					} else if jsonStr, ok := searchValue.(string); ok && jsonStr != "" {
-						// 如果是字符串，尝试解析JSON
-						if err := json.Unmarshal([]byte(jsonStr), &rangeMap); err != nil {
-							// JSON解析失败，跳过这个搜索条件
-							continue
-						}
-					}
-
-					if rangeMap != nil {
-						if min, exists := rangeMap["min"]; exists && min != nil {
-							query = query.Where(fmt.Sprintf("%s >= ?", searchField.Field), min)
-						}
-						if max, exists := rangeMap["max"]; exists && max != nil {
-							query = query.Where(fmt.Sprintf("%s <= ?", searchField.Field), max)
-						}
-					}
-				case types.SearchTypeSingle, types.SearchTypeMulti:
-					query = query.Where(fmt.Sprintf("%s = ?", searchField.Field), searchValue)
-				case types.SearchTypeMultiSelect:
-					// 多选：处理数组值或JSON字符串，使用 IN 查询
-					var values []interface{}
-
-					// 首先尝试直接转换为数组
-					if directValues, ok := searchValue.([]interface{}); ok && len(directValues) > 0 {
-						values = directValues
-					} else if jsonStr, ok := searchValue.(string); ok && jsonStr != "" {
-						// 如果是字符串，尝试解析JSON
-						var parsedValues []string
-						if err := json.Unmarshal([]byte(jsonStr), &parsedValues); err == nil {
-							// 转换为[]interface{}
-							values = make([]interface{}, len(parsedValues))
-							for i, v := range parsedValues {
-								values[i] = v
-							}
-						}
-					}
-
-					if len(values) > 0 {
-						query = query.Where(fmt.Sprintf("%s IN ?", searchField.Field), values)
-					}
-				case types.SearchTypeDateRange:
-					// 日期范围：处理时间戳范围，先尝试直接转换，然后尝试JSON解析
-					var rangeMap map[string]interface{}
-
-					if directMap, ok := searchValue.(map[string]interface{}); ok {
-						rangeMap = directMap
-					} else if jsonStr, ok := searchValue.(string); ok && jsonStr != "" {
-						// 如果是字符串，尝试解析JSON
-						if err := json.Unmarshal([]byte(jsonStr), &rangeMap); err != nil {
-							// JSON解析失败，跳过这个搜索条件
-							continue
-						}
-					}
-
-					if rangeMap != nil {
-						if startTimestamp, exists := rangeMap["start"]; exists && startTimestamp != nil {
-							query = query.Where(fmt.Sprintf("%s >= ?", searchField.Field), startTimestamp)
-						}
-						if endTimestamp, exists := rangeMap["end"]; exists && endTimestamp != nil {
-							query = query.Where(fmt.Sprintf("%s <= ?", searchField.Field), endTimestamp)
-						}
-					}
-				}
-			}
-		}
+	// 应用搜索和过滤条件
+	query, rankOrders, err := s.whereListConditions(query, config, db, sqlDialect, projection, params.Search, params.Filters, params.Where)
+	if err != nil {
+		return nil, err
 	}
 
+	// 游标分页需要开启分页并指定每页条数
+	cursorMode := params.CursorMode && config.QueryPagination && params.PageSize > 0
+
 	// 应用排序
+	var orderColumns []cursorColumn
 	if params.Sort != nil && len(params.Sort) > 0 {
+		// 只能按配置的可排序字段排序，未配置时不允许排序
+		if err := generator.ValidateSort(sortableFields, params.Sort); err != nil {
+			return nil, err
+		}
 		for _, sortField := range params.Sort {
-			// 验证排序字段是否在允许的字段列表中
-			if len(sortableFields) > 0 {
-				allowed := false
-				for _, allowedField := range sortableFields {
-					if allowedField == sortField.Field {
-						allowed = true
-						break
-					}
-				}
-				if !allowed {
-					continue // 跳过不允许的排序字段
+			// 隐藏字段（包括其中的 JSON 路径）不能排序，否则顺序和游标会泄露其取值
+			sortColumn, _ := generator.ParseJSONPath(sortField.Field)
+			for _, hiddenField := range projection.QueryConfig.HiddenFields {
+				if hiddenField == sortColumn {
+					return nil, fmt.Errorf("field '%s' is not sortable", sortField.Field)
 				}
 			}
+			orderColumns = append(orderColumns, cursorColumn{Field: sortField.Field, Desc: sortField.Order == types.SortOrderDESC})
+		}
+	} else {
+		// 未指定排序时使用配置的默认排序
+		for _, sortField := range generator.ParseSort(config.QueryDefaultSort) {
+			orderColumns = append(orderColumns, cursorColumn{Field: sortField.Field, Desc: sortField.Order == types.SortOrderDESC})
+		}
+	}
+	if !cursorMode {
+		var orderParts []string
+		var orderArgs []interface{}
+		// 未指定排序时先按全文检索相关度排序，默认排序作为次要排序；游标分页无法按相关度定位，不使用
+		if len(params.Sort) == 0 {
+			for _, rankOrder := range rankOrders {
+				orderParts = append(orderParts, rankOrder.SQL)
+				orderArgs = append(orderArgs, rankOrder.Vars...)
+			}
+		}
+		for _, column := range orderColumns {
 			order := "ASC"
-			if sortField.Order == types.SortOrderDESC {
+			if column.Desc {
 				order = "DESC"
 			}
-			query = query.Order(fmt.Sprintf("%s %s", sortField.Field, order))
+			orderParts = append(orderParts, fmt.Sprintf("%s %s", generator.ColumnExpression(sqlDialect, column.Field), order))
+		}
+		// 带参数的排序表达式需要作为一个整体添加
+		if len(orderParts) > 0 {
+			query = query.Order(clause.OrderBy{Expression: clause.Expr{SQL: strings.Join(orderParts, ", "), Vars: orderArgs, WithoutParentheses: true}})
 		}
 	}
 
 	result := &types.QueryResult{}
 
+	// 返回主键列，供前端定位记录；无法确定主键时只影响游标分页
+	primaryKey, primaryKeyErr := s.primaryKey(config, db)
+	result.PrimaryKey = primaryKey
+
+	// 确定返回的列：展示字段加主键，fields 参数可进一步缩小范围，隐藏字段永不返回
+	tableColumns, err := projectionTableColumns(config, db, projection, params.Fields)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := generator.ListColumns(projection, primaryKey, tableColumns, params.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursorMode {
+		if primaryKeyErr != nil {
+			return nil, primaryKeyErr
+		}
+		if result, err = listByCursor(query, sqlDialect, orderColumns, primaryKey, columns, params, result); err != nil {
+			return nil, err
+		}
+		unwrapExpressionValues(result.Data)
+		if err := s.attachReferenceLabels(config, db, projection, result.Data); err != nil {
+			return nil, err
+		}
+		encoded.decode(sqlDialect, result.Data)
+		return result, nil
+	}
+
 	// 计算总数
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
@@ -246,11 +237,19 @@ func (s *CRUDService) List(configName string, params *types.QueryParams) (*types
 		result.TotalPages = 1
 	}
 
-	// 执行查询
+	// 执行查询，计数之后再限定列，避免影响 COUNT
+	if len(columns) > 0 {
+		query = query.Select(generator.SelectList(sqlDialect, columns))
+	}
 	var data []map[string]interface{}
 	if err := query.Find(&data).Error; err != nil {
 		return nil, fmt.Errorf("failed to query records: %w", err)
 	}
+	unwrapExpressionValues(data)
+	if err := s.attachReferenceLabels(config, db, projection, data); err != nil {
+		return nil, err
+	}
+	encoded.decode(sqlDialect, data)
 
 	result.Data = data
 	return result, nil
@@ -270,6 +269,11 @@ func (s *CRUDService) Create(configName string, data map[string]interface{}) (*t
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
 
+	sqlDialect, err := dialect.FromDB(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select SQL dialect: %w", err)
+	}
+
 	// 解析可创建字段配置
 	var creatableFields []types.CreatableField
 	fmt.Printf("Raw create_creatable_fields: %s\n", config.CreateCreatableFields)
@@ -308,7 +312,7 @@ func (s *CRUDService) Create(configName string, data map[string]interface{}) (*t
 						// 对于auto_increment字段，尝试生成下一个ID
 						// 查询当前最大ID值
 						var maxID int64
-						query := fmt.Sprintf("COALESCE(MAX(%s), 0)", field.Field)
+						query := fmt.Sprintf("COALESCE(MAX(%s), 0)", sqlDialect.QuoteIdentifier(field.Field))
 						result := db.Table(config.DBTableName).Select(query).Row()
 						if err := result.Scan(&maxID); err != nil {
 							fmt.Printf("Error querying max ID for field %s: %v\n", field.Field, err)
@@ -378,15 +382,33 @@ func (s *CRUDService) Create(configName string, data map[string]interface{}) (*t
 		}
 	}
 
+	// JSON 字段和数组字段编码后写入
+	schema, err := s.tableSchema(config, db)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := parseEncodedFields(config, schema)
+	if err != nil {
+		return nil, err
+	}
+	if validationErrors := encoded.encode(sqlDialect, data); len(validationErrors) > 0 {
+		return &types.CreateResult{
+			Success: false,
+			Errors:  validationErrors,
+		}, nil
+	}
+
 	// 执行插入
 	result := db.Table(config.DBTableName).Create(&data)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to create record: %w", result.Error)
 	}
 
-	// 获取插入的ID（如果有的话）
+	// 获取插入记录的主键（如果有的话）
 	var id interface{}
-	if idValue, exists := data["id"]; exists {
+	if primaryKey, err := s.primaryKey(config, db); err == nil {
+		id = generator.RecordKeyOf(primaryKey, data)
+	} else if idValue, exists := data["id"]; exists {
 		id = idValue
 	}
 
@@ -396,6 +418,256 @@ func (s *CRUDService) Create(configName string, data map[string]interface{}) (*t
 	}, nil
 }
 
+// whereListConditions 应用搜索、关联标签搜索和过滤条件，返回查询和全文检索相关度排序
+func (s *CRUDService) whereListConditions(query *gorm.DB, config *models.TableConfiguration, db *gorm.DB, sqlDialect dialect.Dialect, projection *types.Config, search map[string]interface{}, filters []types.Filter, where *types.FilterNode) (*gorm.DB, []clause.Expr, error) {
+	// 解析搜索字段配置
+	var searchFields []types.SearchField
+	if config.QuerySearchFields != "" {
+		if err := json.Unmarshal([]byte(config.QuerySearchFields), &searchFields); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse search fields: %w", err)
+		}
+	}
+
+	// 解析过滤字段配置
+	var filterFields []types.FilterField
+	if config.QueryFilterFields != "" {
+		if err := json.Unmarshal([]byte(config.QueryFilterFields), &filterFields); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse filter fields: %w", err)
+		}
+	}
+
+	// 应用搜索条件
+	var rankOrders []clause.Expr
+	if search != nil && len(searchFields) > 0 {
+		for _, searchField := range searchFields {
+			if searchValue, exists := search[searchField.Field]; exists && searchValue != nil {
+				// JSON 路径字段（如 attrs.color）按路径上的文本值搜索
+				column := generator.ColumnExpression(sqlDialect, searchField.Field)
+				switch searchField.Type {
+				case types.SearchTypeFuzzy:
+					query = query.Where(fmt.Sprintf("%s %s ?", column, sqlDialect.CaseInsensitiveLike()), fmt.Sprintf("%%%v%%", searchValue))
+				case types.SearchTypeExact:
+					// JSON 路径上的数组包含该值时也匹配
+					if contains, ok := generator.JSONContainsCondition(sqlDialect, searchField.Field, "?"); ok {
+						document, err := json.Marshal(searchValue)
+						if err != nil {
+							return nil, nil, fmt.Errorf("failed to encode search value: %w", err)
+						}
+						query = query.Where(fmt.Sprintf("(%s = ? OR %s)", column, contains), searchValue, string(document))
+						continue
+					}
+					query = query.Where(fmt.Sprintf("%s = ?", column), searchValue)
+				case types.SearchTypeFulltext:
+					// 全文检索：同时搜索多个列，开启 rank 时按相关度排序
+					text := strings.TrimSpace(fmt.Sprint(searchValue))
+					if text == "" {
+						continue
+					}
+					columns := generator.FullTextColumns(searchField)
+					query = query.Where(sqlDialect.FullTextMatch(columns, searchField.Language, "?"), text)
+					if searchField.Rank {
+						if rank := sqlDialect.FullTextRank(columns, searchField.Language, "?"); rank != "" {
+							rankOrders = append(rankOrders, clause.Expr{SQL: rank + " DESC", Vars: []interface{}{text}})
+						}
+					}
+				case types.SearchTypeRange:
+					// 处理范围搜索：先尝试直接转换，然后尝试JSON解析
+					var rangeMap map[string]interface{}
+
+					if directMap, ok := searchValue.(map[string]interface{}); ok {
+						rangeMap = directMap
+					} else if jsonStr, ok := searchValue.(string); ok && jsonStr != "" {
+						// 如果是字符串，尝试解析JSON
+						if err := json.Unmarshal([]byte(jsonStr), &rangeMap); err != nil {
+							// JSON解析失败，跳过这个搜索条件
+							continue
+						}
+					}
+
+					if rangeMap != nil {
+						if min, exists := rangeMap["min"]; exists && min != nil {
+							query = query.Where(fmt.Sprintf("%s >= ?", column), min)
+						}
+						if max, exists := rangeMap["max"]; exists && max != nil {
+							query = query.Where(fmt.Sprintf("%s <= ?", column), max)
+						}
+					}
+				case types.SearchTypeContains, types.SearchTypeOverlaps:
+					// 数组列：包含全部值或任一值
+					condition, literal, ok, err := generator.ArrayCondition(sqlDialect, searchField, searchValue, "?")
+					if err != nil {
+						return nil, nil, err
+					}
+					if ok {
+						query = query.Where(condition, literal)
+					}
+				case types.SearchTypeSingle, types.SearchTypeMulti:
+					query = query.Where(fmt.Sprintf("%s = ?", column), searchValue)
+				case types.SearchTypeMultiSelect:
+					// 多选：处理数组值或JSON字符串，使用 IN 查询
+					var values []interface{}
+
+					// 首先尝试直接转换为数组
+					if directValues, ok := searchValue.([]interface{}); ok && len(directValues) > 0 {
+						values = directValues
+					} else if jsonStr, ok := searchValue.(string); ok && jsonStr != "" {
+						// 如果是字符串，尝试解析JSON
+						var parsedValues []string
+						if err := json.Unmarshal([]byte(jsonStr), &parsedValues); err == nil {
+							// 转换为[]interface{}
+							values = make([]interface{}, len(parsedValues))
+							for i, v := range parsedValues {
+								values[i] = v
+							}
+						}
+					}
+
+					if len(values) > 0 {
+						query = query.Where(fmt.Sprintf("%s IN ?", column), values)
+					}
+				case types.SearchTypeDateRange:
+					// 日期范围：处理时间戳范围，先尝试直接转换，然后尝试JSON解析
+					var rangeMap map[string]interface{}
+
+					if directMap, ok := searchValue.(map[string]interface{}); ok {
+						rangeMap = directMap
+					} else if jsonStr, ok := searchValue.(string); ok && jsonStr != "" {
+						// 如果是字符串，尝试解析JSON
+						if err := json.Unmarshal([]byte(jsonStr), &rangeMap); err != nil {
+							// JSON解析失败，跳过这个搜索条件
+							continue
+						}
+					}
+
+					if rangeMap != nil {
+						if startTimestamp, exists := rangeMap["start"]; exists && startTimestamp != nil {
+							query = query.Where(fmt.Sprintf("%s >= ?", column), startTimestamp)
+						}
+						if endTimestamp, exists := rangeMap["end"]; exists && endTimestamp != nil {
+							query = query.Where(fmt.Sprintf("%s <= ?", column), endTimestamp)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// 按标签搜索关联字段，参数名为 <字段>_label
+	for _, field := range generator.ReferenceFields(projection) {
+		value, exists := search[generator.ReferenceLabelKey(field.Field)]
+		if !exists || value == nil {
+			continue
+		}
+		text := strings.TrimSpace(fmt.Sprint(value))
+		if text == "" {
+			continue
+		}
+		for _, hiddenField := range projection.QueryConfig.HiddenFields {
+			if hiddenField == field.Field {
+				return nil, nil, fmt.Errorf("field '%s' cannot be searched", field.Field)
+			}
+		}
+		labelQuery, err := s.whereReferenceLabel(query, config, db, sqlDialect, field, text)
+		if err != nil {
+			return nil, nil, err
+		}
+		query = labelQuery
+	}
+
+	// 应用过滤条件，操作符必须在字段允许的范围内
+	for _, filter := range filters {
+		if err := generator.CheckFilter(filterFields, filter); err != nil {
+			return nil, nil, err
+		}
+		condition, args, err := generator.FilterCondition(sqlDialect, filter, func() string { return "?" })
+		if err != nil {
+			return nil, nil, err
+		}
+		query = query.Where(condition, args...)
+	}
+
+	// 应用组合过滤条件（and/or/not），与其他条件取 AND
+	if where != nil {
+		condition, args, err := generator.FilterTreeCondition(sqlDialect, filterFields, where, func() string { return "?" })
+		if err != nil {
+			return nil, nil, err
+		}
+		query = query.Where("("+condition+")", args...)
+	}
+
+	return query, rankOrders, nil
+}
+
+// Get 按主键读取单条记录；配置了展示字段时只返回展示字段、主键和可更新字段
+func (s *CRUDService) Get(configName string, id interface{}) (map[string]interface{}, error) {
+	config, err := s.GetConfigByName(configName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取对应的数据库连接
+	db, err := s.getBusinessDB(config.ConnectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	sqlDialect, err := dialect.FromDB(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select SQL dialect: %w", err)
+	}
+
+	primaryKey, err := s.primaryKey(config, db)
+	if err != nil {
+		return nil, err
+	}
+
+	// 根据展示字段、可更新字段和隐藏字段确定返回的列
+	projection, err := projectionConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	tableColumns, err := projectionTableColumns(config, db, projection, nil)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := generator.RecordColumns(projection, primaryKey, tableColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := whereRecordKey(db.Table(config.DBTableName), sqlDialect, primaryKey, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) > 0 {
+		query = query.Select(generator.SelectList(sqlDialect, columns))
+	}
+
+	var records []map[string]interface{}
+	if err := query.Limit(1).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to query record: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w in table '%s'", generator.ErrRecordNotFound, config.DBTableName)
+	}
+	unwrapExpressionValues(records)
+	if err := s.attachReferenceLabels(config, db, projection, records); err != nil {
+		return nil, err
+	}
+	// JSON 字段返回对象、数组字段返回列表，供表单编辑
+	schema, err := s.tableSchema(config, db)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := parseEncodedFields(config, schema)
+	if err != nil {
+		return nil, err
+	}
+	encoded.decode(sqlDialect, records)
+
+	return records[0], nil
+}
+
 func (s *CRUDService) Update(configName string, id interface{}, data map[string]interface{}) (*types.UpdateResult, error) {
 	config, err := s.GetConfigByName(configName)
 	if err != nil {
@@ -409,25 +681,9 @@ func (s *CRUDService) Update(configName string, id interface{}, data map[string]
 	}
 
 	// 解析可更新字段
-	var updatableFields []types.UpdatableField
-	if config.UpdateUpdatableFields != "" {
-		// 尝试解析新格式（对象数组）
-		if err := json.Unmarshal([]byte(config.UpdateUpdatableFields), &updatableFields); err != nil {
-			// 如果失败，尝试解析旧格式（字符串数组）
-			var legacyFields []string
-			if err2 := json.Unmarshal([]byte(config.UpdateUpdatableFields), &legacyFields); err2 != nil {
-				return nil, fmt.Errorf("failed to parse updatable fields: %w", err)
-			}
-			// 转换为新格式
-			for _, field := range legacyFields {
-				updatableFields = append(updatableFields, types.UpdatableField{
-					Field:    field,
-					Label:    "",
-					Type:     "text",
-					Required: false,
-				})
-			}
-		}
+	updatableFields, err := parseUpdatableFields(config.UpdateUpdatableFields)
+	if err != nil {
+		return nil, err
 	}
 
 	// 过滤数据，只保留可更新的字段
@@ -478,8 +734,43 @@ func (s *CRUDService) Update(configName string, id interface{}, data map[string]
 		}
 	}
 
+	sqlDialect, err := dialect.FromDB(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select SQL dialect: %w", err)
+	}
+
+	// JSON 字段和数组字段编码后写入
+	schema, err := s.tableSchema(config, db)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := parseEncodedFields(config, schema)
+	if err != nil {
+		return nil, err
+	}
+	if validationErrors := encoded.encode(sqlDialect, data); len(validationErrors) > 0 {
+		return &types.UpdateResult{
+			Success: false,
+			Errors:  validationErrors,
+		}, nil
+	}
+
+	primaryKey, err := s.primaryKey(config, db)
+	if err != nil {
+		return nil, err
+	}
+
+	// 主键列不允许更新
+	for _, column := range primaryKey {
+		delete(data, column)
+	}
+
 	// 执行更新
-	result := db.Table(config.DBTableName).Where("id = ?", id).Updates(data)
+	query, err := whereRecordKey(db.Table(config.DBTableName), sqlDialect, primaryKey, id)
+	if err != nil {
+		return nil, err
+	}
+	result := query.Updates(data)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to update record: %w", result.Error)
 	}
@@ -502,8 +793,22 @@ func (s *CRUDService) Delete(configName string, id interface{}) (*types.DeleteRe
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
 
+	sqlDialect, err := dialect.FromDB(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select SQL dialect: %w", err)
+	}
+
+	primaryKey, err := s.primaryKey(config, db)
+	if err != nil {
+		return nil, err
+	}
+
 	// 执行删除
-	result := db.Table(config.DBTableName).Where("id = ?", id).Delete(&map[string]interface{}{})
+	query, err := whereRecordKey(db.Table(config.DBTableName), sqlDialect, primaryKey, id)
+	if err != nil {
+		return nil, err
+	}
+	result := query.Delete(&map[string]interface{}{})
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to delete record: %w", result.Error)
 	}
@@ -514,12 +819,45 @@ func (s *CRUDService) Delete(configName string, id interface{}) (*types.DeleteRe
 	}, nil
 }
 
+// parseUpdatableFields 解析可更新字段配置，兼容旧格式（字符串数组）
+func parseUpdatableFields(raw string) ([]types.UpdatableField, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	// 尝试解析新格式（对象数组）
+	var updatableFields []types.UpdatableField
+	if err := json.Unmarshal([]byte(raw), &updatableFields); err != nil {
+		// 如果失败，尝试解析旧格式（字符串数组）
+		var legacyFields []string
+		if err2 := json.Unmarshal([]byte(raw), &legacyFields); err2 != nil {
+			return nil, fmt.Errorf("failed to parse updatable fields: %w", err)
+		}
+		// 转换为新格式
+		updatableFields = nil
+		for _, field := range legacyFields {
+			updatableFields = append(updatableFields, types.UpdatableField{
+				Field:    field,
+				Label:    "",
+				Type:     "text",
+				Required: false,
+			})
+		}
+	}
+
+	return updatableFields, nil
+}
+
 func (s *CRUDService) GetDict(configName string, field string) ([]types.DictItem, error) {
 	config, err := s.GetConfigByName(configName)
 	if err != nil {
 		return nil, err
 	}
+	return s.dictItems(config, field)
+}
 
+// dictItems 读取搜索字段的字典数据
+func (s *CRUDService) dictItems(config *models.TableConfiguration, field string) ([]types.DictItem, error) {
 	// 解析搜索字段配置找到字典源
 	var searchFields []types.SearchField
 	if config.QuerySearchFields != "" {
@@ -534,9 +872,15 @@ func (s *CRUDService) GetDict(configName string, field string) ([]types.DictItem
 
 	for _, searchField := range searchFields {
 		if searchField.Field == field && searchField.DictSource != "" {
-			// 尝试解析字典源配置
+			// JSON 数组是固定的字典值，只有一个值时也不会被当作字段名
+			var values []string
 			var parsedDictSource types.DictSource
-			if err := json.Unmarshal([]byte(searchField.DictSource), &parsedDictSource); err == nil {
+			if err := json.Unmarshal([]byte(searchField.DictSource), &values); err == nil {
+				isCustomDict = true
+				for _, value := range values {
+					customItems = append(customItems, types.DictItem{Value: value, Label: value})
+				}
+			} else if err := json.Unmarshal([]byte(searchField.DictSource), &parsedDictSource); err == nil {
 				// 如果是有效的JSON配置，使用解析后的配置
 				dictSource = &parsedDictSource
 			} else {
@@ -582,15 +926,21 @@ func (s *CRUDService) GetDict(configName string, field string) ([]types.DictItem
 		return nil, fmt.Errorf("failed to get database connection: %w", err)
 	}
 
+	sqlDialect, err := dialect.FromDB(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select SQL dialect: %w", err)
+	}
+
 	// 构建字典查询
-	query := db.Table(dictSource.Table).Select(fmt.Sprintf("DISTINCT %s as value, %s as label", dictSource.Field, dictSource.Field)).Where(fmt.Sprintf("%s IS NOT NULL", dictSource.Field))
+	column := sqlDialect.QuoteIdentifier(dictSource.Field)
+	query := db.Table(dictSource.Table).Select(fmt.Sprintf("DISTINCT %s as value, %s as label", column, column)).Where(fmt.Sprintf("%s IS NOT NULL", column))
 
 	if dictSource.Where != "" {
 		query = query.Where(dictSource.Where)
 	}
 
 	if dictSource.SortOrder != "" {
-		query = query.Order(fmt.Sprintf("%s %s", dictSource.Field, string(dictSource.SortOrder)))
+		query = query.Order(fmt.Sprintf("%s %s", column, string(dictSource.SortOrder)))
 	}
 
 	var items []types.DictItem
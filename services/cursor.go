@@ -0,0 +1,233 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/dialect"
+	"github.com/otkinlife/crud-generator/generator"
+	"github.com/otkinlife/crud-generator/types"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidCursor 游标无法解析或与当前排序不匹配
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorColumn 游标分页的排序列
+type cursorColumn struct {
+	Field string
+	Desc  bool
+}
+
+// pageCursor 游标内容：排序列和主键列的取值，Backward 表示向前翻页
+type pageCursor struct {
+	Values   []interface{} `json:"v"`
+	Backward bool          `json:"b,omitempty"`
+}
+
+// listByCursor 按排序字段加主键做 keyset 分页，不使用 OFFSET，可跳过总数统计；columns 为空时返回全部列
+func listByCursor(query *gorm.DB, sqlDialect dialect.Dialect, orderColumns []cursorColumn, primaryKey []string, columns []string, params *types.QueryParams, result *types.QueryResult) (*types.QueryResult, error) {
+	// 主键作为最后的排序列，保证顺序唯一
+	for _, column := range primaryKey {
+		exists := false
+		for _, orderColumn := range orderColumns {
+			if orderColumn.Field == column {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			orderColumns = append(orderColumns, cursorColumn{Field: column})
+		}
+	}
+
+	result.PageSize = params.PageSize
+	if !params.SkipCount {
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return nil, fmt.Errorf("failed to count records: %w", err)
+		}
+		result.Total = total
+		result.TotalPages = int((total + int64(params.PageSize) - 1) / int64(params.PageSize))
+	}
+
+	var cursor *pageCursor
+	if params.Cursor != "" {
+		var err error
+		if cursor, err = decodeCursor(params.Cursor, len(orderColumns)); err != nil {
+			return nil, err
+		}
+		condition, args := keysetCondition(sqlDialect, orderColumns, cursor.Values, cursor.Backward)
+		query = query.Where(condition, args...)
+	}
+
+	// 向前翻页时反转排序，读取后再恢复顺序
+	backward := cursor != nil && cursor.Backward
+	for _, column := range orderColumns {
+		order := "ASC"
+		if column.Desc != backward {
+			order = "DESC"
+		}
+		query = query.Order(fmt.Sprintf("%s %s", generator.ColumnExpression(sqlDialect, column.Field), order))
+	}
+
+	// 生成游标需要排序列的值，未返回的排序列在生成游标后移除
+	var extraColumns []string
+	if len(columns) > 0 {
+		selected := append([]string{}, columns...)
+		for _, orderColumn := range orderColumns {
+			exists := false
+			for _, column := range columns {
+				if column == orderColumn.Field {
+					exists = true
+					break
+				}
+			}
+			if !exists {
+				selected = append(selected, orderColumn.Field)
+				extraColumns = append(extraColumns, orderColumn.Field)
+			}
+		}
+		query = query.Select(generator.SelectList(sqlDialect, selected))
+	}
+
+	// 多读一条判断是否还有数据
+	var data []map[string]interface{}
+	if err := query.Limit(params.PageSize + 1).Find(&data).Error; err != nil {
+		return nil, fmt.Errorf("failed to query records: %w", err)
+	}
+	hasMore := len(data) > params.PageSize
+	if hasMore {
+		data = data[:params.PageSize]
+	}
+	if backward {
+		for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+			data[i], data[j] = data[j], data[i]
+		}
+	}
+
+	if len(data) > 0 {
+		if hasMore || backward {
+			result.NextCursor = encodeCursor(cursorValues(data[len(data)-1], orderColumns), false)
+		}
+		if (backward && hasMore) || (!backward && cursor != nil) {
+			result.PrevCursor = encodeCursor(cursorValues(data[0], orderColumns), true)
+		}
+	}
+
+	for _, record := range data {
+		for _, column := range extraColumns {
+			delete(record, column)
+		}
+	}
+
+	result.Data = data
+	return result, nil
+}
+
+// keysetCondition 生成 (a > ?) OR (a = ? AND b > ?) 形式的条件，降序列和向前翻页时使用 <；
+// 排序列可以为 NULL，NULL 按数据库的默认顺序排在最前或最后，比较 NULL 时改用 IS NULL / IS NOT NULL
+func keysetCondition(sqlDialect dialect.Dialect, columns []cursorColumn, values []interface{}, backward bool) (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+	for i, column := range columns {
+		var parts []string
+		var partArgs []interface{}
+		for j := 0; j < i; j++ {
+			expression := generator.ColumnExpression(sqlDialect, columns[j].Field)
+			if values[j] == nil {
+				parts = append(parts, expression+" IS NULL")
+				continue
+			}
+			parts = append(parts, expression+" = ?")
+			partArgs = append(partArgs, values[j])
+		}
+
+		// 按本次读取的方向，NULL 是否排在非 NULL 值之后
+		descending := column.Desc != backward
+		nullsAfter := sqlDialect.NullsFirst() == descending
+
+		expression := generator.ColumnExpression(sqlDialect, column.Field)
+		switch {
+		case values[i] == nil && nullsAfter:
+			// 游标停在排在最后的 NULL 上，该列上没有更靠后的值
+			continue
+		case values[i] == nil:
+			parts = append(parts, expression+" IS NOT NULL")
+		default:
+			operator := ">"
+			if descending {
+				operator = "<"
+			}
+			comparison := fmt.Sprintf("%s %s ?", expression, operator)
+			if nullsAfter {
+				comparison = fmt.Sprintf("(%s OR %s IS NULL)", comparison, expression)
+			}
+			parts = append(parts, comparison)
+			partArgs = append(partArgs, values[i])
+		}
+
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+		args = append(args, partArgs...)
+	}
+	if len(clauses) == 0 {
+		return "1 = 0", nil
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+func cursorValues(record map[string]interface{}, columns []cursorColumn) []interface{} {
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		value := record[column.Field]
+		if raw, ok := value.([]byte); ok {
+			value = string(raw)
+		}
+		values[i] = value
+	}
+	return values
+}
+
+func encodeCursor(values []interface{}, backward bool) string {
+	data, err := json.Marshal(pageCursor{Values: values, Backward: backward})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string, columns int) (*pageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	// 使用 json.Number 避免大整数丢失精度
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var cursor pageCursor
+	if err := decoder.Decode(&cursor); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if len(cursor.Values) != columns {
+		return nil, fmt.Errorf("%w: the cursor does not match the sort order", ErrInvalidCursor)
+	}
+
+	for i, value := range cursor.Values {
+		number, ok := value.(json.Number)
+		if !ok {
+			continue
+		}
+		if integer, err := number.Int64(); err == nil {
+			cursor.Values[i] = integer
+		} else if float, err := number.Float64(); err == nil {
+			cursor.Values[i] = float
+		}
+	}
+
+	return &cursor, nil
+}
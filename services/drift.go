@@ -0,0 +1,226 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/generator"
+	"github.com/otkinlife/crud-generator/introspector"
+	"github.com/otkinlife/crud-generator/models"
+	"github.com/otkinlife/crud-generator/parser"
+	"github.com/otkinlife/crud-generator/types"
+)
+
+// DetectDrift 对比配置与数据库中的实时表结构，生成漂移报告
+func (s *ConfigService) DetectDrift(configID uint) (*types.DriftReport, error) {
+	config, err := s.GetConfigByID(configID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configuration: %w", err)
+	}
+
+	db, err := s.getConnection(config.ConnectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	tableIntrospector, err := introspector.NewIntrospector(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select schema introspector: %w", err)
+	}
+
+	liveSchema, err := tableIntrospector.IntrospectTable(config.DBTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to introspect table '%s': %w", config.DBTableName, err)
+	}
+
+	referencedColumns, err := configReferencedColumns(config)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &types.DriftReport{
+		ConfigID:         config.ID,
+		ConfigName:       config.Name,
+		TableName:        config.DBTableName,
+		MissingColumns:   []types.MissingColumn{},
+		TypeMismatches:   []types.TypeMismatch{},
+		UnexposedColumns: []string{},
+	}
+
+	liveFields := make(map[string]types.TableField)
+	for _, field := range liveSchema.Fields {
+		liveFields[field.Name] = field
+	}
+
+	// 配置中引用但数据库中已不存在的字段
+	exposed := make(map[string]bool)
+	for _, column := range referencedColumns {
+		// JSON 路径字段（如 attrs.color）引用的是 JSON 列
+		name, _ := generator.ParseJSONPath(column.Field)
+		exposed[name] = true
+		if _, exists := liveFields[name]; !exists {
+			report.MissingColumns = append(report.MissingColumns, column)
+		}
+	}
+
+	// 建表语句与实时表结构的类型差异
+	if strings.TrimSpace(config.CreateStatement) != "" {
+		schemaParser, err := parser.NewParser(db.Dialector.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to select schema parser: %w", err)
+		}
+
+		declaredSchema, err := schemaParser.ParseScript(config.CreateStatement, config.DBTableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse create statement: %w", err)
+		}
+
+		for _, declared := range declaredSchema.Fields {
+			live, exists := liveFields[declared.Name]
+			if !exists {
+				report.MissingColumns = append(report.MissingColumns, types.MissingColumn{
+					Field:  declared.Name,
+					Source: "create_statement",
+				})
+				continue
+			}
+
+			if !fieldTypesMatch(declared, live) {
+				report.TypeMismatches = append(report.TypeMismatches, types.TypeMismatch{
+					Field:        declared.Name,
+					DeclaredType: fieldTypeLabel(declared),
+					LiveType:     fieldTypeLabel(live),
+				})
+			}
+		}
+	}
+
+	// 数据库中存在但未在任何配置中暴露的字段
+	for _, field := range liveSchema.Fields {
+		if !exposed[field.Name] {
+			report.UnexposedColumns = append(report.UnexposedColumns, field.Name)
+		}
+	}
+
+	report.HasDrift = len(report.MissingColumns) > 0 || len(report.TypeMismatches) > 0
+
+	return report, nil
+}
+
+// configReferencedColumns 收集展示、搜索、排序、创建和更新配置中引用的字段
+func configReferencedColumns(config *models.TableConfiguration) ([]types.MissingColumn, error) {
+	var columns []types.MissingColumn
+
+	if config.QueryDisplayFields != "" {
+		var displayFields []types.DisplayField
+		if err := json.Unmarshal([]byte(config.QueryDisplayFields), &displayFields); err != nil {
+			return nil, fmt.Errorf("failed to parse display fields: %w", err)
+		}
+		for _, field := range displayFields {
+			columns = append(columns, types.MissingColumn{Field: field.Field, Source: "display"})
+		}
+	}
+
+	if config.QuerySearchFields != "" {
+		var searchFields []types.SearchField
+		if err := json.Unmarshal([]byte(config.QuerySearchFields), &searchFields); err != nil {
+			return nil, fmt.Errorf("failed to parse search fields: %w", err)
+		}
+		for _, field := range searchFields {
+			// 全文检索字段的 Field 是参数名，引用的是 Columns 中的列
+			if field.Type == types.SearchTypeFulltext {
+				for _, column := range generator.FullTextColumns(field) {
+					columns = append(columns, types.MissingColumn{Field: column, Source: "search"})
+				}
+				continue
+			}
+			columns = append(columns, types.MissingColumn{Field: field.Field, Source: "search"})
+		}
+	}
+
+	if config.QuerySortableFields != "" {
+		var sortableFields []string
+		if err := json.Unmarshal([]byte(config.QuerySortableFields), &sortableFields); err != nil {
+			return nil, fmt.Errorf("failed to parse sortable fields: %w", err)
+		}
+		for _, field := range sortableFields {
+			columns = append(columns, types.MissingColumn{Field: field, Source: "sort"})
+		}
+	}
+
+	if config.QueryFilterFields != "" {
+		var filterFields []types.FilterField
+		if err := json.Unmarshal([]byte(config.QueryFilterFields), &filterFields); err != nil {
+			return nil, fmt.Errorf("failed to parse filter fields: %w", err)
+		}
+		for _, field := range filterFields {
+			columns = append(columns, types.MissingColumn{Field: field.Field, Source: "filter"})
+		}
+	}
+
+	if config.CreateCreatableFields != "" {
+		var creatableFields []types.CreatableField
+		if err := json.Unmarshal([]byte(config.CreateCreatableFields), &creatableFields); err != nil {
+			return nil, fmt.Errorf("failed to parse creatable fields: %w", err)
+		}
+		for _, field := range creatableFields {
+			columns = append(columns, types.MissingColumn{Field: field.Field, Source: "create"})
+		}
+	}
+
+	if config.UpdateUpdatableFields != "" {
+		var updatableFields []types.UpdatableField
+		if err := json.Unmarshal([]byte(config.UpdateUpdatableFields), &updatableFields); err != nil {
+			// 兼容旧格式（字符串数组）
+			var legacyFields []string
+			if err2 := json.Unmarshal([]byte(config.UpdateUpdatableFields), &legacyFields); err2 != nil {
+				return nil, fmt.Errorf("failed to parse updatable fields: %w", err)
+			}
+			updatableFields = nil
+			for _, field := range legacyFields {
+				updatableFields = append(updatableFields, types.UpdatableField{Field: field})
+			}
+		}
+		for _, field := range updatableFields {
+			columns = append(columns, types.MissingColumn{Field: field.Field, Source: "update"})
+		}
+	}
+
+	return columns, nil
+}
+
+// fieldTypesMatch 比较类型，长度和精度只在两边都声明时比较；自定义类型（枚举、domain）无法从建表语句判断，不比较
+func fieldTypesMatch(declared, live types.TableField) bool {
+	if declared.Type == types.PostgreSQLTypeUserDefined || live.Type == types.PostgreSQLTypeUserDefined {
+		return true
+	}
+	if declared.Type != live.Type {
+		return false
+	}
+	if declared.ElementType != "" && live.ElementType != "" && declared.ElementType != live.ElementType {
+		return false
+	}
+	if declared.Length > 0 && live.Length > 0 && declared.Length != live.Length {
+		return false
+	}
+	if declared.Precision > 0 && live.Precision > 0 &&
+		(declared.Precision != live.Precision || declared.Scale != live.Scale) {
+		return false
+	}
+	return true
+}
+
+func fieldTypeLabel(field types.TableField) string {
+	switch {
+	case field.ElementType != "":
+		return fmt.Sprintf("%s[]", field.ElementType)
+	case field.Type == types.PostgreSQLTypeUserDefined && field.RawType != "":
+		return field.RawType
+	case field.Length > 0:
+		return fmt.Sprintf("%s(%d)", field.Type, field.Length)
+	case field.Precision > 0:
+		return fmt.Sprintf("%s(%d,%d)", field.Type, field.Precision, field.Scale)
+	}
+	return string(field.Type)
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/otkinlife/crud-generator/dialect"
+	"github.com/otkinlife/crud-generator/generator"
+	"github.com/otkinlife/crud-generator/types"
+	"gorm.io/gorm/clause"
+)
+
+// Facets 统计字典搜索字段（single / multi_select）每个字典值的记录数。
+// 每个字段的统计应用除该字段自身外的全部搜索和过滤条件，选中一个值后同一字段的其他值仍显示数量。
+func (s *CRUDService) Facets(configName string, params *types.FacetParams) (*types.FacetResult, error) {
+	config, err := s.GetConfigByName(configName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取对应的数据库连接
+	db, err := s.getBusinessDB(config.ConnectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	sqlDialect, err := dialect.FromDB(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select SQL dialect: %w", err)
+	}
+
+	projection, err := projectionConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var searchFields []types.SearchField
+	if config.QuerySearchFields != "" {
+		if err := json.Unmarshal([]byte(config.QuerySearchFields), &searchFields); err != nil {
+			return nil, fmt.Errorf("failed to parse search fields: %w", err)
+		}
+	}
+
+	result := &types.FacetResult{Facets: []types.Facet{}}
+	for _, searchField := range searchFields {
+		if !facetField(searchField, params.Fields, projection.QueryConfig.HiddenFields) {
+			continue
+		}
+
+		items, err := s.dictItems(config, searchField.Field)
+		if err != nil {
+			return nil, err
+		}
+
+		// 去掉该字段自身的搜索条件
+		search := make(map[string]interface{}, len(params.Search))
+		for key, value := range params.Search {
+			if key != searchField.Field {
+				search[key] = value
+			}
+		}
+		query, _, err := s.whereListConditions(db.Table(config.DBTableName), config, db, sqlDialect, projection, search, params.Filters, params.Where)
+		if err != nil {
+			return nil, err
+		}
+
+		column := generator.ColumnExpression(sqlDialect, searchField.Field)
+		var rows []map[string]interface{}
+		if err := query.Select(fmt.Sprintf("%s AS %s, COUNT(*) AS %s", column, sqlDialect.QuoteIdentifier("value"), sqlDialect.QuoteIdentifier("count"))).
+			Clauses(clause.GroupBy{Columns: []clause.Column{{Name: column, Raw: true}}}).
+			Find(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to count facet '%s': %w", searchField.Field, err)
+		}
+		unwrapExpressionValues(rows)
+
+		// 字典值统一按文本匹配，MySQL 的分组值为 []byte
+		counts := make(map[string]int64)
+		for _, row := range rows {
+			if row["value"] == nil {
+				continue
+			}
+			value := row["value"]
+			if text, ok := textValue(value); ok {
+				value = text
+			}
+			counts[fmt.Sprint(value)] += countValue(row["count"])
+		}
+
+		facet := types.Facet{Field: searchField.Field, Values: make([]types.FacetValue, 0, len(items))}
+		for _, item := range items {
+			facet.Values = append(facet.Values, types.FacetValue{Value: item.Value, Label: item.Label, Count: counts[item.Value]})
+		}
+		result.Facets = append(result.Facets, facet)
+	}
+
+	return result, nil
+}
+
+// facetField 只统计带字典的单选和多选搜索字段，隐藏字段的分布也不返回
+func facetField(searchField types.SearchField, requested, hiddenFields []string) bool {
+	if searchField.DictSource == "" ||
+		(searchField.Type != types.SearchTypeSingle && searchField.Type != types.SearchTypeMultiSelect) {
+		return false
+	}
+	if len(requested) > 0 {
+		found := false
+		for _, field := range requested {
+			if field == searchField.Field {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	column, _ := generator.ParseJSONPath(searchField.Field)
+	for _, hiddenField := range hiddenFields {
+		if hiddenField == column {
+			return false
+		}
+	}
+	return true
+}
+
+// countValue 读取 COUNT(*) 的结果，驱动可能返回整数、浮点数或文本
+func countValue(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case int32:
+		return int64(v)
+	case int:
+		return int64(v)
+	case float64:
+		return int64(v)
+	}
+	if text, ok := textValue(value); ok {
+		count, _ := strconv.ParseInt(text, 10, 64)
+		return count
+	}
+	return 0
+}
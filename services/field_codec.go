@@ -0,0 +1,176 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/otkinlife/crud-generator/dialect"
+	"github.com/otkinlife/crud-generator/generator"
+	"github.com/otkinlife/crud-generator/models"
+	"github.com/otkinlife/crud-generator/types"
+)
+
+const (
+	// jsonInputType 创建、更新字段使用结构化 JSON 编辑器的输入类型
+	jsonInputType = "json"
+	// tagsInputType 数组列使用标签输入的输入类型
+	tagsInputType = "tags"
+)
+
+// encodedFields 写入前需要编码、读取后需要解码的字段：JSON 字段和数组字段
+type encodedFields struct {
+	// 写入时编码和校验的字段：表结构中的 JSON 列和输入类型为 json 的字段
+	json  []string
+	array []string
+	// 读取时解码的 JSON 列和数组列（列名到元素类型），只按表结构中的列类型确定，只读列同样解码
+	jsonColumns  []string
+	arrayColumns map[string]types.PostgreSQLType
+}
+
+// parseEncodedFields 按表结构的列类型确定读取时解码的 JSON 列和数组列，写入时另外按可创建和可更新字段的输入类型编码
+func parseEncodedFields(config *models.TableConfiguration, schema *types.TableSchema) (*encodedFields, error) {
+	fields := &encodedFields{arrayColumns: make(map[string]types.PostgreSQLType)}
+	seen := make(map[string]bool)
+	add := func(field, inputType string) {
+		if seen[field] {
+			return
+		}
+		switch inputType {
+		case jsonInputType:
+			fields.json = append(fields.json, field)
+		case tagsInputType:
+			fields.array = append(fields.array, field)
+		default:
+			return
+		}
+		seen[field] = true
+	}
+
+	if config.CreateCreatableFields != "" {
+		var creatableFields []types.CreatableField
+		if err := json.Unmarshal([]byte(config.CreateCreatableFields), &creatableFields); err != nil {
+			return nil, fmt.Errorf("failed to parse creatable fields: %w", err)
+		}
+		for _, field := range creatableFields {
+			add(field.Field, field.Type)
+		}
+	}
+
+	updatableFields, err := parseUpdatableFields(config.UpdateUpdatableFields)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range updatableFields {
+		add(field.Field, field.Type)
+	}
+
+	// 表结构中的 JSON 列和数组列；输入类型已确定编码方式的列（如以 JSON 列存储的标签）不再按 JSON 编码
+	for _, field := range schema.Fields {
+		switch field.Type {
+		case types.PostgreSQLTypeJSON, types.PostgreSQLTypeJSONB:
+			fields.jsonColumns = append(fields.jsonColumns, field.Name)
+			add(field.Name, jsonInputType)
+		case types.PostgreSQLTypeArray:
+			fields.arrayColumns[field.Name] = field.ElementType
+			add(field.Name, tagsInputType)
+		}
+	}
+
+	return fields, nil
+}
+
+// encode 把 JSON 字段的对象和数组编码为 JSON 文本（文本必须是合法的 JSON），把数组字段编码为数据库的数组格式；空文本写入 NULL
+func (f *encodedFields) encode(d dialect.Dialect, data map[string]interface{}) []types.ValidationError {
+	var validationErrors []types.ValidationError
+	invalid := func(field, tag string, value interface{}, message string) {
+		validationErrors = append(validationErrors, types.ValidationError{
+			Field:   field,
+			Tag:     tag,
+			Value:   value,
+			Message: message,
+		})
+	}
+
+	for _, field := range f.json {
+		value, exists := data[field]
+		if !exists || value == nil {
+			continue
+		}
+
+		switch v := value.(type) {
+		case string:
+			if v == "" {
+				data[field] = nil
+				continue
+			}
+			if !json.Valid([]byte(v)) {
+				invalid(field, "json", value, fmt.Sprintf("%s must be valid JSON", field))
+			}
+		default:
+			document, err := json.Marshal(v)
+			if err != nil {
+				invalid(field, "json", value, fmt.Sprintf("%s cannot be encoded as JSON: %v", field, err))
+				continue
+			}
+			data[field] = string(document)
+		}
+	}
+
+	for _, field := range f.array {
+		value, exists := data[field]
+		if !exists || value == nil {
+			continue
+		}
+		if text, ok := value.(string); ok {
+			if text == "" {
+				data[field] = nil
+				continue
+			}
+			// 已经是数组格式的文本原样写入
+			if _, ok := d.ParseArray(text); ok {
+				continue
+			}
+		}
+
+		literal, err := d.ArrayLiteral(generator.ArrayValues(value))
+		if err != nil {
+			invalid(field, "array", value, fmt.Sprintf("%s cannot be encoded as an array: %v", field, err))
+			continue
+		}
+		data[field] = literal
+	}
+
+	return validationErrors
+}
+
+// decode 把读取到的 JSON 字段解析为对象，把数组字段解析为列表，无法解析的值保持原样
+func (f *encodedFields) decode(d dialect.Dialect, records []map[string]interface{}) {
+	for _, record := range records {
+		for _, field := range f.jsonColumns {
+			if raw, ok := textValue(record[field]); ok {
+				var value interface{}
+				if err := json.Unmarshal([]byte(raw), &value); err == nil {
+					record[field] = value
+				}
+			}
+		}
+		for field, elementType := range f.arrayColumns {
+			if raw, ok := textValue(record[field]); ok {
+				if values, ok := generator.DecodeArray(d, raw, elementType); ok {
+					record[field] = values
+				}
+			}
+		}
+	}
+}
+
+// textValue 返回驱动以文本读取的值
+func textValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	}
+	return "", false
+}
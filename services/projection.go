@@ -0,0 +1,70 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/otkinlife/crud-generator/models"
+	"github.com/otkinlife/crud-generator/types"
+	"gorm.io/gorm"
+)
+
+// projectionConfig 解析确定返回列所需的配置：展示字段、可更新字段和隐藏字段
+func projectionConfig(config *models.TableConfiguration) (*types.Config, error) {
+	projection := &types.Config{
+		QueryConfig:  &types.QueryConfig{},
+		UpdateConfig: &types.UpdateConfig{},
+	}
+
+	if config.QueryDisplayFields != "" {
+		if err := json.Unmarshal([]byte(config.QueryDisplayFields), &projection.QueryConfig.DisplayFields); err != nil {
+			return nil, fmt.Errorf("failed to parse display fields: %w", err)
+		}
+	}
+	if config.QueryHiddenFields != "" {
+		if err := json.Unmarshal([]byte(config.QueryHiddenFields), &projection.QueryConfig.HiddenFields); err != nil {
+			return nil, fmt.Errorf("failed to parse hidden fields: %w", err)
+		}
+	}
+
+	updatableFields, err := parseUpdatableFields(config.UpdateUpdatableFields)
+	if err != nil {
+		return nil, err
+	}
+	projection.UpdateConfig.UpdatableFields = updatableFields
+
+	return projection, nil
+}
+
+// projectionTableColumns 未配置展示字段时，需要表的全部列才能排除隐藏字段或校验 fields 参数；其他情况返回 nil
+func projectionTableColumns(config *models.TableConfiguration, db *gorm.DB, projection *types.Config, requested []string) ([]string, error) {
+	if len(projection.QueryConfig.DisplayFields) > 0 ||
+		(len(projection.QueryConfig.HiddenFields) == 0 && len(requested) == 0) {
+		return nil, nil
+	}
+
+	schema, err := LoadTableSchema(db, config.DBTableName, config.CreateStatement)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]string, len(schema.Fields))
+	for i, field := range schema.Fields {
+		columns[i] = field.Name
+	}
+	return columns, nil
+}
+
+// unwrapExpressionValues 表达式列（统计、JSON 路径）没有声明类型，SQLite 驱动返回 *interface{}，取出其中的值
+func unwrapExpressionValues(records []map[string]interface{}) {
+	for _, record := range records {
+		for key, value := range record {
+			if pointer, ok := value.(*interface{}); ok {
+				value = nil
+				if pointer != nil {
+					value = *pointer
+				}
+				record[key] = value
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/dialect"
+	"github.com/otkinlife/crud-generator/generator"
+	"github.com/otkinlife/crud-generator/models"
+	"gorm.io/gorm"
+)
+
+// splitColumnList 解析逗号分隔的列名列表
+func splitColumnList(value string) []string {
+	var columns []string
+	for _, column := range strings.Split(value, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// primaryKey 获取配置对应表的主键列：优先使用配置中的主键，否则从表结构中读取
+func (s *CRUDService) primaryKey(config *models.TableConfiguration, db *gorm.DB) ([]string, error) {
+	if columns := splitColumnList(config.PrimaryKey); len(columns) > 0 {
+		return columns, nil
+	}
+
+	schema, err := s.tableSchema(config, db)
+	if err != nil {
+		return nil, err
+	}
+	if columns := generator.PrimaryKeyColumns(schema, nil); len(columns) > 0 {
+		return columns, nil
+	}
+
+	// 兼容没有声明主键但包含 id 列的表
+	for _, field := range schema.Fields {
+		if field.Name == "id" {
+			return []string{"id"}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("table '%s' has no primary key, set primary_key in the configuration", config.DBTableName)
+}
+
+// whereRecordKey 按主键列添加定位单条记录的查询条件
+func whereRecordKey(query *gorm.DB, sqlDialect dialect.Dialect, primaryKey []string, id interface{}) (*gorm.DB, error) {
+	key, err := generator.ResolveRecordKey(primaryKey, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, column := range primaryKey {
+		query = query.Where(fmt.Sprintf("%s = ?", sqlDialect.QuoteIdentifier(column)), key[column])
+	}
+	return query, nil
+}
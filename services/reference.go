@@ -0,0 +1,210 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/dialect"
+	"github.com/otkinlife/crud-generator/generator"
+	"github.com/otkinlife/crud-generator/models"
+	"github.com/otkinlife/crud-generator/types"
+	"gorm.io/gorm"
+)
+
+const (
+	// referenceBatchSize 批量读取标签时每次查询的值数量
+	referenceBatchSize = 500
+	// maxReferenceMatches 跨连接按标签搜索时最多匹配的记录数
+	maxReferenceMatches = 1000
+)
+
+// referenceTarget 关联字段指向的表
+type referenceTarget struct {
+	db         *gorm.DB
+	dialect    dialect.Dialect
+	table      string
+	valueField string
+	labelField string
+	sameDB     bool // 与当前表在同一连接，可以使用子查询
+}
+
+// resolveReference 解析关联配置：优先使用目标配置的表和连接，否则使用当前连接中的表
+func (s *CRUDService) resolveReference(config *models.TableConfiguration, db *gorm.DB, field types.DisplayField) (*referenceTarget, error) {
+	reference := field.Reference
+	target := &referenceTarget{
+		db:         db,
+		table:      reference.Table,
+		valueField: reference.ValueField,
+		labelField: reference.LabelField,
+		sameDB:     true,
+	}
+
+	if reference.Config != "" {
+		targetConfig, err := s.GetConfigByName(reference.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load referenced configuration of field '%s': %w", field.Field, err)
+		}
+		if target.db, err = s.getBusinessDB(targetConfig.ConnectionID); err != nil {
+			return nil, fmt.Errorf("failed to get database connection: %w", err)
+		}
+		target.table = targetConfig.DBTableName
+		target.sameDB = targetConfig.ConnectionID == config.ConnectionID
+
+		if target.valueField == "" {
+			primaryKey, err := s.primaryKey(targetConfig, target.db)
+			if err != nil {
+				return nil, err
+			}
+			if len(primaryKey) != 1 {
+				return nil, fmt.Errorf("field '%s' references a composite key, set value_field", field.Field)
+			}
+			target.valueField = primaryKey[0]
+		}
+
+		// 目标配置的隐藏字段不能作为标签或值
+		projection, err := projectionConfig(targetConfig)
+		if err != nil {
+			return nil, err
+		}
+		for _, hiddenField := range projection.QueryConfig.HiddenFields {
+			if hiddenField == target.valueField || hiddenField == target.labelField {
+				return nil, fmt.Errorf("field '%s' references hidden field '%s' of '%s'", field.Field, hiddenField, reference.Config)
+			}
+		}
+	}
+
+	if target.table == "" {
+		return nil, fmt.Errorf("reference of field '%s' needs a config or a table", field.Field)
+	}
+	if target.valueField == "" {
+		target.valueField = "id"
+	}
+
+	var err error
+	if target.dialect, err = dialect.FromDB(target.db); err != nil {
+		return nil, fmt.Errorf("failed to select SQL dialect: %w", err)
+	}
+	return target, nil
+}
+
+// attachReferenceLabels 批量读取关联字段的标签，以 <字段>_label 放在原值旁边
+func (s *CRUDService) attachReferenceLabels(config *models.TableConfiguration, db *gorm.DB, projection *types.Config, records []map[string]interface{}) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	for _, field := range generator.ReferenceFields(projection) {
+		// 通过 fields 参数排除的字段不需要标签
+		var values []interface{}
+		seen := make(map[string]bool)
+		for _, record := range records {
+			value, exists := record[field.Field]
+			if !exists || value == nil {
+				continue
+			}
+			if key := referenceKey(value); !seen[key] {
+				seen[key] = true
+				values = append(values, value)
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		target, err := s.resolveReference(config, db, field)
+		if err != nil {
+			return err
+		}
+
+		labels := make(map[string]interface{}, len(values))
+		for start := 0; start < len(values); start += referenceBatchSize {
+			end := start + referenceBatchSize
+			if end > len(values) {
+				end = len(values)
+			}
+
+			var rows []map[string]interface{}
+			err := target.db.Table(target.table).
+				Select(fmt.Sprintf("%s AS ref_value, %s AS ref_label", target.dialect.QuoteIdentifier(target.valueField), target.dialect.QuoteIdentifier(target.labelField))).
+				Where(fmt.Sprintf("%s IN ?", target.dialect.QuoteIdentifier(target.valueField)), values[start:end]).
+				Find(&rows).Error
+			if err != nil {
+				return fmt.Errorf("failed to load labels of field '%s': %w", field.Field, err)
+			}
+			for _, row := range rows {
+				label := row["ref_label"]
+				if raw, ok := label.([]byte); ok {
+					label = string(raw)
+				}
+				labels[referenceKey(row["ref_value"])] = label
+			}
+		}
+
+		labelKey := generator.ReferenceLabelKey(field.Field)
+		for _, record := range records {
+			value, exists := record[field.Field]
+			if !exists {
+				continue
+			}
+			// 不覆盖同名的真实列
+			if _, taken := record[labelKey]; taken {
+				continue
+			}
+			if value == nil {
+				record[labelKey] = nil
+				continue
+			}
+			record[labelKey] = labels[referenceKey(value)]
+		}
+	}
+
+	return nil
+}
+
+// whereReferenceLabel 按标签搜索关联字段：标签包含搜索文本的目标记录
+func (s *CRUDService) whereReferenceLabel(query *gorm.DB, config *models.TableConfiguration, db *gorm.DB, sqlDialect dialect.Dialect, field types.DisplayField, text string) (*gorm.DB, error) {
+	target, err := s.resolveReference(config, db, field)
+	if err != nil {
+		return nil, err
+	}
+
+	condition, args, err := generator.FilterCondition(target.dialect, types.Filter{
+		Field:    target.labelField,
+		Operator: types.FilterOpContains,
+		Value:    text,
+	}, func() string { return "?" })
+	if err != nil {
+		return nil, err
+	}
+	matches := target.db.Table(target.table).Select(target.dialect.QuoteIdentifier(target.valueField)+" AS ref_value").Where(condition, args...)
+	column := generator.ColumnExpression(sqlDialect, field.Field)
+
+	if target.sameDB {
+		return query.Where(fmt.Sprintf("%s IN (?)", column), matches), nil
+	}
+
+	// 不同连接无法使用子查询，先读取匹配的值
+	var rows []map[string]interface{}
+	if err := matches.Limit(maxReferenceMatches + 1).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to search labels of field '%s': %w", field.Field, err)
+	}
+	values := make([]interface{}, len(rows))
+	for i, row := range rows {
+		values[i] = row["ref_value"]
+	}
+	if len(values) > maxReferenceMatches {
+		return nil, fmt.Errorf("more than %d records match label '%s' of field '%s', narrow the search", maxReferenceMatches, text, field.Field)
+	}
+	if len(values) == 0 {
+		return query.Where("1 = 0"), nil
+	}
+	return query.Where(fmt.Sprintf("%s IN ?", column), values), nil
+}
+
+// referenceKey 统一不同驱动返回的值类型，例如 int64 与 []byte
+func referenceKey(value interface{}) string {
+	if raw, ok := value.([]byte); ok {
+		return string(raw)
+	}
+	return strings.TrimSpace(fmt.Sprint(value))
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/otkinlife/crud-generator/dialect"
+	"github.com/otkinlife/crud-generator/generator"
+	"github.com/otkinlife/crud-generator/models"
+	"github.com/otkinlife/crud-generator/types"
+)
+
+// ErrRelationNotFound 配置中没有该名称的子表关系
+var ErrRelationNotFound = errors.New("relation not found")
+
+// findRelation 按名称查找配置的子表关系
+func findRelation(config *models.TableConfiguration, name string) (*types.Relation, error) {
+	var relations []types.Relation
+	if config.QueryRelations != "" {
+		if err := json.Unmarshal([]byte(config.QueryRelations), &relations); err != nil {
+			return nil, fmt.Errorf("failed to parse relations: %w", err)
+		}
+	}
+	for i := range relations {
+		if relations[i].Name == name {
+			return &relations[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: '%s' in configuration '%s'", ErrRelationNotFound, name, config.Name)
+}
+
+// ListChildren 查询一条记录在子表中的记录，子表使用自己的搜索、排序和分页配置
+func (s *CRUDService) ListChildren(configName string, id interface{}, relationName string, params *types.QueryParams) (*types.QueryResult, error) {
+	config, err := s.GetConfigByName(configName)
+	if err != nil {
+		return nil, err
+	}
+
+	relation, err := findRelation(config, relationName)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := s.getBusinessDB(config.ConnectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	sqlDialect, err := dialect.FromDB(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select SQL dialect: %w", err)
+	}
+
+	primaryKey, err := s.primaryKey(config, db)
+	if err != nil {
+		return nil, err
+	}
+
+	// 外键默认对应父表的单列主键
+	parentField := relation.ParentField
+	if parentField == "" {
+		if len(primaryKey) != 1 {
+			return nil, fmt.Errorf("relation '%s' of a composite key needs parent_field", relation.Name)
+		}
+		parentField = primaryKey[0]
+	}
+
+	query, err := whereRecordKey(db.Table(config.DBTableName), sqlDialect, primaryKey, id)
+	if err != nil {
+		return nil, err
+	}
+	var records []map[string]interface{}
+	if err := query.Select(sqlDialect.QuoteIdentifier(parentField)).Limit(1).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to query record: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w in table '%s'", generator.ErrRecordNotFound, config.DBTableName)
+	}
+
+	childConfig, err := s.GetConfigByName(relation.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load child configuration of relation '%s': %w", relation.Name, err)
+	}
+
+	return s.list(childConfig, params, map[string]interface{}{relation.ForeignKey: records[0][parentField]})
+}
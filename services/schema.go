@@ -0,0 +1,150 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/otkinlife/crud-generator/introspector"
+	"github.com/otkinlife/crud-generator/models"
+	"github.com/otkinlife/crud-generator/parser"
+	"github.com/otkinlife/crud-generator/types"
+	"gorm.io/gorm"
+)
+
+// LoadTableSchema 获取表结构：配置了建表语句（可以是包含索引、注释等语句的完整脚本）时按连接的数据库类型解析，否则读取数据库中的实时结构
+func LoadTableSchema(db *gorm.DB, tableName, createStatement string) (*types.TableSchema, error) {
+	if strings.TrimSpace(createStatement) != "" {
+		schemaParser, err := parser.NewParser(db.Dialector.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to select schema parser: %w", err)
+		}
+
+		schema, err := schemaParser.ParseScript(createStatement, tableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse create statement: %w", err)
+		}
+		return schema, nil
+	}
+
+	tableIntrospector, err := introspector.NewIntrospector(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select schema introspector: %w", err)
+	}
+
+	schema, err := tableIntrospector.IntrospectTable(tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to introspect table '%s': %w", tableName, err)
+	}
+	return schema, nil
+}
+
+// IntrospectTable 读取连接中指定表的实时结构及估算行数
+func (s *ConfigService) IntrospectTable(connectionID, tableName string) (*types.TableSchema, int64, error) {
+	db, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tableIntrospector, err := introspector.NewIntrospector(db)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to select schema introspector: %w", err)
+	}
+
+	schema, err := tableIntrospector.IntrospectTable(tableName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to introspect table '%s': %w", tableName, err)
+	}
+
+	rowCount, err := tableIntrospector.EstimateRowCount(tableName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return schema, rowCount, nil
+}
+
+// getConnection 获取业务数据库连接
+func (s *ConfigService) getConnection(connectionID string) (*gorm.DB, error) {
+	if s.dbManager == nil {
+		return nil, fmt.Errorf("database manager is not available")
+	}
+
+	db, err := s.dbManager.GetConnection(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	return db, nil
+}
+
+// GetTableSchema 获取配置对应的表结构
+func (s *CRUDService) GetTableSchema(configName string) (*types.TableSchema, error) {
+	config, err := s.GetConfigByName(configName)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := s.getBusinessDB(config.ConnectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	return LoadTableSchema(db, config.DBTableName, config.CreateStatement)
+}
+
+// tableSchemaCache 按配置缓存表结构，避免每次读写都重新解析建表语句或读取实时结构
+type tableSchemaCache struct {
+	mu      sync.RWMutex
+	entries map[uint]cachedTableSchema
+}
+
+// cachedTableSchema 记录读取表结构时配置的版本，配置更新后版本变化，缓存随之失效
+type cachedTableSchema struct {
+	version int
+	schema  *types.TableSchema
+}
+
+func newTableSchemaCache() *tableSchemaCache {
+	return &tableSchemaCache{entries: make(map[uint]cachedTableSchema)}
+}
+
+func (c *tableSchemaCache) get(config *models.TableConfiguration) (*types.TableSchema, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, exists := c.entries[config.ID]
+	if !exists || entry.version != config.Version {
+		return nil, false
+	}
+	return entry.schema, true
+}
+
+func (c *tableSchemaCache) set(config *models.TableConfiguration, schema *types.TableSchema) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[config.ID] = cachedTableSchema{version: config.Version, schema: schema}
+}
+
+// forget 清除配置的缓存，在配置更新或删除时调用
+func (c *tableSchemaCache) forget(configID uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, configID)
+}
+
+// tableSchema 获取配置对应的表结构，读取结果按配置缓存
+func (s *CRUDService) tableSchema(config *models.TableConfiguration, db *gorm.DB) (*types.TableSchema, error) {
+	cache := s.configService.schemas
+	if schema, exists := cache.get(config); exists {
+		return schema, nil
+	}
+
+	schema, err := LoadTableSchema(db, config.DBTableName, config.CreateStatement)
+	if err != nil {
+		return nil, err
+	}
+	if config.ID != 0 {
+		cache.set(config, schema)
+	}
+	return schema, nil
+}
@@ -3,9 +3,11 @@ package services
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/otkinlife/crud-generator/database"
+	"github.com/otkinlife/crud-generator/generator"
 	"github.com/otkinlife/crud-generator/models"
 	"github.com/otkinlife/crud-generator/types"
 	"gorm.io/gorm"
@@ -15,6 +17,8 @@ type ConfigService struct {
 	db        *gorm.DB
 	validator *validator.Validate
 	dbManager *database.DatabaseManager
+	// 按配置缓存的表结构，配置更新或删除时清除
+	schemas *tableSchemaCache
 }
 
 func NewConfigService() *ConfigService {
@@ -22,6 +26,7 @@ func NewConfigService() *ConfigService {
 		db:        database.GetDatabaseManager().GetMainDB(),
 		validator: validator.New(),
 		dbManager: database.GetDatabaseManager(),
+		schemas:   newTableSchemaCache(),
 	}
 }
 
@@ -30,6 +35,7 @@ func NewConfigServiceWithDB(db *gorm.DB, dbManager *database.DatabaseManager) *C
 		db:        db,
 		validator: validator.New(),
 		dbManager: dbManager,
+		schemas:   newTableSchemaCache(),
 	}
 }
 
@@ -38,6 +44,7 @@ func NewConfigServiceWithConnectionsDB(db *gorm.DB) *ConfigService {
 		db:        db,
 		validator: validator.New(),
 		dbManager: nil, // For package usage, we don't need the full dbManager
+		schemas:   newTableSchemaCache(),
 	}
 }
 
@@ -65,6 +72,10 @@ func (s *ConfigService) CreateConfig(config *models.TableConfiguration) error {
 		return fmt.Errorf("connection '%s' not found in database configuration", config.ConnectionID)
 	}
 
+	if err := s.validateDefaultSort(config); err != nil {
+		return err
+	}
+
 	return s.db.Create(config).Error
 }
 
@@ -139,6 +150,10 @@ func (s *ConfigService) UpdateConfig(id uint, config *models.TableConfiguration)
 		return fmt.Errorf("connection '%s' not found in database configuration", config.ConnectionID)
 	}
 
+	if err := s.validateDefaultSort(config); err != nil {
+		return err
+	}
+
 	config.Version++ // 增加版本号
 
 	// 避免更新主键ID，只更新需要更新的字段
@@ -147,10 +162,16 @@ func (s *ConfigService) UpdateConfig(id uint, config *models.TableConfiguration)
 		"name":                    config.Name,
 		"table_name":              config.DBTableName,
 		"create_statement":        config.CreateStatement,
+		"primary_key":             config.PrimaryKey,
 		"query_pagination":        config.QueryPagination,
 		"query_display_fields":    config.QueryDisplayFields,
 		"query_search_fields":     config.QuerySearchFields,
 		"query_sortable_fields":   config.QuerySortableFields,
+		"query_default_sort":      config.QueryDefaultSort,
+		"query_filter_fields":     config.QueryFilterFields,
+		"query_hidden_fields":     config.QueryHiddenFields,
+		"query_relations":         config.QueryRelations,
+		"query_aggregate":         config.QueryAggregate,
 		"create_creatable_fields": config.CreateCreatableFields,
 		"create_validation_rules": config.CreateValidationRules,
 		"create_default_values":   config.CreateDefaultValues,
@@ -172,10 +193,12 @@ func (s *ConfigService) UpdateConfig(id uint, config *models.TableConfiguration)
 		return fmt.Errorf("configuration not found")
 	}
 
+	s.schemas.forget(id)
 	return nil
 }
 
 func (s *ConfigService) DeleteConfig(id uint) error {
+	s.schemas.forget(id)
 	return s.db.Model(&models.TableConfiguration{}).Where("id = ?", id).Update("is_active", false).Error
 }
 
@@ -215,18 +238,81 @@ func (s *ConfigService) TestConfigConnection(configID uint) error {
 	return s.dbManager.TestConnectionWithTable(config.ConnectionID, config.DBTableName)
 }
 
+// ScaffoldConfig 根据建表语句或数据库中的实时表结构生成默认配置（不保存）
+func (s *ConfigService) ScaffoldConfig(connectionID, tableName, createStatement string) (*models.TableConfiguration, error) {
+	db, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := LoadTableSchema(db, tableName, createStatement)
+	if err != nil {
+		return nil, err
+	}
+
+	scaffold := generator.ScaffoldConfig(schema)
+
+	displayFields, err := json.Marshal(scaffold.QueryConfig.DisplayFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal display fields: %w", err)
+	}
+	searchFields, err := json.Marshal(scaffold.QueryConfig.SearchFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search fields: %w", err)
+	}
+	sortableFields, err := json.Marshal(scaffold.QueryConfig.SortableFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sortable fields: %w", err)
+	}
+	creatableFields, err := json.Marshal(scaffold.CreateConfig.CreatableFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal creatable fields: %w", err)
+	}
+	updatableFields, err := json.Marshal(scaffold.UpdateConfig.UpdatableFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal updatable fields: %w", err)
+	}
+
+	return &models.TableConfiguration{
+		ConnectionID:          connectionID,
+		Name:                  schema.TableName,
+		DBTableName:           schema.TableName,
+		CreateStatement:       createStatement,
+		QueryPagination:       scaffold.QueryConfig.Pagination,
+		QueryDisplayFields:    string(displayFields),
+		QuerySearchFields:     string(searchFields),
+		QuerySortableFields:   string(sortableFields),
+		CreateCreatableFields: string(creatableFields),
+		UpdateUpdatableFields: string(updatableFields),
+		Description:           schema.Comment,
+		IsActive:              true,
+		Version:               1,
+	}, nil
+}
+
 func (s *ConfigService) ConvertToLegacyConfig(config *models.TableConfiguration) (*types.Config, error) {
 	legacyConfig := &types.Config{
 		TableName:       config.DBTableName,
 		CreateStatement: config.CreateStatement,
+		PrimaryKey:      splitColumnList(config.PrimaryKey),
 	}
 
 	// 转换查询配置
-	if config.QuerySearchFields != "" || config.QuerySortableFields != "" {
+	if config.QueryDisplayFields != "" || config.QuerySearchFields != "" || config.QuerySortableFields != "" ||
+		config.QueryDefaultSort != "" || config.QueryFilterFields != "" || config.QueryHiddenFields != "" ||
+		config.QueryRelations != "" || config.QueryAggregate != "" {
 		queryConfig := &types.QueryConfig{
 			Pagination: config.QueryPagination,
 		}
 
+		if config.QueryDisplayFields != "" {
+			var displayFields []types.DisplayField
+			if err := json.Unmarshal([]byte(config.QueryDisplayFields), &displayFields); err != nil {
+				return nil, fmt.Errorf("failed to parse display fields: %w", err)
+			}
+			queryConfig.DisplayFields = displayFields
+		}
+
 		if config.QuerySearchFields != "" {
 			var searchFields []types.SearchField
 			if err := json.Unmarshal([]byte(config.QuerySearchFields), &searchFields); err != nil {
@@ -243,6 +329,40 @@ func (s *ConfigService) ConvertToLegacyConfig(config *models.TableConfiguration)
 			queryConfig.SortableFields = sortableFields
 		}
 
+		queryConfig.DefaultSort = generator.ParseSort(config.QueryDefaultSort)
+
+		if config.QueryFilterFields != "" {
+			var filterFields []types.FilterField
+			if err := json.Unmarshal([]byte(config.QueryFilterFields), &filterFields); err != nil {
+				return nil, fmt.Errorf("failed to parse filter fields: %w", err)
+			}
+			queryConfig.FilterFields = filterFields
+		}
+
+		if config.QueryHiddenFields != "" {
+			var hiddenFields []string
+			if err := json.Unmarshal([]byte(config.QueryHiddenFields), &hiddenFields); err != nil {
+				return nil, fmt.Errorf("failed to parse hidden fields: %w", err)
+			}
+			queryConfig.HiddenFields = hiddenFields
+		}
+
+		if config.QueryRelations != "" {
+			var relations []types.Relation
+			if err := json.Unmarshal([]byte(config.QueryRelations), &relations); err != nil {
+				return nil, fmt.Errorf("failed to parse relations: %w", err)
+			}
+			queryConfig.Relations = relations
+		}
+
+		if config.QueryAggregate != "" {
+			var aggregate types.AggregateConfig
+			if err := json.Unmarshal([]byte(config.QueryAggregate), &aggregate); err != nil {
+				return nil, fmt.Errorf("failed to parse aggregate settings: %w", err)
+			}
+			queryConfig.Aggregate = &aggregate
+		}
+
 		legacyConfig.QueryConfig = queryConfig
 	}
 
@@ -285,6 +405,54 @@ func (s *ConfigService) ConvertToLegacyConfig(config *models.TableConfiguration)
 	return legacyConfig, nil
 }
 
+// validateJSONPath 检查 JSON 路径字段（如 attrs.color、meta->tags）的写法，路径只能包含字母、数字和下划线
+func validateJSONPath(field string) error {
+	if (strings.Contains(field, ".") || strings.Contains(field, "->")) && !generator.IsJSONPath(field) {
+		return fmt.Errorf("invalid JSON path '%s', use keys of letters, digits and underscores such as attrs.color or meta->tags", field)
+	}
+	return nil
+}
+
+// validateDefaultSort 按表结构校验默认排序：默认排序不受可排序字段限制，只能使用表中存在且未隐藏的列
+func (s *ConfigService) validateDefaultSort(config *models.TableConfiguration) error {
+	sorts := generator.ParseSort(config.QueryDefaultSort)
+	if len(sorts) == 0 {
+		return nil
+	}
+
+	db, err := s.getConnection(config.ConnectionID)
+	if err != nil {
+		return err
+	}
+	schema, err := LoadTableSchema(db, config.DBTableName, config.CreateStatement)
+	if err != nil {
+		return fmt.Errorf("failed to validate default sort: %w", err)
+	}
+
+	columns := make(map[string]bool)
+	for _, field := range schema.Fields {
+		columns[field.Name] = true
+	}
+	var hiddenFields []string
+	if config.QueryHiddenFields != "" {
+		if err := json.Unmarshal([]byte(config.QueryHiddenFields), &hiddenFields); err != nil {
+			return fmt.Errorf("invalid query_hidden_fields JSON: %w", err)
+		}
+	}
+	for _, hiddenField := range hiddenFields {
+		delete(columns, hiddenField)
+	}
+
+	for _, sort := range sorts {
+		// JSON 路径字段按其所在的 JSON 列校验
+		column, _ := generator.ParseJSONPath(sort.Field)
+		if !columns[column] {
+			return fmt.Errorf("default sort field '%s' is not a visible column of table '%s'", sort.Field, config.DBTableName)
+		}
+	}
+	return nil
+}
+
 func (s *ConfigService) validateJSONFields(config *models.TableConfiguration) error {
 	// 验证展示字段JSON
 	if config.QueryDisplayFields != "" {
@@ -292,6 +460,17 @@ func (s *ConfigService) validateJSONFields(config *models.TableConfiguration) er
 		if err := json.Unmarshal([]byte(config.QueryDisplayFields), &displayFields); err != nil {
 			return fmt.Errorf("invalid query_display_fields JSON: %w", err)
 		}
+		for _, field := range displayFields {
+			if err := validateJSONPath(field.Field); err != nil {
+				return err
+			}
+			if field.Reference == nil {
+				continue
+			}
+			if field.Reference.LabelField == "" || (field.Reference.Config == "" && field.Reference.Table == "") {
+				return fmt.Errorf("reference of display field '%s' needs label_field and a config or a table", field.Field)
+			}
+		}
 	}
 
 	// 验证搜索字段JSON
@@ -300,6 +479,15 @@ func (s *ConfigService) validateJSONFields(config *models.TableConfiguration) er
 		if err := json.Unmarshal([]byte(config.QuerySearchFields), &searchFields); err != nil {
 			return fmt.Errorf("invalid query_search_fields JSON: %w", err)
 		}
+		for _, field := range searchFields {
+			// 全文检索字段的 Field 是参数名
+			if field.Type == types.SearchTypeFulltext {
+				continue
+			}
+			if err := validateJSONPath(field.Field); err != nil {
+				return err
+			}
+		}
 	}
 
 	// 验证排序字段JSON
@@ -310,6 +498,57 @@ func (s *ConfigService) validateJSONFields(config *models.TableConfiguration) er
 		}
 	}
 
+	// 验证过滤字段JSON
+	if config.QueryFilterFields != "" {
+		var filterFields []types.FilterField
+		if err := json.Unmarshal([]byte(config.QueryFilterFields), &filterFields); err != nil {
+			return fmt.Errorf("invalid query_filter_fields JSON: %w", err)
+		}
+	}
+
+	// 验证隐藏字段JSON
+	if config.QueryHiddenFields != "" {
+		var hiddenFields []string
+		if err := json.Unmarshal([]byte(config.QueryHiddenFields), &hiddenFields); err != nil {
+			return fmt.Errorf("invalid query_hidden_fields JSON: %w", err)
+		}
+	}
+
+	// 验证子表关系JSON
+	if config.QueryRelations != "" {
+		var relations []types.Relation
+		if err := json.Unmarshal([]byte(config.QueryRelations), &relations); err != nil {
+			return fmt.Errorf("invalid query_relations JSON: %w", err)
+		}
+		names := make(map[string]bool)
+		for _, relation := range relations {
+			if relation.Name == "" || relation.Config == "" || relation.ForeignKey == "" {
+				return fmt.Errorf("relation needs name, config and foreign_key")
+			}
+			if names[relation.Name] {
+				return fmt.Errorf("duplicate relation '%s'", relation.Name)
+			}
+			names[relation.Name] = true
+		}
+	}
+
+	// 验证统计配置JSON
+	if config.QueryAggregate != "" {
+		var aggregate types.AggregateConfig
+		if err := json.Unmarshal([]byte(config.QueryAggregate), &aggregate); err != nil {
+			return fmt.Errorf("invalid query_aggregate JSON: %w", err)
+		}
+		for _, metric := range aggregate.Metrics {
+			for _, function := range metric.Functions {
+				switch function {
+				case types.AggregateCount, types.AggregateSum, types.AggregateAvg, types.AggregateMin, types.AggregateMax:
+				default:
+					return fmt.Errorf("unknown aggregate function '%s' on field '%s'", function, metric.Field)
+				}
+			}
+		}
+	}
+
 	// 验证可创建字段JSON
 	if config.CreateCreatableFields != "" {
 		var creatableFields []types.CreatableField
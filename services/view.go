@@ -0,0 +1,362 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/otkinlife/crud-generator/dialect"
+	"github.com/otkinlife/crud-generator/generator"
+	"github.com/otkinlife/crud-generator/models"
+	"github.com/otkinlife/crud-generator/types"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrViewNotFound 没有该名称的视图，或视图是其他人的私有视图
+	ErrViewNotFound = errors.New("view not found")
+	// ErrViewExists 同一用户在同一配置下已有该名称的视图
+	ErrViewExists = errors.New("view already exists")
+	// ErrViewNotOwned 共享视图只能由创建者修改或删除
+	ErrViewNotOwned = errors.New("view is owned by another user")
+	// ErrViewOwnerRequired 无法识别用户时只能保存共享视图，不能修改或删除视图
+	ErrViewOwnerRequired = errors.New("view requires an identified user")
+)
+
+// viewDB 视图和表配置保存在同一个数据库
+func (s *CRUDService) viewDB() *gorm.DB {
+	if s.mainDB != nil {
+		return s.mainDB
+	}
+	return s.dbManager.GetMainDB()
+}
+
+// visibleViews 查询用户可见的视图：自己的视图和其他人共享的视图；无法识别用户时只有共享视图可见
+func (s *CRUDService) visibleViews(configName, owner string) *gorm.DB {
+	query := s.viewDB().Where("config_name = ?", configName)
+	if owner == "" {
+		return query.Where("shared = ?", true)
+	}
+	return query.Where("owner = ? OR shared = ?", owner, true)
+}
+
+// ListViews 列出配置下当前用户的视图和其他人共享的视图，按名称排序；
+// 与 GetView 一致，自己的视图覆盖其他人共享的同名视图
+func (s *CRUDService) ListViews(configName, owner string) ([]types.SavedView, error) {
+	var rows []models.SavedView
+	if err := s.visibleViews(configName, owner).Order("name").Order("id").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list views: %w", err)
+	}
+
+	ownNames := make(map[string]bool)
+	for _, row := range rows {
+		if row.Owner == owner {
+			ownNames[row.Name] = true
+		}
+	}
+
+	views := make([]types.SavedView, 0, len(rows))
+	for i := range rows {
+		if rows[i].Owner != owner && ownNames[rows[i].Name] {
+			continue
+		}
+		view, err := decodeView(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, *view)
+	}
+	return views, nil
+}
+
+// GetView 按名称读取视图，当前用户自己的视图优先于其他人共享的同名视图
+func (s *CRUDService) GetView(configName, name, owner string) (*types.SavedView, error) {
+	var rows []models.SavedView
+	if err := s.visibleViews(configName, owner).Where("name = ?", name).Order("id").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query view: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: '%s' of configuration '%s'", ErrViewNotFound, name, configName)
+	}
+
+	row := &rows[0]
+	for i := range rows {
+		if rows[i].Owner == owner {
+			row = &rows[i]
+			break
+		}
+	}
+	return decodeView(row)
+}
+
+// CreateView 保存当前用户的视图
+func (s *CRUDService) CreateView(configName, owner string, view *types.SavedView) (*types.SavedView, error) {
+	if err := checkViewOwner(owner, view); err != nil {
+		return nil, err
+	}
+
+	config, err := s.GetConfigByName(configName)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkViewConditions(config, view); err != nil {
+		return nil, err
+	}
+
+	row, err := encodeView(config, view)
+	if err != nil {
+		return nil, err
+	}
+	row.Owner = owner
+
+	if err := s.checkViewName(row, 0); err != nil {
+		return nil, err
+	}
+	if err := s.viewDB().Create(row).Error; err != nil {
+		return nil, fmt.Errorf("failed to create view: %w", err)
+	}
+	return decodeView(row)
+}
+
+// UpdateView 修改当前用户的视图，可以改名或切换共享
+func (s *CRUDService) UpdateView(configName, name, owner string, view *types.SavedView) (*types.SavedView, error) {
+	if err := checkViewOwner(owner, view); err != nil {
+		return nil, err
+	}
+
+	config, err := s.GetConfigByName(configName)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.ownedView(configName, name, owner)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkViewConditions(config, view); err != nil {
+		return nil, err
+	}
+
+	row, err := encodeView(config, view)
+	if err != nil {
+		return nil, err
+	}
+	row.ID = existing.ID
+	row.Owner = existing.Owner
+	row.CreatedAt = existing.CreatedAt
+
+	if err := s.checkViewName(row, existing.ID); err != nil {
+		return nil, err
+	}
+	if err := s.viewDB().Save(row).Error; err != nil {
+		return nil, fmt.Errorf("failed to update view: %w", err)
+	}
+	return decodeView(row)
+}
+
+// DeleteView 删除当前用户的视图
+func (s *CRUDService) DeleteView(configName, name, owner string) error {
+	existing, err := s.ownedView(configName, name, owner)
+	if err != nil {
+		return err
+	}
+	if err := s.viewDB().Delete(&models.SavedView{}, existing.ID).Error; err != nil {
+		return fmt.Errorf("failed to delete view: %w", err)
+	}
+	return nil
+}
+
+// ownedView 查找当前用户的视图；同名视图是其他人共享的时返回 ErrViewNotOwned。
+// 匿名请求无法证明是视图的创建者，不能修改或删除任何视图，包括其他匿名请求共享的视图
+func (s *CRUDService) ownedView(configName, name, owner string) (*models.SavedView, error) {
+	var rows []models.SavedView
+	if err := s.visibleViews(configName, owner).Where("name = ?", name).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query view: %w", err)
+	}
+	if owner == "" && len(rows) > 0 {
+		return nil, fmt.Errorf("%w: view '%s' cannot be changed anonymously", ErrViewOwnerRequired, name)
+	}
+	for i := range rows {
+		if rows[i].Owner == owner {
+			return &rows[i], nil
+		}
+	}
+	if len(rows) > 0 {
+		return nil, fmt.Errorf("%w: '%s' of configuration '%s'", ErrViewNotOwned, name, configName)
+	}
+	return nil, fmt.Errorf("%w: '%s' of configuration '%s'", ErrViewNotFound, name, configName)
+}
+
+// checkViewOwner 私有视图只属于可识别的用户，否则所有匿名请求都能读取和修改
+func checkViewOwner(owner string, view *types.SavedView) error {
+	if owner == "" && !view.Shared {
+		return fmt.Errorf("%w: view '%s' must be shared", ErrViewOwnerRequired, strings.TrimSpace(view.Name))
+	}
+	return nil
+}
+
+// checkViewConditions 视图的搜索条件只能使用配置的搜索字段（及关联字段的标签参数），组合过滤条件只能使用
+// 过滤字段允许的操作符，与列表接口的限制一致
+func (s *CRUDService) checkViewConditions(config *models.TableConfiguration, view *types.SavedView) error {
+	if len(view.Search) > 0 {
+		var searchFields []types.SearchField
+		if config.QuerySearchFields != "" {
+			if err := json.Unmarshal([]byte(config.QuerySearchFields), &searchFields); err != nil {
+				return fmt.Errorf("failed to parse search fields: %w", err)
+			}
+		}
+		projection, err := projectionConfig(config)
+		if err != nil {
+			return err
+		}
+
+		searchable := make(map[string]bool)
+		for _, field := range searchFields {
+			searchable[field.Field] = true
+		}
+		for _, field := range generator.ReferenceFields(projection) {
+			searchable[generator.ReferenceLabelKey(field.Field)] = true
+		}
+		for key := range view.Search {
+			if !searchable[key] {
+				return fmt.Errorf("%w: field '%s' of view '%s' is not searchable", generator.ErrInvalidFilter, key, strings.TrimSpace(view.Name))
+			}
+		}
+	}
+
+	if view.Where != nil {
+		var filterFields []types.FilterField
+		if config.QueryFilterFields != "" {
+			if err := json.Unmarshal([]byte(config.QueryFilterFields), &filterFields); err != nil {
+				return fmt.Errorf("failed to parse filter fields: %w", err)
+			}
+		}
+		db, err := s.getBusinessDB(config.ConnectionID)
+		if err != nil {
+			return fmt.Errorf("failed to get database connection: %w", err)
+		}
+		sqlDialect, err := dialect.FromDB(db)
+		if err != nil {
+			return fmt.Errorf("failed to select SQL dialect: %w", err)
+		}
+		if _, _, err := generator.FilterTreeCondition(sqlDialect, filterFields, view.Where, func() string { return "?" }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkViewName 同一用户在同一配置下的视图名称不能重复
+func (s *CRUDService) checkViewName(row *models.SavedView, excludeID uint) error {
+	var count int64
+	if err := s.viewDB().Model(&models.SavedView{}).
+		Where("config_name = ? AND owner = ? AND name = ? AND id <> ?", row.ConfigName, row.Owner, row.Name, excludeID).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to query view: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: '%s' of configuration '%s'", ErrViewExists, row.Name, row.ConfigName)
+	}
+	return nil
+}
+
+// encodeView 校验视图并转换为保存的格式，隐藏字段不能作为显示列或排序字段
+func encodeView(config *models.TableConfiguration, view *types.SavedView) (*models.SavedView, error) {
+	name := strings.TrimSpace(view.Name)
+	if name == "" {
+		return nil, fmt.Errorf("view name is required")
+	}
+	if len(name) > 100 {
+		return nil, fmt.Errorf("view name must be at most 100 characters")
+	}
+	if view.PageSize < 0 {
+		return nil, fmt.Errorf("page size of view '%s' must not be negative", name)
+	}
+
+	projection, err := projectionConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	hidden := func(field string) bool {
+		column, _ := generator.ParseJSONPath(field)
+		for _, hiddenField := range projection.QueryConfig.HiddenFields {
+			if hiddenField == column {
+				return true
+			}
+		}
+		return false
+	}
+	for _, field := range view.Fields {
+		if hidden(field) {
+			return nil, fmt.Errorf("%w: field '%s' is hidden", generator.ErrInvalidField, field)
+		}
+	}
+	for _, sortField := range generator.ParseSort(view.Sort) {
+		if hidden(sortField.Field) {
+			return nil, fmt.Errorf("field '%s' is not sortable", sortField.Field)
+		}
+	}
+
+	row := &models.SavedView{
+		ConfigName: config.Name,
+		Name:       name,
+		Shared:     view.Shared,
+		Sort:       strings.TrimSpace(view.Sort),
+		PageSize:   view.PageSize,
+	}
+	if len(view.Search) > 0 {
+		search, err := json.Marshal(view.Search)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode search of view '%s': %w", name, err)
+		}
+		row.Search = string(search)
+	}
+	if view.Where != nil {
+		where, err := json.Marshal(view.Where)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode filter of view '%s': %w", name, err)
+		}
+		row.Filter = string(where)
+	}
+	if len(view.Fields) > 0 {
+		fields, err := json.Marshal(view.Fields)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode fields of view '%s': %w", name, err)
+		}
+		row.Fields = string(fields)
+	}
+	return row, nil
+}
+
+// decodeView 把保存的视图转换为接口格式
+func decodeView(row *models.SavedView) (*types.SavedView, error) {
+	view := &types.SavedView{
+		ID:         row.ID,
+		ConfigName: row.ConfigName,
+		Name:       row.Name,
+		Owner:      row.Owner,
+		Shared:     row.Shared,
+		Sort:       row.Sort,
+		PageSize:   row.PageSize,
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+	}
+	if row.Search != "" {
+		if err := json.Unmarshal([]byte(row.Search), &view.Search); err != nil {
+			return nil, fmt.Errorf("failed to parse search of view '%s': %w", row.Name, err)
+		}
+	}
+	if row.Filter != "" {
+		view.Where = &types.FilterNode{}
+		if err := json.Unmarshal([]byte(row.Filter), view.Where); err != nil {
+			return nil, fmt.Errorf("failed to parse filter of view '%s': %w", row.Name, err)
+		}
+	}
+	if row.Fields != "" {
+		if err := json.Unmarshal([]byte(row.Fields), &view.Fields); err != nil {
+			return nil, fmt.Errorf("failed to parse fields of view '%s': %w", row.Name, err)
+		}
+	}
+	return view, nil
+}
@@ -7,12 +7,18 @@ CREATE TABLE IF NOT EXISTS table_configurations (
     name VARCHAR(100) NOT NULL,
     table_name VARCHAR(100) NOT NULL,
     create_statement TEXT NOT NULL,
+    primary_key VARCHAR(255), -- 主键列，多个用逗号分隔，为空时使用表结构中的主键
     
     -- 查询配置
     query_pagination BOOLEAN DEFAULT true,
     query_display_fields TEXT,  -- 展示字段配置
     query_search_fields TEXT,   -- 搜索字段配置
     query_sortable_fields TEXT, -- 可排序字段配置
+    query_default_sort VARCHAR(255), -- 默认排序，例如 status,-created_at
+    query_filter_fields TEXT, -- 过滤字段及允许的操作符配置
+    query_hidden_fields TEXT, -- 永不返回的字段，如密码哈希
+    query_relations TEXT, -- 子表关系配置
+    query_aggregate TEXT, -- 统计接口允许的分组字段和指标
     
     -- 创建配置
     create_creatable_fields TEXT, -- 可创建字段配置
@@ -43,6 +49,26 @@ CREATE INDEX idx_table_configurations_connection ON table_configurations(connect
 CREATE INDEX idx_table_configurations_name ON table_configurations(name);
 CREATE INDEX idx_table_configurations_active ON table_configurations(is_active);
 
+-- 保存的列表视图
+CREATE TABLE IF NOT EXISTS saved_views (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    config_name VARCHAR(100) NOT NULL, -- 所属表配置名称
+    name VARCHAR(100) NOT NULL,
+    owner VARCHAR(100), -- 创建者，未启用认证时为空
+    shared BOOLEAN DEFAULT false, -- 共享视图对所有人可见
+    search TEXT, -- 搜索参数
+    filter TEXT, -- 高级筛选条件树
+    sort VARCHAR(255), -- 排序，例如 status,-created_at
+    fields TEXT, -- 显示列
+    page_size INT DEFAULT 0,
+    
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE INDEX idx_saved_views_config_name ON saved_views(config_name);
+CREATE INDEX idx_saved_views_owner ON saved_views(owner);
+
 -- 插入示例表配置
 INSERT IGNORE INTO table_configurations (
     connection_id, name, table_name, create_statement,
@@ -0,0 +1,1111 @@
+package builder_test
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/otkinlife/crud-generator/database"
+	"github.com/otkinlife/crud-generator/generator"
+	"github.com/otkinlife/crud-generator/models"
+	"github.com/otkinlife/crud-generator/services"
+	"github.com/otkinlife/crud-generator/types"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// newTestCRUDService opens an in-memory SQLite database holding the configuration and the business table
+func newTestCRUDService(t *testing.T, config models.TableConfiguration, statements ...string) *services.CRUDService {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	// every connection of an in-memory database is a separate database
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get sql.DB: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := db.AutoMigrate(&models.TableConfiguration{}, &models.SavedView{}); err != nil {
+		t.Fatalf("Failed to migrate configurations: %v", err)
+	}
+	config.ConnectionID = "default"
+	config.IsActive = true
+	if err := db.Create(&config).Error; err != nil {
+		t.Fatalf("Failed to create configuration: %v", err)
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("Failed to execute '%s': %v", statement, err)
+		}
+	}
+
+	return services.NewCRUDServiceWithDB(db, services.NewConfigServiceWithConnectionsDB(db))
+}
+
+func TestListCursorPagination(t *testing.T) {
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:                "orders",
+		DBTableName:         "orders",
+		QueryPagination:     true,
+		QuerySortableFields: `["amount"]`,
+	},
+		`CREATE TABLE orders (id INTEGER PRIMARY KEY, amount INTEGER NOT NULL)`,
+		`INSERT INTO orders (id, amount) VALUES (1, 30), (2, 10), (3, 20), (4, 10), (5, 30)`,
+	)
+
+	ids := func(result *types.QueryResult) []int64 {
+		var ids []int64
+		for _, record := range result.Data {
+			ids = append(ids, record["id"].(int64))
+		}
+		return ids
+	}
+	equal := func(a, b []int64) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	params := &types.QueryParams{
+		PageSize:   2,
+		CursorMode: true,
+		SkipCount:  true,
+		Sort:       []types.SortField{{Field: "amount", Order: types.SortOrderDESC}},
+	}
+
+	// amount DESC, id ASC: 1, 5, 3, 2, 4
+	first, err := crudService.List("orders", params)
+	if err != nil {
+		t.Fatalf("Failed to list first page: %v", err)
+	}
+	if !equal(ids(first), []int64{1, 5}) || first.PrevCursor != "" || first.NextCursor == "" || first.Total != 0 {
+		t.Fatalf("Unexpected first page: ids=%v prev=%q next=%q total=%d", ids(first), first.PrevCursor, first.NextCursor, first.Total)
+	}
+
+	params.Cursor = first.NextCursor
+	second, err := crudService.List("orders", params)
+	if err != nil {
+		t.Fatalf("Failed to list second page: %v", err)
+	}
+	if !equal(ids(second), []int64{3, 2}) || second.PrevCursor == "" || second.NextCursor == "" {
+		t.Fatalf("Unexpected second page: ids=%v prev=%q next=%q", ids(second), second.PrevCursor, second.NextCursor)
+	}
+
+	params.Cursor = second.NextCursor
+	params.SkipCount = false
+	last, err := crudService.List("orders", params)
+	if err != nil {
+		t.Fatalf("Failed to list last page: %v", err)
+	}
+	if !equal(ids(last), []int64{4}) || last.NextCursor != "" || last.Total != 5 {
+		t.Fatalf("Unexpected last page: ids=%v next=%q total=%d", ids(last), last.NextCursor, last.Total)
+	}
+
+	params.Cursor = second.PrevCursor
+	previous, err := crudService.List("orders", params)
+	if err != nil {
+		t.Fatalf("Failed to list previous page: %v", err)
+	}
+	if !equal(ids(previous), []int64{1, 5}) || previous.PrevCursor != "" || previous.NextCursor == "" {
+		t.Fatalf("Unexpected previous page: ids=%v prev=%q next=%q", ids(previous), previous.PrevCursor, previous.NextCursor)
+	}
+
+	params.Cursor = "not-a-cursor"
+	if _, err := crudService.List("orders", params); err == nil {
+		t.Error("Expected an error for an invalid cursor")
+	}
+}
+
+// NULL 排在非 NULL 值之前（SQLite），游标翻页不能跳过或重复 NULL 行
+func TestListCursorPaginationNulls(t *testing.T) {
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:                "orders",
+		DBTableName:         "orders",
+		QueryPagination:     true,
+		QuerySortableFields: `["amount"]`,
+	},
+		`CREATE TABLE orders (id INTEGER PRIMARY KEY, amount INTEGER)`,
+		`INSERT INTO orders (id, amount) VALUES (1, 30), (2, NULL), (3, 10), (4, NULL), (5, 20), (6, 10), (7, NULL)`,
+	)
+
+	ids := func(result *types.QueryResult) []string {
+		var ids []string
+		for _, record := range result.Data {
+			ids = append(ids, fmt.Sprint(record["id"]))
+		}
+		return ids
+	}
+
+	for _, order := range []types.SortOrder{types.SortOrderASC, types.SortOrderDESC} {
+		sortFields := []types.SortField{{Field: "amount", Order: order}}
+		all, err := crudService.List("orders", &types.QueryParams{Page: 1, PageSize: 100, Sort: sortFields})
+		if err != nil {
+			t.Fatalf("Failed to list all records: %v", err)
+		}
+		expected := strings.Join(ids(all), ",")
+
+		params := &types.QueryParams{PageSize: 2, CursorMode: true, SkipCount: true, Sort: sortFields}
+		var forward []string
+		var pages []*types.QueryResult
+		for {
+			page, err := crudService.List("orders", params)
+			if err != nil {
+				t.Fatalf("Failed to list page: %v", err)
+			}
+			forward = append(forward, ids(page)...)
+			pages = append(pages, page)
+			if page.NextCursor == "" || len(pages) > 7 {
+				break
+			}
+			params.Cursor = page.NextCursor
+		}
+		if strings.Join(forward, ",") != expected {
+			t.Errorf("%s: expected forward pages %s, got %s", order, expected, strings.Join(forward, ","))
+		}
+
+		// 从最后一页向前翻回第一页
+		var backward []string
+		for i := len(pages) - 1; i > 0; i-- {
+			params.Cursor = pages[i].PrevCursor
+			page, err := crudService.List("orders", params)
+			if err != nil {
+				t.Fatalf("Failed to list previous page: %v", err)
+			}
+			if strings.Join(ids(page), ",") != strings.Join(ids(pages[i-1]), ",") {
+				t.Errorf("%s: expected previous page %v, got %v", order, ids(pages[i-1]), ids(page))
+			}
+			backward = append(ids(page), backward...)
+		}
+		if len(pages) > 1 && strings.Join(append(backward, ids(pages[len(pages)-1])...), ",") != expected {
+			t.Errorf("%s: expected backward pages %s, got %v", order, expected, backward)
+		}
+	}
+}
+
+func TestListMultiSort(t *testing.T) {
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:                "tasks",
+		DBTableName:         "tasks",
+		QuerySortableFields: `["status", "priority"]`,
+		QueryDefaultSort:    "status,-priority",
+	},
+		`CREATE TABLE tasks (id INTEGER PRIMARY KEY, status TEXT NOT NULL, priority INTEGER NOT NULL)`,
+		`INSERT INTO tasks (id, status, priority) VALUES (1, 'open', 1), (2, 'done', 3), (3, 'open', 5), (4, 'done', 2)`,
+	)
+
+	listIDs := func(params *types.QueryParams) []int64 {
+		result, err := crudService.List("tasks", params)
+		if err != nil {
+			t.Fatalf("Failed to list tasks: %v", err)
+		}
+		var ids []int64
+		for _, record := range result.Data {
+			ids = append(ids, record["id"].(int64))
+		}
+		return ids
+	}
+
+	if ids := listIDs(&types.QueryParams{}); len(ids) != 4 || ids[0] != 2 || ids[1] != 4 || ids[2] != 3 || ids[3] != 1 {
+		t.Errorf("Expected the default sort status,-priority to give [2 4 3 1], got %v", ids)
+	}
+
+	sorts := generator.ParseSort("-status, priority")
+	if ids := listIDs(&types.QueryParams{Sort: sorts}); len(ids) != 4 || ids[0] != 1 || ids[1] != 3 || ids[2] != 4 || ids[3] != 2 {
+		t.Errorf("Expected -status,priority to give [1 3 4 2], got %v", ids)
+	}
+
+	if _, err := crudService.List("tasks", &types.QueryParams{Sort: generator.ParseSort("id")}); err == nil {
+		t.Error("Expected an error when sorting by a field that is not sortable")
+	}
+}
+
+// 默认排序在保存配置时按表结构校验
+func TestDefaultSortValidation(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.TableConfiguration{}); err != nil {
+		t.Fatalf("Failed to migrate configurations: %v", err)
+	}
+
+	dbManager := database.GetDatabaseManager()
+	dbManager.SetExistingConnection("default_sort", db, &models.DatabaseConfig{Name: "default_sort", DbType: "sqlite", DatabaseName: ":memory:"})
+	t.Cleanup(func() { dbManager.RemoveConnection("default_sort") })
+	configService := services.NewConfigServiceWithDB(db, dbManager)
+
+	tests := []struct {
+		name        string
+		defaultSort string
+		valid       bool
+	}{
+		{"columns", "status,-created_at", true},
+		{"json path", "-attrs.rank", true},
+		{"missing column", "-priority", false},
+		{"hidden column", "secret", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := configService.CreateConfig(&models.TableConfiguration{
+				Name:              "tasks_" + strings.ReplaceAll(tt.name, " ", "_"),
+				ConnectionID:      "default_sort",
+				DBTableName:       "tasks",
+				CreateStatement:   `CREATE TABLE tasks (id INTEGER PRIMARY KEY, status TEXT, created_at DATETIME, attrs JSON, secret TEXT)`,
+				QueryHiddenFields: `["secret"]`,
+				QueryDefaultSort:  tt.defaultSort,
+				IsActive:          true,
+			})
+			if tt.valid && err != nil {
+				t.Errorf("Expected default sort %q to be accepted: %v", tt.defaultSort, err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("Expected default sort %q to be rejected", tt.defaultSort)
+			}
+		})
+	}
+}
+
+func TestListFilters(t *testing.T) {
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:              "people",
+		DBTableName:       "people",
+		QueryDefaultSort:  "id",
+		QueryFilterFields: `[{"field": "age", "operators": ["gte", "between", "is_null"]}, {"field": "name", "operators": ["prefix", "in", "ne"]}]`,
+	},
+		`CREATE TABLE people (id INTEGER PRIMARY KEY, name TEXT NOT NULL, age INTEGER)`,
+		`INSERT INTO people (id, name, age) VALUES (1, 'john', 17), (2, 'joan', 30), (3, 'ann', NULL), (4, 'jo_e', 45)`,
+	)
+
+	tests := []struct {
+		name    string
+		filters []types.Filter
+		ids     []int64
+	}{
+		{"gte", []types.Filter{{Field: "age", Operator: types.FilterOpGte, Value: "18"}}, []int64{2, 4}},
+		{"between", []types.Filter{{Field: "age", Operator: types.FilterOpBetween, Value: "10,30"}}, []int64{1, 2}},
+		{"is_null", []types.Filter{{Field: "age", Operator: types.FilterOpIsNull}}, []int64{3}},
+		{"in", []types.Filter{{Field: "name", Operator: types.FilterOpIn, Value: "ann,joan"}}, []int64{2, 3}},
+		{"prefix escapes wildcards", []types.Filter{{Field: "name", Operator: types.FilterOpPrefix, Value: "jo_"}}, []int64{4}},
+		{"combined", []types.Filter{
+			{Field: "name", Operator: types.FilterOpPrefix, Value: "jo"},
+			{Field: "name", Operator: types.FilterOpNe, Value: "john"},
+		}, []int64{2, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := crudService.List("people", &types.QueryParams{Filters: tt.filters})
+			if err != nil {
+				t.Fatalf("Failed to list people: %v", err)
+			}
+			var ids []int64
+			for _, record := range result.Data {
+				ids = append(ids, record["id"].(int64))
+			}
+			if len(ids) != len(tt.ids) {
+				t.Fatalf("Expected ids %v, got %v", tt.ids, ids)
+			}
+			for i := range ids {
+				if ids[i] != tt.ids[i] {
+					t.Fatalf("Expected ids %v, got %v", tt.ids, ids)
+				}
+			}
+		})
+	}
+
+	rejected := []types.Filter{
+		{Field: "age", Operator: types.FilterOpLt, Value: "18"},
+		{Field: "id", Operator: types.FilterOpEq, Value: "1"},
+		{Field: "age", Operator: types.FilterOpBetween, Value: "10"},
+	}
+	for _, filter := range rejected {
+		_, err := crudService.List("people", &types.QueryParams{Filters: []types.Filter{filter}})
+		if !errors.Is(err, generator.ErrInvalidFilter) {
+			t.Errorf("Expected ErrInvalidFilter for %+v, got %v", filter, err)
+		}
+	}
+}
+
+func TestListFilterTree(t *testing.T) {
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:              "jobs",
+		DBTableName:       "jobs",
+		QueryDefaultSort:  "id",
+		QueryFilterFields: `[{"field": "status", "operators": ["eq", "in"]}, {"field": "created_at", "operators": ["lt"]}]`,
+	},
+		`CREATE TABLE jobs (id INTEGER PRIMARY KEY, status TEXT NOT NULL, created_at TEXT NOT NULL)`,
+		`INSERT INTO jobs (id, status, created_at) VALUES (1, 'failed', '2024-01-05'), (2, 'pending', '2024-01-01'), (3, 'pending', '2024-01-09'), (4, 'done', '2024-01-01')`,
+	)
+
+	leaf := func(field string, operator types.FilterOperator, value interface{}) types.FilterNode {
+		return types.FilterNode{Filter: types.Filter{Field: field, Operator: operator, Value: value}}
+	}
+
+	tests := []struct {
+		name  string
+		where *types.FilterNode
+		ids   []int64
+	}{
+		{"or with nested and", &types.FilterNode{Or: []types.FilterNode{
+			leaf("status", types.FilterOpEq, "failed"),
+			{And: []types.FilterNode{
+				leaf("status", types.FilterOpEq, "pending"),
+				leaf("created_at", types.FilterOpLt, "2024-01-05"),
+			}},
+		}}, []int64{1, 2}},
+		{"not", &types.FilterNode{Not: &types.FilterNode{Or: []types.FilterNode{
+			leaf("status", types.FilterOpIn, "failed,done"),
+		}}}, []int64{2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := crudService.List("jobs", &types.QueryParams{Where: tt.where})
+			if err != nil {
+				t.Fatalf("Failed to list jobs: %v", err)
+			}
+			var ids []int64
+			for _, record := range result.Data {
+				ids = append(ids, record["id"].(int64))
+			}
+			if len(ids) != len(tt.ids) {
+				t.Fatalf("Expected ids %v, got %v", tt.ids, ids)
+			}
+			for i := range ids {
+				if ids[i] != tt.ids[i] {
+					t.Fatalf("Expected ids %v, got %v", tt.ids, ids)
+				}
+			}
+		})
+	}
+
+	// the filter tree is ANDed with the other filters
+	result, err := crudService.List("jobs", &types.QueryParams{
+		Filters: []types.Filter{{Field: "status", Operator: types.FilterOpEq, Value: "pending"}},
+		Where:   &types.FilterNode{Not: &types.FilterNode{Filter: types.Filter{Field: "created_at", Operator: types.FilterOpLt, Value: "2024-01-05"}}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to list jobs: %v", err)
+	}
+	if len(result.Data) != 1 || result.Data[0]["id"].(int64) != 3 {
+		t.Errorf("Expected only job 3, got %v", result.Data)
+	}
+
+	where := &types.FilterNode{Or: []types.FilterNode{leaf("id", types.FilterOpEq, 1)}}
+	if _, err := crudService.List("jobs", &types.QueryParams{Where: where}); !errors.Is(err, generator.ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter for a field that cannot be filtered, got %v", err)
+	}
+}
+
+func TestListFulltextSearch(t *testing.T) {
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:              "articles",
+		DBTableName:       "articles",
+		QueryDefaultSort:  "id",
+		QuerySearchFields: `[{"field": "q", "type": "fulltext", "columns": ["title", "body"], "rank": true}]`,
+	},
+		`CREATE TABLE articles (id INTEGER PRIMARY KEY, title TEXT NOT NULL, body TEXT)`,
+		`INSERT INTO articles (id, title, body) VALUES (1, 'Release notes', 'Bug fixes'), (2, 'Roadmap', NULL), (3, 'Weekly', 'See the RELEASE plan')`,
+	)
+
+	result, err := crudService.List("articles", &types.QueryParams{Search: map[string]interface{}{"q": "release"}})
+	if err != nil {
+		t.Fatalf("Failed to search articles: %v", err)
+	}
+	// SQLite cannot rank matches, the default sort applies
+	if len(result.Data) != 2 || result.Data[0]["id"].(int64) != 1 || result.Data[1]["id"].(int64) != 3 {
+		t.Errorf("Expected articles 1 and 3, got %v", result.Data)
+	}
+
+	result, err = crudService.List("articles", &types.QueryParams{Search: map[string]interface{}{"q": "  "}})
+	if err != nil {
+		t.Fatalf("Failed to list articles: %v", err)
+	}
+	if len(result.Data) != 3 {
+		t.Errorf("Expected an empty search to match all articles, got %d", len(result.Data))
+	}
+}
+
+func TestListProjection(t *testing.T) {
+	const (
+		createUsers = `CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL, email TEXT, password_hash TEXT, role TEXT)`
+		insertUsers = `INSERT INTO users (id, name, email, password_hash, role) VALUES (1, 'ann', 'ann@example.com', 'x1', 'admin'), (2, 'bob', 'bob@example.com', 'x2', 'user'), (3, 'eve', NULL, 'x3', 'user')`
+	)
+	keys := func(record map[string]interface{}) string {
+		var names []string
+		for name := range record {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return strings.Join(names, ",")
+	}
+
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:                "users",
+		DBTableName:         "users",
+		QueryPagination:     true,
+		QueryDisplayFields:  `[{"field": "name"}, {"field": "email"}, {"field": "password_hash"}]`,
+		QueryHiddenFields:   `["password_hash"]`,
+		QueryDefaultSort:    "role,-id",
+		QuerySortableFields: `["name", "password_hash"]`,
+	}, createUsers, insertUsers)
+
+	result, err := crudService.List("users", &types.QueryParams{})
+	if err != nil {
+		t.Fatalf("Failed to list users: %v", err)
+	}
+	if len(result.Data) != 3 || keys(result.Data[0]) != "email,id,name" {
+		t.Errorf("Expected the displayed columns and the primary key without hidden ones, got %v", result.Data)
+	}
+
+	result, err = crudService.List("users", &types.QueryParams{Fields: []string{"name"}})
+	if err != nil {
+		t.Fatalf("Failed to list users: %v", err)
+	}
+	if keys(result.Data[0]) != "id,name" {
+		t.Errorf("Expected fields to narrow the columns to id and name, got %v", result.Data[0])
+	}
+
+	for _, fields := range [][]string{{"password_hash"}, {"role"}} {
+		if _, err := crudService.List("users", &types.QueryParams{Fields: fields}); !errors.Is(err, generator.ErrInvalidField) {
+			t.Errorf("Expected ErrInvalidField for %v, got %v", fields, err)
+		}
+	}
+
+	sorts := []types.SortField{{Field: "password_hash", Order: types.SortOrderASC}}
+	if _, err := crudService.List("users", &types.QueryParams{Sort: sorts}); err == nil {
+		t.Error("Expected an error when sorting by a hidden field")
+	}
+
+	// the cursor needs the default sort column role, which is not returned
+	params := &types.QueryParams{PageSize: 2, CursorMode: true, Fields: []string{"name"}}
+	first, err := crudService.List("users", params)
+	if err != nil {
+		t.Fatalf("Failed to list the first page: %v", err)
+	}
+	params.Cursor = first.NextCursor
+	second, err := crudService.List("users", params)
+	if err != nil {
+		t.Fatalf("Failed to list the second page: %v", err)
+	}
+	if len(first.Data) != 2 || keys(first.Data[0]) != "id,name" || len(second.Data) != 1 || second.Data[0]["id"].(int64) != 2 {
+		t.Errorf("Unexpected cursor pages: %v then %v", first.Data, second.Data)
+	}
+
+	record, err := crudService.Get("users", "1")
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	if _, exists := record["password_hash"]; exists {
+		t.Errorf("Expected the hidden column to be left out of the record, got %v", record)
+	}
+
+	// without display fields every column but the hidden ones is returned
+	crudService = newTestCRUDService(t, models.TableConfiguration{
+		Name:              "users",
+		DBTableName:       "users",
+		CreateStatement:   createUsers,
+		QueryHiddenFields: `["password_hash"]`,
+	}, createUsers, insertUsers)
+
+	result, err = crudService.List("users", &types.QueryParams{})
+	if err != nil {
+		t.Fatalf("Failed to list users: %v", err)
+	}
+	if keys(result.Data[0]) != "email,id,name,role" {
+		t.Errorf("Expected every column but password_hash, got %v", result.Data[0])
+	}
+	if record, err = crudService.Get("users", "1"); err != nil || keys(record) != "email,id,name,role" {
+		t.Errorf("Expected every column but password_hash, got %v (%v)", record, err)
+	}
+}
+
+func TestListReferenceLabels(t *testing.T) {
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:               "orders",
+		DBTableName:        "orders",
+		QueryPagination:    true,
+		QueryDisplayFields: `[{"field": "customer_id", "reference": {"table": "customers", "label_field": "name"}}, {"field": "owner_id", "reference": {"config": "staff", "label_field": "full_name"}}]`,
+	},
+		`CREATE TABLE customers (id INTEGER PRIMARY KEY, name TEXT NOT NULL)`,
+		`INSERT INTO customers (id, name) VALUES (1, 'Acme'), (2, 'Globex')`,
+		`CREATE TABLE staff (code TEXT PRIMARY KEY, full_name TEXT NOT NULL)`,
+		`INSERT INTO staff (code, full_name) VALUES ('s1', 'Ann Lee'), ('s2', 'Bob Ray')`,
+		`INSERT INTO table_configurations (connection_id, name, table_name, create_statement, primary_key, is_active) VALUES ('default', 'staff', 'staff', '', 'code', 1)`,
+		`CREATE TABLE orders (id INTEGER PRIMARY KEY, customer_id INTEGER, owner_id TEXT)`,
+		`INSERT INTO orders (id, customer_id, owner_id) VALUES (1, 1, 's1'), (2, 2, 's2'), (3, 1, NULL), (4, 9, 's1')`,
+	)
+
+	result, err := crudService.List("orders", &types.QueryParams{})
+	if err != nil {
+		t.Fatalf("Failed to list orders: %v", err)
+	}
+	labels := map[int64][2]interface{}{}
+	for _, record := range result.Data {
+		labels[record["id"].(int64)] = [2]interface{}{record["customer_id_label"], record["owner_id_label"]}
+	}
+	expected := map[int64][2]interface{}{
+		1: {"Acme", "Ann Lee"},
+		2: {"Globex", "Bob Ray"},
+		3: {"Acme", nil},
+		4: {nil, "Ann Lee"},
+	}
+	for id, want := range expected {
+		if labels[id] != want {
+			t.Errorf("Expected labels %v for order %d, got %v", want, id, labels[id])
+		}
+	}
+
+	result, err = crudService.List("orders", &types.QueryParams{Search: map[string]interface{}{"customer_id_label": "ac"}})
+	if err != nil {
+		t.Fatalf("Failed to search orders by label: %v", err)
+	}
+	if len(result.Data) != 2 || result.Total != 2 {
+		t.Errorf("Expected the two orders of Acme, got %v", result.Data)
+	}
+
+	result, err = crudService.List("orders", &types.QueryParams{Search: map[string]interface{}{"owner_id_label": "bob"}})
+	if err != nil {
+		t.Fatalf("Failed to search orders by label: %v", err)
+	}
+	if len(result.Data) != 1 || result.Data[0]["id"].(int64) != 2 {
+		t.Errorf("Expected the order owned by Bob, got %v", result.Data)
+	}
+
+	record, err := crudService.Get("orders", "2")
+	if err != nil {
+		t.Fatalf("Failed to get order: %v", err)
+	}
+	if record["customer_id_label"] != "Globex" || record["owner_id_label"] != "Bob Ray" {
+		t.Errorf("Expected labels on the record, got %v", record)
+	}
+}
+
+func TestListChildren(t *testing.T) {
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:           "orders",
+		DBTableName:    "orders",
+		QueryRelations: `[{"name": "items", "config": "order_items", "foreign_key": "order_id"}]`,
+	},
+		`CREATE TABLE orders (id INTEGER PRIMARY KEY, number TEXT NOT NULL)`,
+		`INSERT INTO orders (id, number) VALUES (1, 'A-1'), (2, 'A-2')`,
+		`CREATE TABLE order_items (id INTEGER PRIMARY KEY, order_id INTEGER NOT NULL, product TEXT NOT NULL, quantity INTEGER NOT NULL)`,
+		`INSERT INTO order_items (id, order_id, product, quantity) VALUES (1, 1, 'pen', 3), (2, 1, 'ink', 1), (3, 2, 'pen', 5), (4, 1, 'paper', 2)`,
+		`INSERT INTO table_configurations (connection_id, name, table_name, create_statement, query_pagination, query_search_fields, query_sortable_fields, query_default_sort, is_active)
+			VALUES ('default', 'order_items', 'order_items', '', 1, '[{"field": "product", "type": "fuzzy"}]', '["quantity"]', '-quantity', 1)`,
+	)
+
+	products := func(result *types.QueryResult) string {
+		var names []string
+		for _, record := range result.Data {
+			names = append(names, record["product"].(string))
+		}
+		return strings.Join(names, ",")
+	}
+
+	result, err := crudService.ListChildren("orders", "1", "items", &types.QueryParams{Page: 1, PageSize: 2})
+	if err != nil {
+		t.Fatalf("Failed to list items: %v", err)
+	}
+	if products(result) != "pen,paper" || result.Total != 3 {
+		t.Errorf("Expected the first page of the items of order 1 by the default sort, got %s of %d", products(result), result.Total)
+	}
+
+	result, err = crudService.ListChildren("orders", "1", "items", &types.QueryParams{
+		Search: map[string]interface{}{"product": "pe"},
+		Sort:   []types.SortField{{Field: "quantity", Order: types.SortOrderASC}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to search items: %v", err)
+	}
+	if products(result) != "paper,pen" {
+		t.Errorf("Expected the child search and sort to apply, got %s", products(result))
+	}
+
+	if _, err := crudService.ListChildren("orders", "1", "payments", &types.QueryParams{}); !errors.Is(err, services.ErrRelationNotFound) {
+		t.Errorf("Expected ErrRelationNotFound, got %v", err)
+	}
+	if _, err := crudService.ListChildren("orders", "9", "items", &types.QueryParams{}); !errors.Is(err, generator.ErrRecordNotFound) {
+		t.Errorf("Expected ErrRecordNotFound for a missing parent, got %v", err)
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:              "orders",
+		DBTableName:       "orders",
+		QuerySearchFields: `[{"field": "region", "type": "exact"}]`,
+		QueryFilterFields: `[{"field": "amount", "operators": ["gte"]}]`,
+		QueryHiddenFields: `["cost"]`,
+		QueryAggregate:    `{"group_by": ["status", "region"], "metrics": [{"field": "amount", "functions": ["sum", "avg", "max"]}, {"field": "cost", "functions": ["sum"]}]}`,
+	},
+		`CREATE TABLE orders (id INTEGER PRIMARY KEY, status TEXT NOT NULL, region TEXT NOT NULL, amount INTEGER NOT NULL, cost INTEGER)`,
+		`INSERT INTO orders (id, status, region, amount, cost) VALUES (1, 'paid', 'eu', 30, 1), (2, 'paid', 'us', 10, 1), (3, 'open', 'eu', 20, 1), (4, 'paid', 'eu', 40, 1)`,
+	)
+
+	result, err := crudService.Aggregate("orders", &types.AggregateParams{})
+	if err != nil {
+		t.Fatalf("Failed to count orders: %v", err)
+	}
+	if len(result.Rows) != 1 || result.Rows[0]["count"].(int64) != 4 {
+		t.Errorf("Expected a single row counting 4 orders, got %v", result.Rows)
+	}
+
+	result, err = crudService.Aggregate("orders", &types.AggregateParams{
+		Search:  map[string]interface{}{"region": "eu"},
+		GroupBy: []string{"status"},
+		Metrics: generator.ParseMetrics("count,sum:amount,max:amount"),
+	})
+	if err != nil {
+		t.Fatalf("Failed to aggregate orders: %v", err)
+	}
+	if strings.Join(result.Metrics, ",") != "count,sum_amount,max_amount" || len(result.Rows) != 2 {
+		t.Fatalf("Unexpected aggregate result: %v %v", result.Metrics, result.Rows)
+	}
+	open, paid := result.Rows[0], result.Rows[1]
+	if open["status"] != "open" || open["count"].(int64) != 1 || paid["status"] != "paid" || paid["sum_amount"].(int64) != 70 || paid["max_amount"].(int64) != 40 {
+		t.Errorf("Expected open and paid groups of the eu orders, got %v", result.Rows)
+	}
+
+	filters := []types.Filter{{Field: "amount", Operator: types.FilterOpGte, Value: 20}}
+	result, err = crudService.Aggregate("orders", &types.AggregateParams{Filters: filters, Metrics: []types.Metric{{Function: types.AggregateAvg, Field: "amount"}}})
+	if err != nil {
+		t.Fatalf("Failed to aggregate filtered orders: %v", err)
+	}
+	if result.Rows[0]["avg_amount"].(float64) != 30 {
+		t.Errorf("Expected the average of the filtered orders, got %v", result.Rows)
+	}
+
+	for _, params := range []*types.AggregateParams{
+		{GroupBy: []string{"amount"}},
+		{Metrics: []types.Metric{{Function: types.AggregateMin, Field: "amount"}}},
+		{Metrics: []types.Metric{{Function: types.AggregateSum, Field: "cost"}}},
+		{Metrics: []types.Metric{{Function: "median", Field: "amount"}}},
+	} {
+		if _, err := crudService.Aggregate("orders", params); !errors.Is(err, generator.ErrInvalidAggregate) {
+			t.Errorf("Expected ErrInvalidAggregate for %+v, got %v", params, err)
+		}
+	}
+}
+
+func TestJSONPathFields(t *testing.T) {
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:                  "products",
+		DBTableName:           "products",
+		QueryDisplayFields:    `[{"field": "name"}, {"field": "attrs.color"}, {"field": "specs"}]`,
+		QuerySearchFields:     `[{"field": "attrs.color", "type": "fuzzy"}, {"field": "attrs->tags", "type": "exact"}]`,
+		QuerySortableFields:   `["attrs.color"]`,
+		CreateCreatableFields: `[{"field": "id"}, {"field": "name"}, {"field": "attrs", "type": "json"}]`,
+		UpdateUpdatableFields: `[{"field": "attrs", "type": "json"}]`,
+	},
+		`CREATE TABLE products (id INTEGER PRIMARY KEY, name TEXT NOT NULL, attrs JSON, specs JSON)`,
+		`INSERT INTO products (id, name, attrs, specs) VALUES (1, 'shirt', '{"color": "red", "tags": ["sale", "new"]}', '{"size": "M"}'), (2, 'hat', '{"color": "dark red", "tags": "sale"}', NULL), (3, 'scarf', '{"color": "blue"}', NULL)`,
+	)
+
+	names := func(result *types.QueryResult) string {
+		var names []string
+		for _, record := range result.Data {
+			names = append(names, record["name"].(string))
+		}
+		return strings.Join(names, ",")
+	}
+
+	result, err := crudService.List("products", &types.QueryParams{Search: map[string]interface{}{"attrs.color": "red"}})
+	if err != nil {
+		t.Fatalf("Failed to search the JSON path: %v", err)
+	}
+	if names(result) != "shirt,hat" || result.Data[0]["attrs.color"] != "red" {
+		t.Errorf("Expected the records with a red color and the color as a column, got %v", result.Data)
+	}
+	if _, exists := result.Data[0]["attrs"]; exists {
+		t.Errorf("Expected only the JSON path to be selected, got %v", result.Data[0])
+	}
+	// specs 不可创建也不可更新，按列类型同样解码为对象
+	if specs, ok := result.Data[0]["specs"].(map[string]interface{}); !ok || specs["size"] != "M" {
+		t.Errorf("Expected the read-only JSON column as an object, got %#v", result.Data[0]["specs"])
+	}
+
+	// exact search matches the value itself and arrays holding it
+	result, err = crudService.List("products", &types.QueryParams{Search: map[string]interface{}{"attrs->tags": "sale"}})
+	if err != nil {
+		t.Fatalf("Failed to search the JSON array: %v", err)
+	}
+	if names(result) != "shirt,hat" {
+		t.Errorf("Expected the records tagged sale, got %v", result.Data)
+	}
+
+	result, err = crudService.List("products", &types.QueryParams{Sort: generator.ParseSort("attrs.color")})
+	if err != nil {
+		t.Fatalf("Failed to sort by the JSON path: %v", err)
+	}
+	if names(result) != "scarf,hat,shirt" {
+		t.Errorf("Expected the records sorted by color, got %v", result.Data)
+	}
+
+	created, err := crudService.Create("products", map[string]interface{}{
+		"id":    4,
+		"name":  "sock",
+		"attrs": map[string]interface{}{"color": "green", "tags": []interface{}{"new"}},
+	})
+	if err != nil || !created.Success {
+		t.Fatalf("Failed to create a record with a JSON object: %v %v", err, created)
+	}
+	record, err := crudService.Get("products", created.ID)
+	if err != nil {
+		t.Fatalf("Failed to get the record: %v", err)
+	}
+	attrs, ok := record["attrs"].(map[string]interface{})
+	if !ok || attrs["color"] != "green" {
+		t.Errorf("Expected the JSON column as an object, got %#v", record["attrs"])
+	}
+
+	record, err = crudService.Get("products", 1)
+	if err != nil {
+		t.Fatalf("Failed to get the record: %v", err)
+	}
+	if specs, ok := record["specs"].(map[string]interface{}); !ok || specs["size"] != "M" {
+		t.Errorf("Expected the read-only JSON column as an object, got %#v", record["specs"])
+	}
+
+	updated, err := crudService.Update("products", created.ID, map[string]interface{}{"attrs": "{not json"})
+	if err != nil || updated.Success || len(updated.Errors) != 1 || updated.Errors[0].Tag != "json" {
+		t.Errorf("Expected invalid JSON text to be rejected, got %v %v", err, updated)
+	}
+}
+
+func TestArrayFields(t *testing.T) {
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:                  "posts",
+		DBTableName:           "posts",
+		QuerySearchFields:     `[{"field": "tags", "type": "contains"}, {"field": "labels", "type": "overlaps"}]`,
+		QuerySortableFields:   `["id"]`,
+		CreateCreatableFields: `[{"field": "id"}, {"field": "tags", "type": "tags"}, {"field": "labels", "type": "tags"}]`,
+	},
+		`CREATE TABLE posts (id INTEGER PRIMARY KEY, tags JSON, labels JSON, scores JSON)`,
+		`INSERT INTO posts (id, tags, labels, scores) VALUES (1, '["go", "db"]', '["a"]', '[3, 5]'), (2, '["go"]', '["b", "c"]', NULL), (3, '[]', NULL, NULL)`,
+	)
+
+	ids := func(search map[string]interface{}) string {
+		t.Helper()
+		result, err := crudService.List("posts", &types.QueryParams{Search: search, Sort: generator.ParseSort("id")})
+		if err != nil {
+			t.Fatalf("Failed to search %v: %v", search, err)
+		}
+		var ids []string
+		for _, record := range result.Data {
+			ids = append(ids, fmt.Sprint(record["id"]))
+		}
+		return strings.Join(ids, ",")
+	}
+
+	if got := ids(map[string]interface{}{"tags": `["go", "db"]`}); got != "1" {
+		t.Errorf("Expected the posts tagged go and db, got %s", got)
+	}
+	if got := ids(map[string]interface{}{"tags": "go"}); got != "1,2" {
+		t.Errorf("Expected the posts tagged go, got %s", got)
+	}
+	if got := ids(map[string]interface{}{"labels": "a,c"}); got != "1,2" {
+		t.Errorf("Expected the posts labelled a or c, got %s", got)
+	}
+
+	created, err := crudService.Create("posts", map[string]interface{}{"id": 4, "tags": []interface{}{"rust", "db"}, "labels": "x, y"})
+	if err != nil || !created.Success {
+		t.Fatalf("Failed to create a record with arrays: %v %v", err, created)
+	}
+	record, err := crudService.Get("posts", 4)
+	if err != nil {
+		t.Fatalf("Failed to get the record: %v", err)
+	}
+	tags, ok := record["tags"].([]interface{})
+	labels, _ := record["labels"].([]interface{})
+	if !ok || len(tags) != 2 || tags[0] != "rust" || len(labels) != 2 || labels[1] != "y" {
+		t.Errorf("Expected the arrays as lists, got %#v %#v", record["tags"], record["labels"])
+	}
+	// scores 不可创建也不可更新，按列类型同样返回列表
+	record, err = crudService.Get("posts", 1)
+	if err != nil {
+		t.Fatalf("Failed to get the record: %v", err)
+	}
+	if scores, ok := record["scores"].([]interface{}); !ok || len(scores) != 2 || scores[1] != float64(5) {
+		t.Errorf("Expected the read-only array as a list, got %#v", record["scores"])
+	}
+}
+
+func TestSavedViews(t *testing.T) {
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:              "orders",
+		DBTableName:       "orders",
+		QuerySearchFields: `[{"field": "status", "type": "exact"}]`,
+		QueryFilterFields: `[{"field": "amount", "operators": ["gte", "lte"]}]`,
+		QueryHiddenFields: `["secret"]`,
+	},
+		`CREATE TABLE orders (id INTEGER PRIMARY KEY, status TEXT, amount INTEGER, secret TEXT)`,
+	)
+
+	daily := &types.SavedView{
+		Name:     "daily",
+		Search:   map[string]interface{}{"status": "paid"},
+		Where:    &types.FilterNode{Filter: types.Filter{Field: "amount", Operator: types.FilterOpGte, Value: float64(100)}},
+		Sort:     "-amount",
+		Fields:   []string{"status", "amount"},
+		PageSize: 50,
+	}
+	created, err := crudService.CreateView("orders", "alice", daily)
+	if err != nil {
+		t.Fatalf("Failed to create view: %v", err)
+	}
+	if created.Owner != "alice" || created.Shared || created.PageSize != 50 || created.Where == nil || created.Where.Field != "amount" {
+		t.Errorf("Unexpected view %+v", created)
+	}
+	if _, err := crudService.CreateView("orders", "alice", daily); !errors.Is(err, services.ErrViewExists) {
+		t.Errorf("Expected ErrViewExists for a duplicate name, got %v", err)
+	}
+	if _, err := crudService.CreateView("orders", "bob", &types.SavedView{Name: "daily", Shared: true, Sort: "status"}); err != nil {
+		t.Fatalf("Failed to create a shared view: %v", err)
+	}
+	if _, err := crudService.CreateView("orders", "bob", &types.SavedView{Name: "leak", Fields: []string{"secret"}}); err == nil {
+		t.Errorf("Expected an error for a hidden field")
+	}
+	// 搜索和组合过滤条件与列表接口一样受配置限制
+	if _, err := crudService.CreateView("orders", "bob", &types.SavedView{Name: "probe", Search: map[string]interface{}{"secret": "x"}}); !errors.Is(err, generator.ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter for a field that is not searchable, got %v", err)
+	}
+	probe := &types.SavedView{Name: "probe", Where: &types.FilterNode{Filter: types.Filter{Field: "secret", Operator: types.FilterOpEq, Value: "x"}}}
+	if _, err := crudService.CreateView("orders", "bob", probe); !errors.Is(err, generator.ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter for a field that cannot be filtered, got %v", err)
+	}
+	probe.Where = &types.FilterNode{Filter: types.Filter{Field: "amount", Operator: types.FilterOpEq, Value: float64(1)}}
+	if _, err := crudService.CreateView("orders", "bob", probe); !errors.Is(err, generator.ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter for an operator that is not allowed, got %v", err)
+	}
+
+	names := func(owner string) string {
+		t.Helper()
+		views, err := crudService.ListViews("orders", owner)
+		if err != nil {
+			t.Fatalf("Failed to list views: %v", err)
+		}
+		var names []string
+		for _, view := range views {
+			names = append(names, view.Name+"/"+view.Owner)
+		}
+		return strings.Join(names, ",")
+	}
+	if got := names("alice"); got != "daily/alice" {
+		t.Errorf("Expected alice's view to shadow bob's shared view, got %s", got)
+	}
+	if got := names("carol"); got != "daily/bob" {
+		t.Errorf("Expected only the shared view, got %s", got)
+	}
+
+	// 自己的视图优先于其他人共享的同名视图
+	view, err := crudService.GetView("orders", "daily", "alice")
+	if err != nil || view.Owner != "alice" || view.Search["status"] != "paid" {
+		t.Errorf("Expected alice's own view, got %+v %v", view, err)
+	}
+	view, err = crudService.GetView("orders", "daily", "carol")
+	if err != nil || view.Owner != "bob" {
+		t.Errorf("Expected bob's shared view, got %+v %v", view, err)
+	}
+
+	if _, err := crudService.UpdateView("orders", "daily", "carol", &types.SavedView{Name: "daily"}); !errors.Is(err, services.ErrViewNotOwned) {
+		t.Errorf("Expected ErrViewNotOwned when changing another user's view, got %v", err)
+	}
+	updated, err := crudService.UpdateView("orders", "daily", "alice", &types.SavedView{Name: "morning", Shared: true, Sort: "status"})
+	if err != nil || updated.Name != "morning" || !updated.Shared || updated.Where != nil {
+		t.Errorf("Expected the renamed shared view, got %+v %v", updated, err)
+	}
+
+	if err := crudService.DeleteView("orders", "morning", "alice"); err != nil {
+		t.Fatalf("Failed to delete view: %v", err)
+	}
+	if _, err := crudService.GetView("orders", "morning", "alice"); !errors.Is(err, services.ErrViewNotFound) {
+		t.Errorf("Expected ErrViewNotFound after delete, got %v", err)
+	}
+
+	// 私有视图对其他用户和匿名请求不可见
+	if _, err := crudService.CreateView("orders", "alice", &types.SavedView{Name: "mine", Sort: "status"}); err != nil {
+		t.Fatalf("Failed to create a private view: %v", err)
+	}
+	for _, owner := range []string{"bob", ""} {
+		if got := names(owner); strings.Contains(got, "mine") {
+			t.Errorf("Expected alice's private view to be hidden from '%s', got %s", owner, got)
+		}
+		if _, err := crudService.GetView("orders", "mine", owner); !errors.Is(err, services.ErrViewNotFound) {
+			t.Errorf("Expected ErrViewNotFound reading alice's private view as '%s', got %v", owner, err)
+		}
+		if _, err := crudService.UpdateView("orders", "mine", owner, &types.SavedView{Name: "mine", Shared: true}); !errors.Is(err, services.ErrViewNotFound) {
+			t.Errorf("Expected ErrViewNotFound changing alice's private view as '%s', got %v", owner, err)
+		}
+		if err := crudService.DeleteView("orders", "mine", owner); !errors.Is(err, services.ErrViewNotFound) {
+			t.Errorf("Expected ErrViewNotFound deleting alice's private view as '%s', got %v", owner, err)
+		}
+	}
+	if _, err := crudService.GetView("orders", "mine", "alice"); err != nil {
+		t.Errorf("Expected alice's private view to remain, got %v", err)
+	}
+
+	// 无法识别用户时只能保存共享视图
+	if _, err := crudService.CreateView("orders", "", &types.SavedView{Name: "anonymous"}); !errors.Is(err, services.ErrViewOwnerRequired) {
+		t.Errorf("Expected ErrViewOwnerRequired for an anonymous private view, got %v", err)
+	}
+	if _, err := crudService.CreateView("orders", "", &types.SavedView{Name: "anonymous", Shared: true}); err != nil {
+		t.Errorf("Failed to create an anonymous shared view: %v", err)
+	}
+	if _, err := crudService.UpdateView("orders", "anonymous", "", &types.SavedView{Name: "anonymous"}); !errors.Is(err, services.ErrViewOwnerRequired) {
+		t.Errorf("Expected ErrViewOwnerRequired making an anonymous view private, got %v", err)
+	}
+
+	// 匿名请求不能修改或删除视图，包括其他匿名请求共享的视图
+	if _, err := crudService.UpdateView("orders", "anonymous", "", &types.SavedView{Name: "anonymous", Shared: true, Sort: "-amount"}); !errors.Is(err, services.ErrViewOwnerRequired) {
+		t.Errorf("Expected ErrViewOwnerRequired changing a view anonymously, got %v", err)
+	}
+	if err := crudService.DeleteView("orders", "anonymous", ""); !errors.Is(err, services.ErrViewOwnerRequired) {
+		t.Errorf("Expected ErrViewOwnerRequired deleting a view anonymously, got %v", err)
+	}
+	if _, err := crudService.GetView("orders", "anonymous", ""); err != nil {
+		t.Errorf("Expected the anonymous shared view to remain, got %v", err)
+	}
+}
+
+func TestFacets(t *testing.T) {
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:        "orders",
+		DBTableName: "orders",
+		QuerySearchFields: `[{"field": "status", "type": "single", "dict_source": "paid\npending\nrefunded"},
+			{"field": "region", "type": "multi_select", "dict_source": "region"},
+			{"field": "amount", "type": "range"},
+			{"field": "secret", "type": "single", "dict_source": "secret"}]`,
+		QueryHiddenFields: `["secret"]`,
+	},
+		`CREATE TABLE orders (id INTEGER PRIMARY KEY, status TEXT, region TEXT, amount INTEGER, secret TEXT)`,
+		`INSERT INTO orders (id, status, region, amount, secret) VALUES
+			(1, 'paid', 'east', 10, 'x'), (2, 'paid', 'west', 20, 'x'), (3, 'pending', 'east', 30, 'y'),
+			(4, 'paid', 'east', 40, 'y'), (5, 'pending', NULL, 50, 'z')`,
+	)
+
+	counts := func(result *types.FacetResult) map[string]string {
+		formatted := make(map[string]string)
+		for _, facet := range result.Facets {
+			var values []string
+			for _, value := range facet.Values {
+				values = append(values, fmt.Sprintf("%s=%d", value.Value, value.Count))
+			}
+			formatted[facet.Field] = strings.Join(values, ",")
+		}
+		return formatted
+	}
+
+	result, err := crudService.Facets("orders", &types.FacetParams{
+		Search: map[string]interface{}{"status": "paid", "amount": `{"min": 15}`},
+	})
+	if err != nil {
+		t.Fatalf("Failed to count facets: %v", err)
+	}
+	if len(result.Facets) != 2 {
+		t.Fatalf("Expected the status and region facets, got %+v", result.Facets)
+	}
+	got := counts(result)
+	// status 的统计不应用自身的搜索条件
+	if got["status"] != "paid=2,pending=2,refunded=0" {
+		t.Errorf("Unexpected status facet %s", got["status"])
+	}
+	if got["region"] != "east=1,west=1" {
+		t.Errorf("Unexpected region facet %s", got["region"])
+	}
+
+	result, err = crudService.Facets("orders", &types.FacetParams{
+		Search: map[string]interface{}{"region": `["east"]`},
+		Fields: []string{"status"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to count facets: %v", err)
+	}
+	if got := counts(result); len(got) != 1 || got["status"] != "paid=2,pending=1,refunded=0" {
+		t.Errorf("Expected only the status facet of the east region, got %v", got)
+	}
+}
+
+// 主键按配置版本缓存：配置未经更新时沿用缓存，通过 UpdateConfig 更新后重新解析
+func TestPrimaryKeyCache(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get sql.DB: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := db.AutoMigrate(&models.TableConfiguration{}); err != nil {
+		t.Fatalf("Failed to migrate configurations: %v", err)
+	}
+	statements := []string{
+		`CREATE TABLE tickets (id INTEGER NOT NULL, code TEXT PRIMARY KEY)`,
+		`INSERT INTO tickets (id, code) VALUES (1, 'T-1'), (2, 'T-2')`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("Failed to execute '%s': %v", statement, err)
+		}
+	}
+
+	config := models.TableConfiguration{
+		Name:            "tickets",
+		ConnectionID:    "pk_cache",
+		DBTableName:     "tickets",
+		CreateStatement: statements[0],
+		IsActive:        true,
+	}
+	if err := db.Create(&config).Error; err != nil {
+		t.Fatalf("Failed to create configuration: %v", err)
+	}
+
+	dbManager := database.GetDatabaseManager()
+	dbManager.SetExistingConnection("pk_cache", db, &models.DatabaseConfig{Name: "pk_cache", DbType: "sqlite", DatabaseName: ":memory:"})
+	t.Cleanup(func() { dbManager.RemoveConnection("pk_cache") })
+
+	configService := services.NewConfigServiceWithDB(db, dbManager)
+	crudService := services.NewCRUDServiceWithDB(db, configService)
+
+	if record, err := crudService.Get("tickets", "T-2"); err != nil || record["id"] != int64(2) {
+		t.Fatalf("Expected ticket T-2, got %v (%v)", record, err)
+	}
+
+	// 绕过 UpdateConfig 修改建表语句，版本号不变，仍按缓存的主键 code 定位
+	statement := `CREATE TABLE tickets (id INTEGER PRIMARY KEY, code TEXT)`
+	if err := db.Model(&models.TableConfiguration{}).Where("id = ?", config.ID).Update("create_statement", statement).Error; err != nil {
+		t.Fatalf("Failed to change create statement: %v", err)
+	}
+	if _, err := crudService.Get("tickets", "T-1"); err != nil {
+		t.Fatalf("Expected the cached primary key to be used: %v", err)
+	}
+
+	updated, err := configService.GetConfigByID(config.ID)
+	if err != nil {
+		t.Fatalf("Failed to get configuration: %v", err)
+	}
+	if err := configService.UpdateConfig(config.ID, updated); err != nil {
+		t.Fatalf("Failed to update configuration: %v", err)
+	}
+	if record, err := crudService.Get("tickets", "1"); err != nil || record["code"] != "T-1" {
+		t.Fatalf("Expected ticket 1 after the primary key changed, got %v (%v)", record, err)
+	}
+	if _, err := crudService.Get("tickets", "T-1"); !errors.Is(err, generator.ErrRecordNotFound) {
+		t.Errorf("Expected the old primary key to be dropped, got %v", err)
+	}
+}
@@ -0,0 +1,615 @@
+package builder_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/otkinlife/crud-generator/dialect"
+	"github.com/otkinlife/crud-generator/generator"
+	"github.com/otkinlife/crud-generator/types"
+)
+
+func TestGeneratorDialects(t *testing.T) {
+	schema := &types.TableSchema{
+		TableName: "users",
+		Fields: []types.TableField{
+			{Name: "id", Type: types.PostgreSQLTypeInteger, PrimaryKey: true},
+			{Name: "name", Type: types.PostgreSQLTypeVarchar},
+		},
+	}
+	config := &types.Config{
+		TableName: "users",
+		QueryConfig: &types.QueryConfig{
+			Pagination:   true,
+			SearchFields: []types.SearchField{{Field: "name", Type: types.SearchTypeFuzzy}},
+		},
+	}
+
+	tests := []struct {
+		dbType     string
+		insert     string
+		update     string
+		query      string
+		countQuery string
+	}{
+		{
+			dbType:     "postgres",
+			insert:     `INSERT INTO "users" ("name") VALUES ($1) RETURNING "id"`,
+			update:     `UPDATE "users" SET "name" = $1 WHERE "id" = $2`,
+			query:      `SELECT * FROM "users" WHERE "name" ILIKE $1 LIMIT 10 OFFSET 10`,
+			countQuery: `SELECT COUNT(*) FROM "users" WHERE "name" ILIKE $1`,
+		},
+		{
+			dbType:     "mysql",
+			insert:     "INSERT INTO `users` (`name`) VALUES (?)",
+			update:     "UPDATE `users` SET `name` = ? WHERE `id` = ?",
+			query:      "SELECT * FROM `users` WHERE `name` LIKE ? LIMIT 10 OFFSET 10",
+			countQuery: "SELECT COUNT(*) FROM `users` WHERE `name` LIKE ?",
+		},
+		{
+			dbType:     "sqlite",
+			insert:     `INSERT INTO "users" ("name") VALUES (?) RETURNING "id"`,
+			update:     `UPDATE "users" SET "name" = ? WHERE "id" = ?`,
+			query:      `SELECT * FROM "users" WHERE "name" LIKE ? LIMIT 10 OFFSET 10`,
+			countQuery: `SELECT COUNT(*) FROM "users" WHERE "name" LIKE ?`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dbType, func(t *testing.T) {
+			d, err := dialect.New(tt.dbType)
+			if err != nil {
+				t.Fatalf("Failed to create dialect: %v", err)
+			}
+
+			crudGen := generator.NewCRUDGenerator(schema, config, d)
+			insert, _, err := crudGen.GenerateInsert(map[string]interface{}{"name": "ann"})
+			if err != nil {
+				t.Fatalf("Failed to generate insert: %v", err)
+			}
+			if insert != tt.insert {
+				t.Errorf("Expected insert %q, got %q", tt.insert, insert)
+			}
+
+			update, _, err := crudGen.GenerateUpdate(1, map[string]interface{}{"name": "ann"})
+			if err != nil {
+				t.Fatalf("Failed to generate update: %v", err)
+			}
+			if update != tt.update {
+				t.Errorf("Expected update %q, got %q", tt.update, update)
+			}
+
+			queryGen := generator.NewQueryGenerator(schema, config, d)
+			query, countQuery, _, err := queryGen.GenerateQuery(types.QueryParams{
+				Page:     2,
+				PageSize: 10,
+				Search:   map[string]interface{}{"name": "an"},
+			})
+			if err != nil {
+				t.Fatalf("Failed to generate query: %v", err)
+			}
+			if query != tt.query {
+				t.Errorf("Expected query %q, got %q", tt.query, query)
+			}
+			if countQuery != tt.countQuery {
+				t.Errorf("Expected count query %q, got %q", tt.countQuery, countQuery)
+			}
+		})
+	}
+}
+
+func TestCompositeRecordKey(t *testing.T) {
+	schema := &types.TableSchema{
+		TableName:  "order_items",
+		PrimaryKey: []string{"order_no", "line"},
+		Fields: []types.TableField{
+			{Name: "order_no", Type: types.PostgreSQLTypeVarchar, PrimaryKey: true},
+			{Name: "line", Type: types.PostgreSQLTypeInteger, PrimaryKey: true},
+			{Name: "quantity", Type: types.PostgreSQLTypeInteger},
+		},
+	}
+	config := &types.Config{TableName: "order_items"}
+
+	d, err := dialect.New("postgres")
+	if err != nil {
+		t.Fatalf("Failed to create dialect: %v", err)
+	}
+	crudGen := generator.NewCRUDGenerator(schema, config, d)
+
+	segment := generator.EncodeRecordKey("A,1/2", 3)
+	update, args, err := crudGen.GenerateUpdate(segment, map[string]interface{}{"quantity": 5, "line": 9})
+	if err != nil {
+		t.Fatalf("Failed to generate update: %v", err)
+	}
+	expectedUpdate := `UPDATE "order_items" SET "quantity" = $1 WHERE "order_no" = $2 AND "line" = $3`
+	if update != expectedUpdate {
+		t.Errorf("Expected update %q, got %q", expectedUpdate, update)
+	}
+	if len(args) != 3 || args[1] != "A,1/2" || args[2] != int64(3) {
+		t.Errorf("Unexpected update args: %v", args)
+	}
+
+	deleteQuery, args, err := crudGen.GenerateDelete(map[string]interface{}{"order_no": "B", "line": 1})
+	if err != nil {
+		t.Fatalf("Failed to generate delete: %v", err)
+	}
+	expectedDelete := `DELETE FROM "order_items" WHERE "order_no" = $1 AND "line" = $2`
+	if deleteQuery != expectedDelete {
+		t.Errorf("Expected delete %q, got %q", expectedDelete, deleteQuery)
+	}
+	if len(args) != 2 || args[0] != "B" || args[1] != 1 {
+		t.Errorf("Unexpected delete args: %v", args)
+	}
+
+	if _, _, err := crudGen.GenerateDelete(7); !errors.Is(err, generator.ErrInvalidRecordKey) {
+		t.Errorf("Expected ErrInvalidRecordKey for a single value, got %v", err)
+	}
+	if _, _, err := crudGen.GenerateDelete("B"); !errors.Is(err, generator.ErrInvalidRecordKey) {
+		t.Errorf("Expected ErrInvalidRecordKey for an incomplete segment, got %v", err)
+	}
+
+	// 配置中的主键优先于表结构
+	config.PrimaryKey = []string{"order_no"}
+	deleteQuery, _, err = crudGen.GenerateDelete("B")
+	if err != nil {
+		t.Fatalf("Failed to generate delete: %v", err)
+	}
+	if expected := `DELETE FROM "order_items" WHERE "order_no" = $1`; deleteQuery != expected {
+		t.Errorf("Expected delete %q, got %q", expected, deleteQuery)
+	}
+}
+
+func TestGenerateSelect(t *testing.T) {
+	schema := &types.TableSchema{
+		TableName:  "users",
+		PrimaryKey: []string{"id"},
+		Fields: []types.TableField{
+			{Name: "id", Type: types.PostgreSQLTypeInteger, PrimaryKey: true},
+			{Name: "name", Type: types.PostgreSQLTypeVarchar},
+			{Name: "email", Type: types.PostgreSQLTypeVarchar},
+			{Name: "password_hash", Type: types.PostgreSQLTypeVarchar},
+		},
+	}
+	config := &types.Config{TableName: "users"}
+
+	d, err := dialect.New("mysql")
+	if err != nil {
+		t.Fatalf("Failed to create dialect: %v", err)
+	}
+	crudGen := generator.NewCRUDGenerator(schema, config, d)
+
+	query, args, err := crudGen.GenerateSelect("42")
+	if err != nil {
+		t.Fatalf("Failed to generate select: %v", err)
+	}
+	if expected := "SELECT * FROM `users` WHERE `id` = ?"; query != expected {
+		t.Errorf("Expected select %q, got %q", expected, query)
+	}
+	if len(args) != 1 || args[0] != int64(42) {
+		t.Errorf("Unexpected select args: %v", args)
+	}
+
+	// 配置了展示字段时只读取展示字段、主键和可更新字段
+	config.QueryConfig = &types.QueryConfig{DisplayFields: []types.DisplayField{{Field: "name"}}}
+	config.UpdateConfig = &types.UpdateConfig{UpdatableFields: []types.UpdatableField{{Field: "email"}, {Field: "name"}}}
+	query, _, err = crudGen.GenerateSelect(42)
+	if err != nil {
+		t.Fatalf("Failed to generate select: %v", err)
+	}
+	if expected := "SELECT `name`, `id`, `email` FROM `users` WHERE `id` = ?"; query != expected {
+		t.Errorf("Expected select %q, got %q", expected, query)
+	}
+
+	// 隐藏字段不会被读取，未配置展示字段时读取其余全部列
+	config.QueryConfig = &types.QueryConfig{HiddenFields: []string{"password_hash"}}
+	query, _, err = crudGen.GenerateSelect(42)
+	if err != nil {
+		t.Fatalf("Failed to generate select: %v", err)
+	}
+	if expected := "SELECT `id`, `name`, `email` FROM `users` WHERE `id` = ?"; query != expected {
+		t.Errorf("Expected select %q, got %q", expected, query)
+	}
+}
+
+func TestGenerateQueryProjection(t *testing.T) {
+	schema := &types.TableSchema{
+		TableName:  "users",
+		PrimaryKey: []string{"id"},
+		Fields: []types.TableField{
+			{Name: "id", Type: types.PostgreSQLTypeInteger, PrimaryKey: true},
+			{Name: "name", Type: types.PostgreSQLTypeVarchar},
+			{Name: "email", Type: types.PostgreSQLTypeVarchar},
+			{Name: "password_hash", Type: types.PostgreSQLTypeVarchar},
+		},
+	}
+	config := &types.Config{
+		TableName: "users",
+		QueryConfig: &types.QueryConfig{
+			DisplayFields: []types.DisplayField{{Field: "name"}, {Field: "email"}, {Field: "password_hash"}},
+			HiddenFields:  []string{"password_hash"},
+		},
+	}
+
+	d, err := dialect.New("postgres")
+	if err != nil {
+		t.Fatalf("Failed to create dialect: %v", err)
+	}
+	queryGen := generator.NewQueryGenerator(schema, config, d)
+
+	tests := []struct {
+		fields []string
+		query  string
+	}{
+		{nil, `SELECT "name", "email", "id" FROM "users"`},
+		{[]string{"email"}, `SELECT "email", "id" FROM "users"`},
+	}
+	for _, tt := range tests {
+		query, countQuery, _, err := queryGen.GenerateQuery(types.QueryParams{Fields: tt.fields})
+		if err != nil {
+			t.Fatalf("Failed to generate query for %v: %v", tt.fields, err)
+		}
+		if query != tt.query {
+			t.Errorf("Expected query %q, got %q", tt.query, query)
+		}
+		if expected := `SELECT COUNT(*) FROM "users"`; countQuery != expected {
+			t.Errorf("Expected count query %q, got %q", expected, countQuery)
+		}
+	}
+
+	if _, _, _, err := queryGen.GenerateQuery(types.QueryParams{Fields: []string{"password_hash"}}); !errors.Is(err, generator.ErrInvalidField) {
+		t.Errorf("Expected ErrInvalidField for a hidden field, got %v", err)
+	}
+}
+
+// PostgreSQL 数组列按元素类型解码，只读列同样适用
+func TestDecodeArray(t *testing.T) {
+	d, err := dialect.New("postgres")
+	if err != nil {
+		t.Fatalf("Failed to create dialect: %v", err)
+	}
+
+	tests := []struct {
+		value       string
+		elementType types.PostgreSQLType
+		expected    []interface{}
+	}{
+		{`{1,2,NULL}`, types.PostgreSQLTypeInteger, []interface{}{int64(1), int64(2), nil}},
+		{`{1.5,2}`, types.PostgreSQLTypeNumeric, []interface{}{1.5, float64(2)}},
+		{`{t,f}`, types.PostgreSQLTypeBoolean, []interface{}{true, false}},
+		{`{"a,b",c}`, types.PostgreSQLTypeText, []interface{}{"a,b", "c"}},
+		{`{}`, types.PostgreSQLTypeInteger, []interface{}{}},
+	}
+	for _, tt := range tests {
+		values, ok := generator.DecodeArray(d, tt.value, tt.elementType)
+		if !ok || fmt.Sprintf("%#v", values) != fmt.Sprintf("%#v", tt.expected) {
+			t.Errorf("DecodeArray(%q, %s) = %#v, %v; expected %#v", tt.value, tt.elementType, values, ok, tt.expected)
+		}
+	}
+
+	if _, ok := generator.DecodeArray(d, "not an array", types.PostgreSQLTypeInteger); ok {
+		t.Error("Expected text that is not an array to be rejected")
+	}
+}
+
+func TestGenerateQuerySort(t *testing.T) {
+	schema := &types.TableSchema{
+		TableName: "tasks",
+		Fields: []types.TableField{
+			{Name: "id", Type: types.PostgreSQLTypeInteger, PrimaryKey: true},
+			{Name: "status", Type: types.PostgreSQLTypeVarchar},
+			{Name: "created_at", Type: types.PostgreSQLTypeTimestamp},
+		},
+	}
+	config := &types.Config{
+		TableName: "tasks",
+		QueryConfig: &types.QueryConfig{
+			SortableFields: []string{"status", "created_at"},
+			DefaultSort:    generator.ParseSort("-created_at"),
+		},
+	}
+
+	d, err := dialect.New("postgres")
+	if err != nil {
+		t.Fatalf("Failed to create dialect: %v", err)
+	}
+	queryGen := generator.NewQueryGenerator(schema, config, d)
+
+	query, _, _, err := queryGen.GenerateQuery(types.QueryParams{})
+	if err != nil {
+		t.Fatalf("Failed to generate query: %v", err)
+	}
+	if expected := `SELECT * FROM "tasks" ORDER BY "created_at" DESC`; query != expected {
+		t.Errorf("Expected query %q, got %q", expected, query)
+	}
+
+	query, _, _, err = queryGen.GenerateQuery(types.QueryParams{Sort: generator.ParseSort("status,-created_at")})
+	if err != nil {
+		t.Fatalf("Failed to generate query: %v", err)
+	}
+	if expected := `SELECT * FROM "tasks" ORDER BY "status" ASC, "created_at" DESC`; query != expected {
+		t.Errorf("Expected query %q, got %q", expected, query)
+	}
+
+	if _, _, _, err := queryGen.GenerateQuery(types.QueryParams{Sort: generator.ParseSort("id")}); err == nil {
+		t.Error("Expected an error when sorting by a field that is not sortable")
+	}
+	if err := generator.ValidateSort(nil, generator.ParseSort("status")); err == nil {
+		t.Error("Expected no field to be sortable without sortable fields")
+	}
+	if err := generator.ValidateSort([]string{"status"}, []types.SortField{{Field: "status", Order: "sideways"}}); err == nil {
+		t.Error("Expected an error for an invalid sort order")
+	}
+}
+
+func TestGenerateQueryFilters(t *testing.T) {
+	schema := &types.TableSchema{
+		TableName: "people",
+		Fields: []types.TableField{
+			{Name: "id", Type: types.PostgreSQLTypeInteger, PrimaryKey: true},
+			{Name: "name", Type: types.PostgreSQLTypeVarchar},
+			{Name: "age", Type: types.PostgreSQLTypeInteger},
+		},
+	}
+	config := &types.Config{
+		TableName: "people",
+		QueryConfig: &types.QueryConfig{
+			FilterFields: []types.FilterField{
+				{Field: "name", Operators: []types.FilterOperator{types.FilterOpIn, types.FilterOpPrefix}},
+				{Field: "age", Operators: []types.FilterOperator{types.FilterOpBetween}},
+			},
+		},
+	}
+
+	d, err := dialect.New("postgres")
+	if err != nil {
+		t.Fatalf("Failed to create dialect: %v", err)
+	}
+	queryGen := generator.NewQueryGenerator(schema, config, d)
+
+	query, countQuery, args, err := queryGen.GenerateQuery(types.QueryParams{Filters: []types.Filter{
+		{Field: "name", Operator: types.FilterOpIn, Value: []string{"ann", "joe"}},
+		{Field: "age", Operator: types.FilterOpBetween, Value: "18,65"},
+		{Field: "name", Operator: types.FilterOpPrefix, Value: "50%"},
+	}})
+	if err != nil {
+		t.Fatalf("Failed to generate query: %v", err)
+	}
+	where := ` WHERE "name" IN ($1, $2) AND "age" BETWEEN $3 AND $4 AND "name" ILIKE $5 ESCAPE '!'`
+	if expected := `SELECT * FROM "people"` + where; query != expected {
+		t.Errorf("Expected query %q, got %q", expected, query)
+	}
+	if expected := `SELECT COUNT(*) FROM "people"` + where; countQuery != expected {
+		t.Errorf("Expected count query %q, got %q", expected, countQuery)
+	}
+	if len(args) != 5 || args[4] != "50!%%" {
+		t.Errorf("Unexpected args: %v", args)
+	}
+
+	if _, _, _, err := queryGen.GenerateQuery(types.QueryParams{Filters: []types.Filter{
+		{Field: "age", Operator: types.FilterOpGte, Value: 18},
+	}}); !errors.Is(err, generator.ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter, got %v", err)
+	}
+	query, _, args, err = queryGen.GenerateQuery(types.QueryParams{
+		Filters: []types.Filter{{Field: "name", Operator: types.FilterOpPrefix, Value: "a"}},
+		Where: &types.FilterNode{Or: []types.FilterNode{
+			{Filter: types.Filter{Field: "name", Operator: types.FilterOpIn, Value: "ann"}},
+			{Not: &types.FilterNode{Filter: types.Filter{Field: "age", Operator: types.FilterOpBetween, Value: "18,65"}}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate query with a filter tree: %v", err)
+	}
+	if expected := `SELECT * FROM "people" WHERE "name" ILIKE $1 ESCAPE '!' AND (("name" IN ($2)) OR (NOT ("age" BETWEEN $3 AND $4)))`; query != expected {
+		t.Errorf("Expected query %q, got %q", expected, query)
+	}
+	if len(args) != 4 {
+		t.Errorf("Unexpected args: %v", args)
+	}
+
+	invalidTrees := []*types.FilterNode{
+		{And: []types.FilterNode{{Filter: types.Filter{Field: "age", Operator: types.FilterOpGte, Value: 18}}}},
+		{Or: []types.FilterNode{{}}},
+		{Not: &types.FilterNode{}, Filter: types.Filter{Field: "name", Operator: types.FilterOpIn, Value: "ann"}},
+	}
+	for _, where := range invalidTrees {
+		if _, _, _, err := queryGen.GenerateQuery(types.QueryParams{Where: where}); !errors.Is(err, generator.ErrInvalidFilter) {
+			t.Errorf("Expected ErrInvalidFilter for %+v, got %v", where, err)
+		}
+	}
+}
+
+func TestFullTextSearch(t *testing.T) {
+	schema := &types.TableSchema{
+		TableName: "articles",
+		Fields: []types.TableField{
+			{Name: "id", Type: types.PostgreSQLTypeInteger, PrimaryKey: true},
+			{Name: "title", Type: types.PostgreSQLTypeVarchar},
+			{Name: "body", Type: types.PostgreSQLTypeText},
+		},
+	}
+	config := &types.Config{
+		TableName: "articles",
+		QueryConfig: &types.QueryConfig{
+			SearchFields: []types.SearchField{{Field: "q", Type: types.SearchTypeFulltext, Columns: []string{"title", "body"}, Language: "english"}},
+		},
+	}
+
+	tests := []struct {
+		dbType string
+		query  string
+		rank   string
+	}{
+		{
+			dbType: "postgres",
+			query:  `SELECT * FROM "articles" WHERE to_tsvector('english'::regconfig, COALESCE("title", '') || ' ' || COALESCE("body", '')) @@ websearch_to_tsquery('english'::regconfig, $1)`,
+			rank:   `ts_rank(to_tsvector('english'::regconfig, COALESCE("title", '') || ' ' || COALESCE("body", '')), websearch_to_tsquery('english'::regconfig, $1))`,
+		},
+		{
+			dbType: "mysql",
+			query:  "SELECT * FROM `articles` WHERE MATCH (`title`, `body`) AGAINST (? IN NATURAL LANGUAGE MODE)",
+			rank:   "MATCH (`title`, `body`) AGAINST (? IN NATURAL LANGUAGE MODE)",
+		},
+		{
+			dbType: "sqlite",
+			query:  `SELECT * FROM "articles" WHERE instr(lower(COALESCE("title", '') || ' ' || COALESCE("body", '')), lower(?)) > 0`,
+			rank:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dbType, func(t *testing.T) {
+			d, err := dialect.New(tt.dbType)
+			if err != nil {
+				t.Fatalf("Failed to create dialect: %v", err)
+			}
+
+			queryGen := generator.NewQueryGenerator(schema, config, d)
+			query, _, args, err := queryGen.GenerateQuery(types.QueryParams{Search: map[string]interface{}{"q": " release notes "}})
+			if err != nil {
+				t.Fatalf("Failed to generate query: %v", err)
+			}
+			if query != tt.query {
+				t.Errorf("Expected query %q, got %q", tt.query, query)
+			}
+			if len(args) != 1 || args[0] != "release notes" {
+				t.Errorf("Unexpected args: %v", args)
+			}
+
+			if rank := d.FullTextRank([]string{"title", "body"}, "english", d.Placeholder(1)); rank != tt.rank {
+				t.Errorf("Expected rank %q, got %q", tt.rank, rank)
+			}
+		})
+	}
+
+	// a quote in the language cannot leave the literal
+	d, _ := dialect.New("postgres")
+	if match := d.FullTextMatch([]string{"title"}, "x'; DROP TABLE articles; --", "$1"); !strings.Contains(match, `'x''; DROP TABLE articles; --'::regconfig`) {
+		t.Errorf("Expected the language to be escaped, got %q", match)
+	}
+}
+
+func TestJSONPathSearch(t *testing.T) {
+	schema := &types.TableSchema{
+		TableName: "products",
+		Fields: []types.TableField{
+			{Name: "id", Type: types.PostgreSQLTypeInteger, PrimaryKey: true},
+			{Name: "attrs", Type: types.PostgreSQLTypeJSONB},
+			{Name: "meta", Type: types.PostgreSQLTypeJSON},
+		},
+	}
+	config := &types.Config{
+		TableName: "products",
+		QueryConfig: &types.QueryConfig{
+			DisplayFields: []types.DisplayField{{Field: "attrs.color"}},
+			SearchFields:  []types.SearchField{{Field: "meta->tags", Type: types.SearchTypeExact}},
+		},
+	}
+
+	tests := []struct {
+		dbType string
+		query  string
+	}{
+		{
+			dbType: "postgres",
+			query:  `SELECT "attrs"->>'color' AS "attrs.color", "id" FROM "products" WHERE ("meta"->>'tags' = $1 OR ("meta"->'tags')::jsonb @> CAST($2 AS jsonb))`,
+		},
+		{
+			dbType: "mysql",
+			query:  "SELECT JSON_UNQUOTE(JSON_EXTRACT(`attrs`, '$.\"color\"')) AS `attrs.color`, `id` FROM `products` WHERE (JSON_UNQUOTE(JSON_EXTRACT(`meta`, '$.\"tags\"')) = ? OR JSON_CONTAINS(`meta`, ?, '$.\"tags\"'))",
+		},
+		{
+			dbType: "sqlite",
+			query:  `SELECT json_extract("attrs", '$."color"') AS "attrs.color", "id" FROM "products" WHERE (json_extract("meta", '$."tags"') = ? OR EXISTS (SELECT 1 FROM json_each("meta", '$."tags"') WHERE json_each.value = json_extract(?, '$')))`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dbType, func(t *testing.T) {
+			d, err := dialect.New(tt.dbType)
+			if err != nil {
+				t.Fatalf("Failed to create dialect: %v", err)
+			}
+
+			queryGen := generator.NewQueryGenerator(schema, config, d)
+			query, _, args, err := queryGen.GenerateQuery(types.QueryParams{Search: map[string]interface{}{"meta->tags": "sale"}})
+			if err != nil {
+				t.Fatalf("Failed to generate query: %v", err)
+			}
+			if query != tt.query {
+				t.Errorf("Expected query %q, got %q", tt.query, query)
+			}
+			if len(args) != 2 || args[0] != "sale" || args[1] != `"sale"` {
+				t.Errorf("Unexpected args: %v", args)
+			}
+		})
+	}
+
+	d, _ := dialect.New("postgres")
+	if expression := generator.ColumnExpression(d, "attrs.sizes.0.label"); expression != `"attrs"->'sizes'->0->>'label'` {
+		t.Errorf("Expected array indexes in the path, got %q", expression)
+	}
+	// a path with other characters is an identifier, not a JSON path
+	if generator.IsJSONPath("attrs.x'y") || generator.IsJSONPath("status") {
+		t.Error("Expected only letters, digits and underscores in JSON paths")
+	}
+}
+
+func TestArraySearch(t *testing.T) {
+	schema := &types.TableSchema{
+		TableName: "posts",
+		Fields: []types.TableField{
+			{Name: "id", Type: types.PostgreSQLTypeInteger, PrimaryKey: true},
+			{Name: "tags", Type: types.PostgreSQLTypeArray, ElementType: types.PostgreSQLTypeText},
+		},
+	}
+
+	tests := []struct {
+		dbType     string
+		searchType types.SearchType
+		query      string
+		literal    string
+	}{
+		{"postgres", types.SearchTypeContains, `SELECT * FROM "posts" WHERE "tags" @> $1`, `{"go","db \"x\""}`},
+		{"postgres", types.SearchTypeOverlaps, `SELECT * FROM "posts" WHERE "tags" && $1`, `{"go","db \"x\""}`},
+		{"mysql", types.SearchTypeContains, "SELECT * FROM `posts` WHERE JSON_CONTAINS(`tags`, ?)", `["go","db \"x\""]`},
+		{"mysql", types.SearchTypeOverlaps, "SELECT * FROM `posts` WHERE JSON_OVERLAPS(`tags`, ?)", `["go","db \"x\""]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dbType+"/"+string(tt.searchType), func(t *testing.T) {
+			d, err := dialect.New(tt.dbType)
+			if err != nil {
+				t.Fatalf("Failed to create dialect: %v", err)
+			}
+			config := &types.Config{
+				TableName:   "posts",
+				QueryConfig: &types.QueryConfig{SearchFields: []types.SearchField{{Field: "tags", Type: tt.searchType}}},
+			}
+
+			queryGen := generator.NewQueryGenerator(schema, config, d)
+			query, _, args, err := queryGen.GenerateQuery(types.QueryParams{Search: map[string]interface{}{"tags": `["go", "db \"x\""]`}})
+			if err != nil {
+				t.Fatalf("Failed to generate query: %v", err)
+			}
+			if query != tt.query {
+				t.Errorf("Expected query %q, got %q", tt.query, query)
+			}
+			if len(args) != 1 || args[0] != tt.literal {
+				t.Errorf("Expected literal %q, got %v", tt.literal, args)
+			}
+		})
+	}
+
+	// the PostgreSQL array output is decoded, NULL elements included
+	d, _ := dialect.New("postgres")
+	values, ok := d.ParseArray(`{go,"db \"x\"",NULL,"NULL"}`)
+	if !ok || len(values) != 4 || values[0] != "go" || values[1] != `db "x"` || values[2] != nil || values[3] != "NULL" {
+		t.Errorf("Unexpected array elements: %#v", values)
+	}
+	if _, ok := d.ParseArray(`{{1,2},{3,4}}`); ok {
+		t.Error("Expected multidimensional arrays to be left as text")
+	}
+}
@@ -0,0 +1,98 @@
+package builder_test
+
+import (
+	"testing"
+
+	"github.com/otkinlife/crud-generator/database"
+	"github.com/otkinlife/crud-generator/models"
+	"github.com/otkinlife/crud-generator/services"
+	"github.com/otkinlife/crud-generator/types"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDetectDrift(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	// every connection of an in-memory database is a separate database
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get sql.DB: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := db.AutoMigrate(&models.TableConfiguration{}); err != nil {
+		t.Fatalf("Failed to migrate configurations: %v", err)
+	}
+
+	// 配置按建表语句编写，之后实时表删除了 legacy_code、price 改为整数、新增了 notes
+	statements := []string{
+		`CREATE TABLE products (id INTEGER PRIMARY KEY, name VARCHAR(50), price INTEGER, legacy_code TEXT, notes TEXT)`,
+		`ALTER TABLE products DROP COLUMN legacy_code`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("Failed to execute '%s': %v", statement, err)
+		}
+	}
+
+	config := models.TableConfiguration{
+		Name:                  "products",
+		ConnectionID:          "drift",
+		DBTableName:           "products",
+		CreateStatement:       `CREATE TABLE products (id INTEGER PRIMARY KEY, name VARCHAR(50), price DECIMAL(10, 2), legacy_code TEXT)`,
+		IsActive:              true,
+		QueryDisplayFields:    `[{"field": "id"}, {"field": "name"}, {"field": "price"}, {"field": "legacy_code"}]`,
+		QuerySearchFields:     `[{"field": "name", "type": "fuzzy"}]`,
+		QuerySortableFields:   `["price"]`,
+		CreateCreatableFields: `[{"field": "name"}, {"field": "price"}]`,
+		UpdateUpdatableFields: `[{"field": "name"}]`,
+	}
+	if err := db.Create(&config).Error; err != nil {
+		t.Fatalf("Failed to create configuration: %v", err)
+	}
+
+	dbManager := database.GetDatabaseManager()
+	dbManager.SetExistingConnection("drift", db, &models.DatabaseConfig{Name: "drift", DbType: "sqlite", DatabaseName: ":memory:"})
+	t.Cleanup(func() { dbManager.RemoveConnection("drift") })
+
+	report, err := services.NewConfigServiceWithDB(db, dbManager).DetectDrift(config.ID)
+	if err != nil {
+		t.Fatalf("Failed to detect drift: %v", err)
+	}
+	if !report.HasDrift {
+		t.Error("Expected drift to be reported")
+	}
+
+	t.Run("missing column", func(t *testing.T) {
+		sources := make(map[string]bool)
+		for _, column := range report.MissingColumns {
+			if column.Field != "legacy_code" {
+				t.Errorf("Unexpected missing column %+v", column)
+			}
+			sources[column.Source] = true
+		}
+		if !sources["display"] || !sources["create_statement"] {
+			t.Errorf("Expected legacy_code to be missing from display fields and create statement, got %+v", report.MissingColumns)
+		}
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		if len(report.TypeMismatches) != 1 {
+			t.Fatalf("Expected 1 type mismatch, got %+v", report.TypeMismatches)
+		}
+		mismatch := report.TypeMismatches[0]
+		if mismatch.Field != "price" || mismatch.DeclaredType != string(types.PostgreSQLTypeNumeric)+"(10,2)" ||
+			mismatch.LiveType != string(types.PostgreSQLTypeInteger) {
+			t.Errorf("Unexpected type mismatch %+v", mismatch)
+		}
+	})
+
+	t.Run("unexposed column", func(t *testing.T) {
+		if len(report.UnexposedColumns) != 1 || report.UnexposedColumns[0] != "notes" {
+			t.Errorf("Expected notes to be unexposed, got %v", report.UnexposedColumns)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package builder_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	crudgen "github.com/otkinlife/crud-generator"
+	"github.com/otkinlife/crud-generator/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// a composite key whose values contain a comma and a percent sign must reach the service unchanged
+func TestRecordRoutesCompositeKeyEscaping(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get sql.DB: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	statements := []string{
+		`CREATE TABLE order_items (order_no TEXT NOT NULL, line TEXT NOT NULL, quantity INTEGER NOT NULL, PRIMARY KEY (order_no, line))`,
+		`INSERT INTO order_items (order_no, line, quantity) VALUES ('A,1', '50%', 1), ('A', '1,50%', 2)`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("Failed to execute '%s': %v", statement, err)
+		}
+	}
+	if err := db.AutoMigrate(&models.TableConfiguration{}, &models.SavedView{}); err != nil {
+		t.Fatalf("Failed to migrate configurations: %v", err)
+	}
+
+	generator, err := crudgen.NewWithGormDB(db, "handlers", nil)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	if err := generator.AddTableConfig(&crudgen.TableConfig{
+		Name:            "order_items",
+		TableName:       "order_items",
+		ConnectionID:    "handlers",
+		CreateStatement: statements[0],
+		IsActive:        true,
+	}); err != nil {
+		t.Fatalf("Failed to add configuration: %v", err)
+	}
+
+	router := gin.New()
+	generator.RegisterAPIRoutes(router)
+
+	request := func(method, target, body string) map[string]interface{} {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("%s %s returned %d: %s", method, target, recorder.Code, recorder.Body.String())
+		}
+		var response struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return response.Data
+	}
+
+	key := crudgen.EncodeRecordKey("A,1", "50%")
+	record := request("GET", "/api/order_items/get/"+key, "")
+	if record["order_no"] != "A,1" || record["line"] != "50%" || record["quantity"] != float64(1) {
+		t.Errorf("Unexpected record: %v", record)
+	}
+
+	request("PUT", "/api/order_items/update/"+key, `{"quantity": 7}`)
+	request("DELETE", "/api/order_items/delete/"+crudgen.EncodeRecordKey("A", "1,50%"), "")
+
+	var rows []struct {
+		OrderNo  string
+		Line     string
+		Quantity int
+	}
+	if err := db.Raw(`SELECT order_no, line, quantity FROM order_items`).Scan(&rows).Error; err != nil {
+		t.Fatalf("Failed to read rows: %v", err)
+	}
+	if len(rows) != 1 || rows[0].OrderNo != "A,1" || rows[0].Quantity != 7 {
+		t.Errorf("Expected only the updated record to remain, got %+v", rows)
+	}
+}
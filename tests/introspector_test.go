@@ -0,0 +1,221 @@
+package builder_test
+
+import (
+	"testing"
+
+	"github.com/otkinlife/crud-generator/introspector"
+	"github.com/otkinlife/crud-generator/types"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestSQLiteIntrospector(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	statements := []string{
+		`CREATE TABLE authors (id INTEGER PRIMARY KEY, name TEXT NOT NULL)`,
+		`CREATE TABLE books (
+			id INTEGER PRIMARY KEY,
+			author_id INTEGER NOT NULL REFERENCES authors(id) ON DELETE CASCADE,
+			isbn VARCHAR(13) UNIQUE,
+			title TEXT NOT NULL,
+			price DECIMAL(8, 2),
+			slug TEXT GENERATED ALWAYS AS (lower(title)) VIRTUAL
+		)`,
+		`INSERT INTO authors (id, name) VALUES (1, 'Ann')`,
+		`INSERT INTO books (author_id, isbn, title) VALUES (1, '9780000000001', 'One'), (1, '9780000000002', 'Two')`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("Failed to execute '%s': %v", statement, err)
+		}
+	}
+
+	tableIntrospector, err := introspector.NewIntrospector(db)
+	if err != nil {
+		t.Fatalf("Failed to select introspector: %v", err)
+	}
+
+	schema, err := tableIntrospector.IntrospectTable("books")
+	if err != nil {
+		t.Fatalf("Failed to introspect table: %v", err)
+	}
+
+	if len(schema.Fields) != 6 {
+		t.Fatalf("Expected 6 fields, got %d", len(schema.Fields))
+	}
+
+	fields := make(map[string]types.TableField)
+	for _, field := range schema.Fields {
+		fields[field.Name] = field
+	}
+
+	if id := fields["id"]; !id.PrimaryKey || !id.AutoIncrement {
+		t.Errorf("Unexpected id field: %+v", id)
+	}
+	if isbn := fields["isbn"]; isbn.Type != types.PostgreSQLTypeVarchar || isbn.Length != 13 || !isbn.Unique {
+		t.Errorf("Unexpected isbn field: %+v", isbn)
+	}
+	if price := fields["price"]; price.Type != types.PostgreSQLTypeNumeric || price.Precision != 8 {
+		t.Errorf("Unexpected price field: %+v", price)
+	}
+	if !fields["slug"].ReadOnly {
+		t.Error("Expected generated column to be read-only")
+	}
+
+	if len(schema.ForeignKeys) != 1 || schema.ForeignKeys[0].ReferencedTable != "authors" || schema.ForeignKeys[0].OnDelete != "CASCADE" {
+		t.Errorf("Unexpected foreign keys: %+v", schema.ForeignKeys)
+	}
+
+	count, err := tableIntrospector.EstimateRowCount("books")
+	if err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 rows, got %d", count)
+	}
+}
+
+// catalog rows as returned by the information_schema.columns query of PostgreSQLIntrospector
+func TestPostgreSQLIntrospectorConvertColumn(t *testing.T) {
+	text := func(value string) *string { return &value }
+	number := func(value int) *int { return &value }
+
+	columns := []introspector.PostgreSQLColumn{
+		{ColumnName: "id", DataType: "bigint", UdtName: "int8", IsNullable: "NO", ColumnType: "bigint",
+			ColumnDefault: text("nextval('devices_id_seq'::regclass)")},
+		{ColumnName: "serial_no", DataType: "integer", UdtName: "int4", IsNullable: "NO", ColumnType: "integer",
+			IsIdentity: text("YES"), IdentityGeneration: text("ALWAYS")},
+		{ColumnName: "code", DataType: "integer", UdtName: "int4", IsNullable: "NO", ColumnType: "integer",
+			IsIdentity: text("YES"), IdentityGeneration: text("BY DEFAULT")},
+		{ColumnName: "name", DataType: "character varying", UdtName: "varchar", IsNullable: "NO", ColumnType: "character varying(64)",
+			CharacterMaximumLength: number(64), ColumnComment: text("设备名称")},
+		{ColumnName: "price", DataType: "numeric", UdtName: "numeric", IsNullable: "YES", ColumnType: "numeric(10,2)",
+			NumericPrecision: number(10), NumericScale: number(2), ColumnDefault: text("0")},
+		{ColumnName: "seen_at", DataType: "timestamp with time zone", UdtName: "timestamptz", IsNullable: "YES",
+			ColumnType: "timestamp(3) with time zone"},
+		{ColumnName: "scores", DataType: "ARRAY", UdtName: "_int4", IsNullable: "YES", ColumnType: "integer[]"},
+		{ColumnName: "email", DataType: "USER-DEFINED", UdtName: "citext", IsNullable: "YES", ColumnType: "citext"},
+		{ColumnName: "mood", DataType: "USER-DEFINED", UdtName: "mood_type", IsNullable: "YES", ColumnType: "mood_type",
+			EnumValues: text(`["sad", "ok", "happy"]`), ColumnDefault: text("'ok'::mood_type")},
+		{ColumnName: "search", DataType: "tsvector", UdtName: "tsvector", IsNullable: "YES", ColumnType: "tsvector",
+			IsGenerated: text("ALWAYS")},
+	}
+
+	tableIntrospector := introspector.NewPostgreSQLIntrospector(nil)
+	fields := make(map[string]types.TableField)
+	for _, column := range columns {
+		field, err := tableIntrospector.ConvertColumn(column)
+		if err != nil {
+			t.Fatalf("Failed to convert column '%s': %v", column.ColumnName, err)
+		}
+		if field.Name != column.ColumnName || field.RawType != column.ColumnType {
+			t.Errorf("Unexpected name or raw type of column '%s': %+v", column.ColumnName, field)
+		}
+		fields[field.Name] = field
+	}
+
+	if id := fields["id"]; id.Type != types.PostgreSQLTypeBigint || !id.AutoIncrement || id.ReadOnly || !id.NotNull {
+		t.Errorf("Expected serial column to be auto increment: %+v", id)
+	}
+	if serialNo := fields["serial_no"]; !serialNo.AutoIncrement || !serialNo.ReadOnly {
+		t.Errorf("Expected GENERATED ALWAYS identity to be auto increment and read-only: %+v", serialNo)
+	}
+	if code := fields["code"]; !code.AutoIncrement || code.ReadOnly {
+		t.Errorf("Expected GENERATED BY DEFAULT identity to be writable: %+v", code)
+	}
+	if name := fields["name"]; name.Type != types.PostgreSQLTypeVarchar || name.Length != 64 || name.Comment != "设备名称" {
+		t.Errorf("Unexpected name field: %+v", name)
+	}
+	price := fields["price"]
+	if price.Type != types.PostgreSQLTypeNumeric || price.Precision != 10 || price.Scale != 2 || price.NotNull {
+		t.Errorf("Unexpected price field: %+v", price)
+	}
+	if price.DefaultValue == nil || *price.DefaultValue != "0" {
+		t.Errorf("Expected price default '0', got %v", price.DefaultValue)
+	}
+	if fields["seen_at"].Type != types.PostgreSQLTypeTimestampTZ {
+		t.Errorf("Unexpected seen_at type: %s", fields["seen_at"].Type)
+	}
+	if scores := fields["scores"]; scores.Type != types.PostgreSQLTypeArray || scores.ElementType != types.PostgreSQLTypeInteger {
+		t.Errorf("Expected the element type from udt_name: %+v", scores)
+	}
+	if fields["email"].Type != types.PostgreSQLTypeCitext {
+		t.Errorf("Expected the extension type from udt_name, got %s", fields["email"].Type)
+	}
+	mood := fields["mood"]
+	if mood.Type != types.PostgreSQLTypeEnum || len(mood.EnumValues) != 3 || mood.EnumValues[2] != "happy" {
+		t.Errorf("Expected enum labels from pg_enum: %+v", mood)
+	}
+	if search := fields["search"]; !search.ReadOnly || search.AutoIncrement {
+		t.Errorf("Expected generated column to be read-only: %+v", search)
+	}
+
+	if _, err := tableIntrospector.ConvertColumn(introspector.PostgreSQLColumn{
+		ColumnName: "broken", DataType: "USER-DEFINED", UdtName: "mood_type", EnumValues: text("not json"),
+	}); err == nil {
+		t.Error("Expected an error for invalid enum labels")
+	}
+}
+
+// catalog rows as returned by the information_schema.columns query of MySQLIntrospector
+func TestMySQLIntrospectorConvertColumn(t *testing.T) {
+	text := func(value string) *string { return &value }
+
+	columns := []introspector.MySQLColumn{
+		{ColumnName: "id", ColumnType: "int unsigned", IsNullable: "NO", Extra: "auto_increment"},
+		{ColumnName: "name", ColumnType: "varchar(100)", IsNullable: "NO", ColumnComment: "名称"},
+		{ColumnName: "price", ColumnType: "decimal(10,2)", IsNullable: "YES", ColumnDefault: text("0.00")},
+		{ColumnName: "active", ColumnType: "tinyint(1)", IsNullable: "NO", ColumnDefault: text("1")},
+		{ColumnName: "status", ColumnType: "enum('draft','published')", IsNullable: "NO", ColumnDefault: text("draft")},
+		{ColumnName: "updated_at", ColumnType: "datetime", IsNullable: "YES", Extra: "DEFAULT_GENERATED on update CURRENT_TIMESTAMP"},
+		{ColumnName: "shape", ColumnType: "geometry", IsNullable: "YES"},
+		{ColumnName: "location", ColumnType: "point", IsNullable: "NO"},
+	}
+
+	tableIntrospector := introspector.NewMySQLIntrospector(nil)
+	fields := make(map[string]types.TableField)
+	for _, column := range columns {
+		field, err := tableIntrospector.ConvertColumn(column)
+		if err != nil {
+			t.Fatalf("Failed to convert column '%s': %v", column.ColumnName, err)
+		}
+		if field.Name != column.ColumnName || field.RawType != column.ColumnType {
+			t.Errorf("Unexpected name or raw type of column '%s': %+v", column.ColumnName, field)
+		}
+		fields[field.Name] = field
+	}
+
+	if id := fields["id"]; id.Type != types.PostgreSQLTypeBigint || !id.Unsigned || !id.AutoIncrement || !id.NotNull {
+		t.Errorf("Unexpected id field: %+v", id)
+	}
+	if name := fields["name"]; name.Type != types.PostgreSQLTypeVarchar || name.Length != 100 || name.Comment != "名称" || name.AutoIncrement {
+		t.Errorf("Unexpected name field: %+v", name)
+	}
+	price := fields["price"]
+	if price.Type != types.PostgreSQLTypeNumeric || price.Precision != 10 || price.Scale != 2 || price.NotNull {
+		t.Errorf("Unexpected price field: %+v", price)
+	}
+	if price.DefaultValue == nil || *price.DefaultValue != "0.00" {
+		t.Errorf("Expected price default '0.00', got %v", price.DefaultValue)
+	}
+	if fields["active"].Type != types.PostgreSQLTypeBoolean {
+		t.Errorf("Expected tinyint(1) to be boolean, got %s", fields["active"].Type)
+	}
+	if status := fields["status"]; status.Type != types.PostgreSQLTypeEnum || len(status.EnumValues) != 2 || status.EnumValues[1] != "published" {
+		t.Errorf("Unexpected status field: %+v", status)
+	}
+	if updatedAt := fields["updated_at"]; updatedAt.Type != types.PostgreSQLTypeTimestamp || updatedAt.AutoIncrement || updatedAt.DefaultValue != nil {
+		t.Errorf("Unexpected updated_at field: %+v", updatedAt)
+	}
+	// 解析器不认识的类型原样保留，不影响整张表的读取
+	if shape := fields["shape"]; shape.Type != types.PostgreSQLTypeUserDefined || shape.RawType != "geometry" {
+		t.Errorf("Expected geometry to pass through as a user-defined type: %+v", shape)
+	}
+	if location := fields["location"]; location.Type != types.PostgreSQLTypeUserDefined || !location.NotNull {
+		t.Errorf("Expected point to pass through as a user-defined type: %+v", location)
+	}
+}
@@ -0,0 +1,445 @@
+package builder_test
+
+import (
+	"testing"
+
+	"github.com/otkinlife/crud-generator/parser"
+	"github.com/otkinlife/crud-generator/types"
+)
+
+func TestMySQLParser(t *testing.T) {
+	createSQL := "CREATE TABLE IF NOT EXISTS `shop`.`orders` (\n" +
+		"  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `order_no` varchar(32) NOT NULL COMMENT 'order number, unique',\n" +
+		"  `status` enum('pending','paid','it''s done') NOT NULL DEFAULT 'pending',\n" +
+		"  `amount` decimal(10,2) DEFAULT NULL,\n" +
+		"  `is_gift` tinyint(1) NOT NULL DEFAULT '0',\n" +
+		"  `note` text CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci,\n" +
+		"  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `uk_order_no` (`order_no`),\n" +
+		"  KEY `idx_status` (`status`)\n" +
+		") ENGINE=InnoDB AUTO_INCREMENT=10 DEFAULT CHARSET=utf8mb4 COMMENT='orders (main)';"
+
+	schema, err := parser.NewMySQLParser().ParseCreateStatement(createSQL)
+	if err != nil {
+		t.Fatalf("Failed to parse create statement: %v", err)
+	}
+
+	if schema.TableName != "orders" {
+		t.Errorf("Expected table name 'orders', got '%s'", schema.TableName)
+	}
+
+	if len(schema.Fields) != 7 {
+		t.Fatalf("Expected 7 fields, got %d", len(schema.Fields))
+	}
+
+	id := schema.Fields[0]
+	if id.Type != types.PostgreSQLTypeBigint || !id.Unsigned || !id.AutoIncrement || !id.PrimaryKey {
+		t.Errorf("Unexpected id field: %+v", id)
+	}
+
+	orderNo := schema.Fields[1]
+	if orderNo.Type != types.PostgreSQLTypeVarchar || orderNo.Length != 32 || !orderNo.Unique || !orderNo.NotNull {
+		t.Errorf("Unexpected order_no field: %+v", orderNo)
+	}
+	if orderNo.Comment != "order number, unique" {
+		t.Errorf("Expected order_no comment, got '%s'", orderNo.Comment)
+	}
+
+	status := schema.Fields[2]
+	if status.Type != types.PostgreSQLTypeEnum || len(status.EnumValues) != 3 || status.EnumValues[2] != "it's done" {
+		t.Errorf("Unexpected status field: %+v", status)
+	}
+	if status.DefaultValue == nil || *status.DefaultValue != "'pending'" {
+		t.Errorf("Unexpected status default: %v", status.DefaultValue)
+	}
+
+	amount := schema.Fields[3]
+	if amount.Type != types.PostgreSQLTypeNumeric || amount.Precision != 10 || amount.Scale != 2 {
+		t.Errorf("Unexpected amount field: %+v", amount)
+	}
+
+	if schema.Fields[4].Type != types.PostgreSQLTypeBoolean {
+		t.Errorf("Expected tinyint(1) to map to boolean, got %s", schema.Fields[4].Type)
+	}
+
+	if schema.Fields[5].Type != types.PostgreSQLTypeText {
+		t.Errorf("Expected text type for note, got %s", schema.Fields[5].Type)
+	}
+
+	if schema.Fields[6].Type != types.PostgreSQLTypeTimestamp {
+		t.Errorf("Expected timestamp type for created_at, got %s", schema.Fields[6].Type)
+	}
+}
+
+func TestNewParser(t *testing.T) {
+	tests := []struct {
+		dbType      string
+		expectError bool
+	}{
+		{dbType: "postgresql"},
+		{dbType: "postgres"},
+		{dbType: "mysql"},
+		{dbType: "sqlite"},
+		{dbType: "oracle", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dbType, func(t *testing.T) {
+			_, err := parser.NewParser(tt.dbType)
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgreSQLParserTableConstraints(t *testing.T) {
+	p := parser.NewPostgreSQLParser()
+
+	createSQL := `CREATE TABLE order_items (
+		order_id INTEGER NOT NULL,
+		line_no INTEGER NOT NULL,
+		product_id INTEGER REFERENCES products(id) ON DELETE SET NULL,
+		sku VARCHAR(32),
+		quantity INTEGER CHECK (quantity > 0),
+		CONSTRAINT pk_order_items PRIMARY KEY (order_id, line_no),
+		CONSTRAINT fk_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE,
+		UNIQUE (order_id, sku),
+		CHECK (line_no >= 1)
+	)`
+
+	schema, err := p.ParseCreateStatement(createSQL)
+	if err != nil {
+		t.Fatalf("Failed to parse CREATE statement: %v", err)
+	}
+
+	if len(schema.Fields) != 5 {
+		t.Fatalf("Expected 5 fields, got %d", len(schema.Fields))
+	}
+
+	if len(schema.PrimaryKey) != 2 || schema.PrimaryKey[0] != "order_id" || schema.PrimaryKey[1] != "line_no" {
+		t.Errorf("Unexpected primary key: %v", schema.PrimaryKey)
+	}
+	if !schema.Fields[0].PrimaryKey || !schema.Fields[1].PrimaryKey {
+		t.Error("Expected order_id and line_no to be marked as primary key")
+	}
+
+	if len(schema.UniqueKeys) != 1 || len(schema.UniqueKeys[0]) != 2 {
+		t.Errorf("Unexpected unique keys: %v", schema.UniqueKeys)
+	}
+
+	if len(schema.ForeignKeys) != 2 {
+		t.Fatalf("Expected 2 foreign keys, got %d", len(schema.ForeignKeys))
+	}
+	product := schema.ForeignKeys[0]
+	if product.ReferencedTable != "products" || product.ReferencedColumns[0] != "id" || product.OnDelete != "SET NULL" {
+		t.Errorf("Unexpected inline foreign key: %+v", product)
+	}
+	order := schema.ForeignKeys[1]
+	if order.Name != "fk_order" || order.ReferencedTable != "orders" || order.OnDelete != "CASCADE" {
+		t.Errorf("Unexpected table foreign key: %+v", order)
+	}
+
+	if len(schema.Checks) != 2 || schema.Checks[0].Expression != "quantity > 0" || schema.Checks[1].Expression != "line_no >= 1" {
+		t.Errorf("Unexpected checks: %+v", schema.Checks)
+	}
+}
+
+func TestMySQLParserForeignKey(t *testing.T) {
+	p := parser.NewMySQLParser()
+
+	createSQL := "CREATE TABLE `posts` (\n" +
+		"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+		"  `user_id` int NOT NULL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `idx_user` (`user_id`),\n" +
+		"  CONSTRAINT `fk_posts_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE ON UPDATE NO ACTION\n" +
+		") ENGINE=InnoDB"
+
+	schema, err := p.ParseCreateStatement(createSQL)
+	if err != nil {
+		t.Fatalf("Failed to parse CREATE statement: %v", err)
+	}
+
+	if len(schema.PrimaryKey) != 1 || schema.PrimaryKey[0] != "id" {
+		t.Errorf("Unexpected primary key: %v", schema.PrimaryKey)
+	}
+
+	if len(schema.ForeignKeys) != 1 {
+		t.Fatalf("Expected 1 foreign key, got %d", len(schema.ForeignKeys))
+	}
+	fk := schema.ForeignKeys[0]
+	if fk.Name != "fk_posts_user" || fk.Columns[0] != "user_id" || fk.ReferencedTable != "users" ||
+		fk.OnDelete != "CASCADE" || fk.OnUpdate != "NO ACTION" {
+		t.Errorf("Unexpected foreign key: %+v", fk)
+	}
+}
+
+func TestParserComments(t *testing.T) {
+	pgSQL := `CREATE TABLE public.users (
+		id SERIAL PRIMARY KEY,
+		status SMALLINT NOT NULL
+	);
+	COMMENT ON TABLE public.users IS 'Registered users';
+	COMMENT ON COLUMN public.users.status IS 'Status: 0 disabled, 1 active';
+	COMMENT ON COLUMN "users"."id" IS 'User''s ID';
+	COMMENT ON COLUMN orders.status IS 'Order status';`
+
+	pgSchema, err := parser.NewPostgreSQLParser().ParseCreateStatement(pgSQL)
+	if err != nil {
+		t.Fatalf("Failed to parse PostgreSQL statement: %v", err)
+	}
+	if pgSchema.Comment != "Registered users" {
+		t.Errorf("Unexpected table comment: %q", pgSchema.Comment)
+	}
+	if pgSchema.Fields[0].Comment != "User's ID" {
+		t.Errorf("Unexpected comment on id: %q", pgSchema.Fields[0].Comment)
+	}
+	if pgSchema.Fields[1].Comment != "Status: 0 disabled, 1 active" {
+		t.Errorf("Unexpected comment on status: %q", pgSchema.Fields[1].Comment)
+	}
+
+	mysqlSQL := "CREATE TABLE `users` (\n" +
+		"  `id` int NOT NULL AUTO_INCREMENT COMMENT 'ID',\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='Registered users'"
+
+	mysqlSchema, err := parser.NewMySQLParser().ParseCreateStatement(mysqlSQL)
+	if err != nil {
+		t.Fatalf("Failed to parse MySQL statement: %v", err)
+	}
+	if mysqlSchema.Comment != "Registered users" {
+		t.Errorf("Unexpected table comment: %q", mysqlSchema.Comment)
+	}
+	if mysqlSchema.Fields[0].Comment != "ID" {
+		t.Errorf("Unexpected comment on id: %q", mysqlSchema.Fields[0].Comment)
+	}
+}
+
+func TestParserScript(t *testing.T) {
+	script := `-- migration 0042
+CREATE TYPE order_status AS ENUM ('pending', 'paid', 'shipped');
+
+CREATE TABLE customers (
+	id BIGSERIAL PRIMARY KEY
+);
+
+CREATE FUNCTION touch() RETURNS trigger AS $$
+BEGIN
+	NEW.updated_at = now(); -- CREATE TABLE orders (fake int);
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TABLE public.orders (
+	id BIGSERIAL PRIMARY KEY, -- surrogate key
+	customer_id BIGINT NOT NULL,
+	code VARCHAR(20) NOT NULL,
+	status order_status NOT NULL DEFAULT 'pending', /* (unbalanced */
+	history order_status[]
+);
+
+CREATE UNIQUE INDEX orders_code_key ON public.orders (code);
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_orders_customer ON ONLY orders USING btree (customer_id);
+CREATE UNIQUE INDEX idx_orders_open ON orders (customer_id) WHERE status = 'pending';
+CREATE INDEX idx_customers_id ON customers (id);
+COMMENT ON TABLE orders IS 'Customer orders';
+COMMENT ON COLUMN public.orders.code IS 'Order code; shown on invoices';
+ALTER TABLE ONLY public.orders
+	ADD CONSTRAINT fk_orders_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE CASCADE,
+	ADD CONSTRAINT chk_code CHECK (length(code) > 3);`
+
+	schema, err := parser.NewPostgreSQLParser().ParseScript(script, "orders")
+	if err != nil {
+		t.Fatalf("Failed to parse script: %v", err)
+	}
+
+	if schema.TableName != "public.orders" || len(schema.Fields) != 5 {
+		t.Fatalf("Unexpected table: %s with %d fields", schema.TableName, len(schema.Fields))
+	}
+	if schema.Comment != "Customer orders" {
+		t.Errorf("Unexpected table comment: %q", schema.Comment)
+	}
+
+	fields := make(map[string]types.TableField)
+	for _, field := range schema.Fields {
+		fields[field.Name] = field
+	}
+	if fields["code"].Comment != "Order code; shown on invoices" || !fields["code"].Unique {
+		t.Errorf("Unexpected code field: %+v", fields["code"])
+	}
+	if fields["status"].Type != types.PostgreSQLTypeEnum || len(fields["status"].EnumValues) != 3 {
+		t.Errorf("Expected status to resolve to the enum type, got %+v", fields["status"])
+	}
+	if fields["history"].ElementType != types.PostgreSQLTypeEnum {
+		t.Errorf("Expected history to be an array of the enum type, got %+v", fields["history"])
+	}
+	if fields["customer_id"].Unique {
+		t.Error("A partial unique index should not make customer_id unique")
+	}
+
+	if len(schema.Indexes) != 3 {
+		t.Fatalf("Expected 3 indexes, got %+v", schema.Indexes)
+	}
+	if schema.Indexes[1].Name != "idx_orders_customer" || schema.Indexes[1].Columns[0] != "customer_id" || schema.Indexes[1].Unique {
+		t.Errorf("Unexpected index: %+v", schema.Indexes[1])
+	}
+
+	if len(schema.ForeignKeys) != 1 || schema.ForeignKeys[0].Name != "fk_orders_customer" || schema.ForeignKeys[0].OnDelete != "CASCADE" {
+		t.Errorf("Unexpected foreign keys: %+v", schema.ForeignKeys)
+	}
+	if len(schema.Checks) != 1 || schema.Checks[0].Expression != "length(code) > 3" {
+		t.Errorf("Unexpected checks: %+v", schema.Checks)
+	}
+
+	first, err := parser.NewPostgreSQLParser().ParseCreateStatement(script)
+	if err != nil {
+		t.Fatalf("Failed to parse script: %v", err)
+	}
+	if first.TableName != "customers" || len(first.Indexes) != 1 {
+		t.Errorf("Expected the first table with its index, got %s with %+v", first.TableName, first.Indexes)
+	}
+
+	if _, err := parser.NewPostgreSQLParser().ParseScript(script, "invoices"); err == nil {
+		t.Error("Expected an error for a table missing from the script")
+	}
+
+	mysqlScript := "CREATE TABLE `posts` (\n" +
+		"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+		"  `title` varchar(200) NOT NULL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `idx_title` (`title`(20))\n" +
+		") ENGINE=InnoDB;\n" +
+		"ALTER TABLE `posts` ADD FULLTEXT INDEX `ft_title` (`title`), COMMENT='Blog posts';"
+
+	mysqlSchema, err := parser.NewMySQLParser().ParseScript(mysqlScript, "posts")
+	if err != nil {
+		t.Fatalf("Failed to parse MySQL script: %v", err)
+	}
+	if len(mysqlSchema.Indexes) != 2 || mysqlSchema.Indexes[0].Name != "idx_title" || mysqlSchema.Indexes[1].Name != "ft_title" {
+		t.Errorf("Unexpected MySQL indexes: %+v", mysqlSchema.Indexes)
+	}
+	if mysqlSchema.Comment != "Blog posts" {
+		t.Errorf("Unexpected MySQL table comment: %q", mysqlSchema.Comment)
+	}
+}
+
+func TestPostgreSQLParserTypeCoverage(t *testing.T) {
+	createSQL := `CREATE TABLE devices (
+		id BIGSERIAL PRIMARY KEY,
+		serial_no INTEGER GENERATED ALWAYS AS IDENTITY,
+		email citext NOT NULL,
+		address inet,
+		balance money,
+		price double precision,
+		name character varying(64),
+		seen_at timestamp(3) with time zone,
+		opens_at timetz,
+		tags text[],
+		scores integer ARRAY,
+		mood mood_type DEFAULT 'ok',
+		search tsvector GENERATED ALWAYS AS (to_tsvector('simple', name)) STORED
+	)`
+
+	schema, err := parser.NewPostgreSQLParser().ParseCreateStatement(createSQL)
+	if err != nil {
+		t.Fatalf("Failed to parse CREATE statement: %v", err)
+	}
+
+	if len(schema.Fields) != 13 {
+		t.Fatalf("Expected 13 fields, got %d", len(schema.Fields))
+	}
+
+	fields := make(map[string]types.TableField)
+	for _, field := range schema.Fields {
+		fields[field.Name] = field
+	}
+
+	if id := fields["id"]; id.Type != types.PostgreSQLTypeBigint || !id.AutoIncrement || !id.PrimaryKey {
+		t.Errorf("Unexpected id field: %+v", id)
+	}
+	if serialNo := fields["serial_no"]; !serialNo.AutoIncrement || !serialNo.ReadOnly {
+		t.Errorf("Expected identity column to be auto increment and read-only: %+v", serialNo)
+	}
+	if fields["email"].Type != types.PostgreSQLTypeCitext || fields["address"].Type != types.PostgreSQLTypeInet ||
+		fields["balance"].Type != types.PostgreSQLTypeMoney || fields["price"].Type != types.PostgreSQLTypeDouble {
+		t.Errorf("Unexpected scalar types: %s %s %s %s",
+			fields["email"].Type, fields["address"].Type, fields["balance"].Type, fields["price"].Type)
+	}
+	if name := fields["name"]; name.Type != types.PostgreSQLTypeVarchar || name.Length != 64 {
+		t.Errorf("Unexpected name field: %+v", name)
+	}
+	if fields["seen_at"].Type != types.PostgreSQLTypeTimestampTZ || fields["opens_at"].Type != types.PostgreSQLTypeTimeTZ {
+		t.Errorf("Unexpected time types: %s %s", fields["seen_at"].Type, fields["opens_at"].Type)
+	}
+	if tags := fields["tags"]; tags.Type != types.PostgreSQLTypeArray || tags.ElementType != types.PostgreSQLTypeText {
+		t.Errorf("Unexpected tags field: %+v", tags)
+	}
+	if scores := fields["scores"]; scores.Type != types.PostgreSQLTypeArray || scores.ElementType != types.PostgreSQLTypeInteger {
+		t.Errorf("Unexpected scores field: %+v", scores)
+	}
+	if mood := fields["mood"]; mood.Type != types.PostgreSQLTypeUserDefined || mood.RawType != "mood_type" {
+		t.Errorf("Unexpected mood field: %+v", mood)
+	}
+	if search := fields["search"]; !search.ReadOnly || search.AutoIncrement {
+		t.Errorf("Expected generated column to be read-only: %+v", search)
+	}
+}
+
+func TestSQLiteParser(t *testing.T) {
+	createSQL := `CREATE TABLE IF NOT EXISTS "notes" (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		author_id INTEGER NOT NULL REFERENCES authors(id) ON DELETE CASCADE,
+		title VARCHAR(120) NOT NULL,
+		body TEXT,
+		rating REAL,
+		price DECIMAL(8, 2) DEFAULT 0,
+		archived BOOLEAN NOT NULL DEFAULT 0,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		payload,
+		UNIQUE (author_id, title)
+	)`
+
+	schema, err := parser.NewSQLiteParser().ParseCreateStatement(createSQL)
+	if err != nil {
+		t.Fatalf("Failed to parse CREATE statement: %v", err)
+	}
+
+	if schema.TableName != "notes" {
+		t.Errorf("Expected table name 'notes', got '%s'", schema.TableName)
+	}
+	if len(schema.Fields) != 9 {
+		t.Fatalf("Expected 9 fields, got %d", len(schema.Fields))
+	}
+
+	if id := schema.Fields[0]; id.Type != types.PostgreSQLTypeInteger || !id.PrimaryKey || !id.AutoIncrement {
+		t.Errorf("Unexpected id field: %+v", id)
+	}
+	if title := schema.Fields[2]; title.Type != types.PostgreSQLTypeVarchar || title.Length != 120 || !title.NotNull {
+		t.Errorf("Unexpected title field: %+v", title)
+	}
+	if rating := schema.Fields[4]; rating.Type != types.PostgreSQLTypeDouble {
+		t.Errorf("Expected double type for rating, got %s", rating.Type)
+	}
+	if price := schema.Fields[5]; price.Type != types.PostgreSQLTypeNumeric || price.Precision != 8 || price.Scale != 2 {
+		t.Errorf("Unexpected price field: %+v", price)
+	}
+	if schema.Fields[6].Type != types.PostgreSQLTypeBoolean || schema.Fields[7].Type != types.PostgreSQLTypeTimestamp {
+		t.Errorf("Unexpected archived/created_at types: %s %s", schema.Fields[6].Type, schema.Fields[7].Type)
+	}
+	if schema.Fields[8].Type != types.PostgreSQLTypeBytea {
+		t.Errorf("Expected untyped column to have blob affinity, got %s", schema.Fields[8].Type)
+	}
+
+	if len(schema.ForeignKeys) != 1 || schema.ForeignKeys[0].ReferencedTable != "authors" {
+		t.Errorf("Unexpected foreign keys: %+v", schema.ForeignKeys)
+	}
+	if len(schema.UniqueKeys) != 1 || len(schema.UniqueKeys[0]) != 2 {
+		t.Errorf("Unexpected unique keys: %v", schema.UniqueKeys)
+	}
+}
@@ -0,0 +1,137 @@
+package builder_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/otkinlife/crud-generator/generator"
+	"github.com/otkinlife/crud-generator/models"
+	"github.com/otkinlife/crud-generator/types"
+)
+
+func TestScaffoldConfig(t *testing.T) {
+	schema := &types.TableSchema{
+		TableName: "users",
+		Fields: []types.TableField{
+			{Name: "id", Type: types.PostgreSQLTypeInteger, PrimaryKey: true, NotNull: true, AutoIncrement: true},
+			{Name: "username", Type: types.PostgreSQLTypeVarchar, Length: 50, NotNull: true},
+			{Name: "bio", Type: types.PostgreSQLTypeText},
+			{Name: "age", Type: types.PostgreSQLTypeInteger},
+			{Name: "created_at", Type: types.PostgreSQLTypeTimestamp},
+		},
+	}
+
+	config := generator.ScaffoldConfig(schema)
+
+	if len(config.QueryConfig.DisplayFields) != 5 {
+		t.Errorf("Expected 5 display fields, got %d", len(config.QueryConfig.DisplayFields))
+	}
+
+	searchTypes := make(map[string]types.SearchType)
+	for _, field := range config.QueryConfig.SearchFields {
+		searchTypes[field.Field] = field.Type
+	}
+	if searchTypes["username"] != types.SearchTypeFuzzy {
+		t.Errorf("Expected fuzzy search on username, got '%s'", searchTypes["username"])
+	}
+	if searchTypes["age"] != types.SearchTypeRange {
+		t.Errorf("Expected range search on age, got '%s'", searchTypes["age"])
+	}
+	if searchTypes["created_at"] != types.SearchTypeDateRange {
+		t.Errorf("Expected date range search on created_at, got '%s'", searchTypes["created_at"])
+	}
+
+	for _, field := range config.CreateConfig.CreatableFields {
+		if field.Field == "id" {
+			t.Error("Primary key should not be creatable")
+		}
+	}
+
+	var username *types.CreatableField
+	for i := range config.CreateConfig.CreatableFields {
+		if config.CreateConfig.CreatableFields[i].Field == "username" {
+			username = &config.CreateConfig.CreatableFields[i]
+		}
+	}
+	if username == nil {
+		t.Fatal("Expected username to be creatable")
+	}
+	if !username.Required {
+		t.Error("Expected username to be required")
+	}
+	if username.Validation == nil || username.Validation.MaxLength == nil || *username.Validation.MaxLength != 50 {
+		t.Errorf("Expected max length 50 on username, got %+v", username.Validation)
+	}
+}
+
+func TestScaffoldConfigComments(t *testing.T) {
+	schema := &types.TableSchema{
+		TableName: "users",
+		Fields: []types.TableField{
+			{Name: "status", Type: types.PostgreSQLTypeSmallint, Comment: "Status: 0 disabled, 1 active"},
+			{Name: "nickname", Type: types.PostgreSQLTypeVarchar, Comment: "Nickname"},
+			{Name: "bio", Type: types.PostgreSQLTypeText},
+		},
+	}
+
+	config := generator.ScaffoldConfig(schema)
+
+	expected := []struct {
+		label    string
+		helpText string
+	}{
+		{"Status", "Status: 0 disabled, 1 active"},
+		{"Nickname", ""},
+		{"bio", ""},
+	}
+	for i, field := range config.CreateConfig.CreatableFields {
+		if field.Label != expected[i].label || field.HelpText != expected[i].helpText {
+			t.Errorf("Unexpected label or help text on %s: %q, %q", field.Field, field.Label, field.HelpText)
+		}
+		if config.QueryConfig.DisplayFields[i].Label != expected[i].label {
+			t.Errorf("Unexpected display label on %s: %q", field.Field, config.QueryConfig.DisplayFields[i].Label)
+		}
+	}
+}
+
+func TestScaffoldConfigEnumDict(t *testing.T) {
+	schema := &types.TableSchema{
+		TableName: "tickets",
+		Fields: []types.TableField{
+			{Name: "id", Type: types.PostgreSQLTypeInteger, PrimaryKey: true},
+			{Name: "status", Type: types.PostgreSQLTypeEnum, EnumValues: []string{"open", "closed"}},
+			{Name: "kind", Type: types.PostgreSQLTypeEnum, EnumValues: []string{"bug"}},
+		},
+	}
+
+	config := generator.ScaffoldConfig(schema)
+
+	dictSources := make(map[string]string)
+	for _, field := range config.QueryConfig.SearchFields {
+		dictSources[field.Field] = field.DictSource
+	}
+	if dictSources["status"] != `["open","closed"]` || dictSources["kind"] != `["bug"]` {
+		t.Fatalf("Expected enum values as JSON arrays, got %q and %q", dictSources["status"], dictSources["kind"])
+	}
+
+	// 单个值的枚举读取固定字典值，不查询同名字段的去重值
+	searchFields, err := json.Marshal([]types.SearchField{{Field: "kind", Type: types.SearchTypeSingle, DictSource: dictSources["kind"]}})
+	if err != nil {
+		t.Fatalf("Failed to encode search fields: %v", err)
+	}
+	crudService := newTestCRUDService(t, models.TableConfiguration{
+		Name:              "tickets",
+		DBTableName:       "tickets",
+		QuerySearchFields: string(searchFields),
+	},
+		`CREATE TABLE tickets (id INTEGER PRIMARY KEY, kind TEXT)`,
+		`INSERT INTO tickets (id, kind) VALUES (1, 'question')`,
+	)
+	items, err := crudService.GetDict("tickets", "kind")
+	if err != nil {
+		t.Fatalf("Failed to get dict: %v", err)
+	}
+	if len(items) != 1 || items[0].Value != "bug" || items[0].Label != "bug" {
+		t.Errorf("Expected the single enum value, got %+v", items)
+	}
+}
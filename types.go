@@ -12,6 +12,18 @@ type QueryParams struct {
 	PageSize int                    `json:"page_size"`
 	Search   map[string]interface{} `json:"search"`
 	Sort     []SortField            `json:"sort"`
+	// Filters are operator conditions, each must be allowed by the QueryFilterFields of the table
+	Filters []Filter `json:"filters"`
+	// Where is a boolean filter expression ANDed with Search and Filters, its leaves follow the same rules as Filters
+	Where *FilterNode `json:"where,omitempty"`
+	// Fields narrows the returned columns to a subset of the displayed ones, the primary key is always returned
+	Fields []string `json:"fields,omitempty"`
+	// CursorMode switches to keyset pagination: records are read after Cursor, ordered by the
+	// sort fields plus the primary key, without OFFSET. Page is ignored in this mode.
+	CursorMode bool   `json:"cursor_mode"`
+	Cursor     string `json:"cursor"`
+	// SkipCount leaves Total and TotalPages empty in cursor mode, avoiding COUNT(*) on large tables
+	SkipCount bool `json:"skip_count"`
 }
 
 // SortField represents a sort field configuration
@@ -20,6 +32,30 @@ type SortField struct {
 	Order SortOrder `json:"order"`
 }
 
+// Filter represents an operator condition such as filter[age][gte]=18.
+// Operator is one of eq, ne, gt, gte, lt, lte, in, not_in, prefix, suffix, contains,
+// is_null, not_null and between. in, not_in and between take a list or a comma separated string.
+type Filter struct {
+	Field    string      `json:"field"`
+	Operator string      `json:"operator"`
+	Value    interface{} `json:"value"`
+}
+
+// FilterNode is a node of a boolean filter expression. Exactly one of And, Or, Not or Field is set:
+//
+//	{"or": [{"field": "status", "operator": "eq", "value": "failed"},
+//	        {"and": [{"field": "status", "operator": "eq", "value": "pending"},
+//	                 {"field": "created_at", "operator": "lt", "value": "2024-01-01"}]}]}
+type FilterNode struct {
+	And []FilterNode `json:"and,omitempty"`
+	Or  []FilterNode `json:"or,omitempty"`
+	Not *FilterNode  `json:"not,omitempty"`
+
+	Field    string      `json:"field,omitempty"`
+	Operator string      `json:"operator,omitempty"`
+	Value    interface{} `json:"value,omitempty"`
+}
+
 // SortOrder represents sort order
 type SortOrder string
 
@@ -35,6 +71,89 @@ type QueryResult struct {
 	Page       int                      `json:"page"`
 	PageSize   int                      `json:"page_size"`
 	TotalPages int                      `json:"total_pages"`
+	// PrimaryKey lists the key columns used to address a record in Update and Delete
+	PrimaryKey []string `json:"primary_key,omitempty"`
+	// NextCursor and PrevCursor are opaque cursors of the adjacent pages in cursor mode,
+	// empty when there is no such page
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// AggregateParams represents parameters of an aggregate query, Search, Filters and Where work as in List
+type AggregateParams struct {
+	Search  map[string]interface{} `json:"search"`
+	Filters []Filter               `json:"filters"`
+	Where   *FilterNode            `json:"where,omitempty"`
+	// GroupBy lists the columns to group by, each must be allowed by the QueryAggregate of the table
+	GroupBy []string `json:"group_by,omitempty"`
+	// Metrics lists the aggregate functions to compute, the record count when empty
+	Metrics []Metric `json:"metrics,omitempty"`
+}
+
+// Metric is an aggregate function (count, sum, avg, min or max) of a column.
+// count without a column counts the records.
+type Metric struct {
+	Function string `json:"function"`
+	Field    string `json:"field,omitempty"`
+}
+
+// AggregateResult holds a row per group, keyed by the group-by columns and the metric keys
+// such as count and sum_amount. Without group-by columns there is a single row.
+type AggregateResult struct {
+	GroupBy []string                 `json:"group_by,omitempty"`
+	Metrics []string                 `json:"metrics"`
+	Rows    []map[string]interface{} `json:"rows"`
+}
+
+// FacetParams selects the facets to count. Search, Filters and Where work as in List, except that
+// the counts of a field ignore the search parameter of that field itself, so the other values of a
+// selected dropdown keep their counts. Fields narrows the counted search fields, all single and
+// multi_select search fields with a dictionary are counted when empty.
+type FacetParams struct {
+	Search  map[string]interface{} `json:"search"`
+	Filters []Filter               `json:"filters"`
+	Where   *FilterNode            `json:"where,omitempty"`
+	Fields  []string               `json:"fields,omitempty"`
+}
+
+// FacetValue is a dictionary item of a search field with the number of matching records
+type FacetValue struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// Facet holds the counts of the dictionary items of a search field, in dictionary order
+type Facet struct {
+	Field  string       `json:"field"`
+	Values []FacetValue `json:"values"`
+}
+
+// FacetResult holds a facet per counted search field, in search field order
+type FacetResult struct {
+	Facets []Facet `json:"facets"`
+}
+
+// SavedView is a named set of list settings of a table configuration: search parameters, filter
+// expression, sort, visible columns and page size. A private view is visible to its owner only,
+// a shared view to everyone. Views are addressed by name, the owner's own view taking precedence
+// over a view of the same name shared by another user.
+type SavedView struct {
+	ID         uint                   `json:"id"`
+	ConfigName string                 `json:"config_name"`
+	Name       string                 `json:"name"`
+	Owner      string                 `json:"owner"`
+	Shared     bool                   `json:"shared"`
+	Search     map[string]interface{} `json:"search,omitempty"`
+	Where      *FilterNode            `json:"where,omitempty"`
+	// Sort lists the sort fields, "-" meaning descending, e.g. "status,-created_at"
+	Sort string `json:"sort,omitempty"`
+	// Fields lists the visible columns, all displayed fields when empty
+	Fields []string `json:"fields,omitempty"`
+	// PageSize is the page size of the view, the default page size when 0
+	PageSize  int       `json:"page_size,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // CRUDResult represents the result of a CRUD operation
@@ -88,12 +207,19 @@ type APIResponse struct {
 	Message string      `json:"message,omitempty"`
 }
 
+// ConfigScaffoldRequest represents a request to scaffold a default table configuration
+type ConfigScaffoldRequest struct {
+	ConnectionID    string `json:"connection_id" binding:"required"`
+	TableName       string `json:"table_name" binding:"required"`
+	CreateStatement string `json:"create_statement"`
+}
+
 // ConfigCreateRequest represents a request to create a new table configuration
 type ConfigCreateRequest struct {
 	Name            string `json:"name" binding:"required"`
 	TableName       string `json:"table_name" binding:"required"`
 	ConnectionID    string `json:"connection_id" binding:"required"`
-	CreateStatement string `json:"create_statement" binding:"required"`
+	CreateStatement string `json:"create_statement"`
 	Description     string `json:"description"`
 	Tags            string `json:"tags"`
 }
@@ -162,6 +288,7 @@ type DatabaseInfo struct {
 type TableInfo struct {
 	Name     string       `json:"name"`
 	Schema   string       `json:"schema"`
+	Comment  string       `json:"comment,omitempty"`
 	Columns  []ColumnInfo `json:"columns"`
 	RowCount int64        `json:"row_count"`
 }
@@ -174,6 +301,31 @@ type ColumnInfo struct {
 	DefaultValue    string `json:"default_value"`
 	IsPrimaryKey    bool   `json:"is_primary_key"`
 	IsAutoIncrement bool   `json:"is_auto_increment"`
+	Comment         string `json:"comment,omitempty"`
+}
+
+// DriftReport describes differences between a table configuration and the live table
+type DriftReport struct {
+	ConfigID         uint            `json:"config_id"`
+	ConfigName       string          `json:"config_name"`
+	TableName        string          `json:"table_name"`
+	HasDrift         bool            `json:"has_drift"`
+	MissingColumns   []MissingColumn `json:"missing_columns"`
+	TypeMismatches   []TypeMismatch  `json:"type_mismatches"`
+	UnexposedColumns []string        `json:"unexposed_columns"`
+}
+
+// MissingColumn is a configured column that does not exist in the live table
+type MissingColumn struct {
+	Field  string `json:"field"`
+	Source string `json:"source"` // display, search, sort, create, update, create_statement
+}
+
+// TypeMismatch is a column whose type in CreateStatement differs from the live table
+type TypeMismatch struct {
+	Field        string `json:"field"`
+	DeclaredType string `json:"declared_type"`
+	LiveType     string `json:"live_type"`
 }
 
 // PackageInfo contains metadata about the package
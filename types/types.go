@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 type SearchType string
 
 const (
@@ -10,6 +12,28 @@ const (
 	SearchTypeRange       SearchType = "range"
 	SearchTypeMultiSelect SearchType = "multi_select" // 多选
 	SearchTypeDateRange   SearchType = "date_range"   // 日期范围
+	SearchTypeFulltext    SearchType = "fulltext"     // 全文检索
+	SearchTypeContains    SearchType = "contains"     // 数组包含全部值
+	SearchTypeOverlaps    SearchType = "overlaps"     // 数组包含任一值
+)
+
+type FilterOperator string
+
+const (
+	FilterOpEq       FilterOperator = "eq"
+	FilterOpNe       FilterOperator = "ne"
+	FilterOpGt       FilterOperator = "gt"
+	FilterOpGte      FilterOperator = "gte"
+	FilterOpLt       FilterOperator = "lt"
+	FilterOpLte      FilterOperator = "lte"
+	FilterOpIn       FilterOperator = "in"
+	FilterOpNotIn    FilterOperator = "not_in"
+	FilterOpPrefix   FilterOperator = "prefix"
+	FilterOpSuffix   FilterOperator = "suffix"
+	FilterOpContains FilterOperator = "contains"
+	FilterOpIsNull   FilterOperator = "is_null"
+	FilterOpNotNull  FilterOperator = "not_null"
+	FilterOpBetween  FilterOperator = "between"
 )
 
 type SortOrder string
@@ -19,6 +43,16 @@ const (
 	SortOrderDESC SortOrder = "DESC"
 )
 
+type AggregateFunction string
+
+const (
+	AggregateCount AggregateFunction = "count"
+	AggregateSum   AggregateFunction = "sum"
+	AggregateAvg   AggregateFunction = "avg"
+	AggregateMin   AggregateFunction = "min"
+	AggregateMax   AggregateFunction = "max"
+)
+
 type PostgreSQLType string
 
 const (
@@ -37,30 +71,84 @@ const (
 	PostgreSQLTypeTime        PostgreSQLType = "time"
 	PostgreSQLTypeTimestamp   PostgreSQLType = "timestamp"
 	PostgreSQLTypeTimestampTZ PostgreSQLType = "timestamptz"
+	PostgreSQLTypeTimeTZ      PostgreSQLType = "timetz"
 	PostgreSQLTypeInterval    PostgreSQLType = "interval"
 	PostgreSQLTypeJSON        PostgreSQLType = "json"
 	PostgreSQLTypeJSONB       PostgreSQLType = "jsonb"
 	PostgreSQLTypeUUID        PostgreSQLType = "uuid"
 	PostgreSQLTypeArray       PostgreSQLType = "array"
+	PostgreSQLTypeEnum        PostgreSQLType = "enum"
+	PostgreSQLTypeCitext      PostgreSQLType = "citext"
+	PostgreSQLTypeInet        PostgreSQLType = "inet"
+	PostgreSQLTypeCidr        PostgreSQLType = "cidr"
+	PostgreSQLTypeMacaddr     PostgreSQLType = "macaddr"
+	PostgreSQLTypeMoney       PostgreSQLType = "money"
+	PostgreSQLTypeTSVector    PostgreSQLType = "tsvector"
+	PostgreSQLTypeBit         PostgreSQLType = "bit"
+	PostgreSQLTypeVarbit      PostgreSQLType = "varbit"
+	// PostgreSQLTypeUserDefined is used for enum types, domains and any other type
+	// the parser does not know; the original spelling is kept in TableField.RawType
+	PostgreSQLTypeUserDefined PostgreSQLType = "user_defined"
 )
 
 type SearchField struct {
 	Field      string     `json:"field" validate:"required"`
 	Type       SearchType `json:"type" validate:"required"`
 	DictSource string     `json:"dict_source,omitempty"` // 改为字符串类型，便于前端处理
+	// Columns are the columns searched by a fulltext field, Field names the search parameter then.
+	// Field itself is searched when Columns is empty.
+	Columns  []string `json:"columns,omitempty"`
+	Language string   `json:"language,omitempty"` // PostgreSQL text search configuration, "simple" by default
+	Rank     bool     `json:"rank,omitempty"`     // order by relevance when no sort is given
+}
+
+// FilterField lists the filter operators allowed on a field
+type FilterField struct {
+	Field     string           `json:"field" validate:"required"`
+	Operators []FilterOperator `json:"operators"`
+}
+
+// Filter is a condition such as filter[age][gte]=18. Value is a list (or a comma separated
+// string) for in, not_in and between, and is ignored by is_null and not_null.
+type Filter struct {
+	Field    string         `json:"field"`
+	Operator FilterOperator `json:"operator"`
+	Value    interface{}    `json:"value,omitempty"`
+}
+
+// FilterNode is a node of a boolean filter expression: a group with And, Or or Not children,
+// or a leaf filter when Field is set, e.g.
+// {"or": [{"field": "status", "operator": "eq", "value": "failed"}, {"and": [...]}]}
+type FilterNode struct {
+	And []FilterNode `json:"and,omitempty"`
+	Or  []FilterNode `json:"or,omitempty"`
+	Not *FilterNode  `json:"not,omitempty"`
+	Filter
 }
 
 type DisplayField struct {
-	Field      string `json:"field" validate:"required"`
-	Label      string `json:"label,omitempty"`
-	Width      int    `json:"width,omitempty"`
-	Sortable   bool   `json:"sortable,omitempty"`
-	Searchable bool   `json:"searchable,omitempty"`
+	Field      string     `json:"field" validate:"required"`
+	Label      string     `json:"label,omitempty"`
+	Width      int        `json:"width,omitempty"`
+	Sortable   bool       `json:"sortable,omitempty"`
+	Searchable bool       `json:"searchable,omitempty"`
+	Reference  *Reference `json:"reference,omitempty"` // shows the label of the referenced record next to the value
+}
+
+// Reference resolves a foreign key column to a label column of the referenced table. The table is
+// either the table of another configuration, whose connection and hidden fields apply, or a table
+// on the same connection.
+type Reference struct {
+	Config     string `json:"config,omitempty"`
+	Table      string `json:"table,omitempty"`
+	ValueField string `json:"value_field,omitempty"` // the referenced column, the primary key by default
+	LabelField string `json:"label_field" validate:"required"`
 }
 
 type CreatableField struct {
 	Field        string           `json:"field" validate:"required"`
 	Label        string           `json:"label,omitempty"`
+	HelpText     string           `json:"help_text,omitempty"`
 	Type         string           `json:"type,omitempty"` // input, select, textarea, date, etc.
 	Required     bool             `json:"required,omitempty"`
 	DefaultType  string           `json:"default_type,omitempty"`  // fixed, auto_increment, current_time, uuid
@@ -72,6 +160,7 @@ type CreatableField struct {
 type UpdatableField struct {
 	Field      string           `json:"field" validate:"required"`
 	Label      string           `json:"label,omitempty"`
+	HelpText   string           `json:"help_text,omitempty"`
 	Type       string           `json:"type,omitempty"`
 	Required   bool             `json:"required,omitempty"`
 	Validation *FieldValidation `json:"validation,omitempty"`
@@ -105,10 +194,37 @@ type DictSource struct {
 }
 
 type QueryConfig struct {
-	Pagination     bool           `json:"pagination"`
-	DisplayFields  []DisplayField `json:"display_fields,omitempty"`
-	SearchFields   []SearchField  `json:"search_fields,omitempty"`
-	SortableFields []string       `json:"sortable_fields,omitempty"`
+	Pagination     bool             `json:"pagination"`
+	DisplayFields  []DisplayField   `json:"display_fields,omitempty"`
+	SearchFields   []SearchField    `json:"search_fields,omitempty"`
+	SortableFields []string         `json:"sortable_fields,omitempty"`
+	DefaultSort    []SortField      `json:"default_sort,omitempty"` // applied when a query has no sort
+	FilterFields   []FilterField    `json:"filter_fields,omitempty"`
+	HiddenFields   []string         `json:"hidden_fields,omitempty"` // never selected, whatever the other settings
+	Relations      []Relation       `json:"relations,omitempty"`
+	Aggregate      *AggregateConfig `json:"aggregate,omitempty"`
+}
+
+// Relation lists the records of a child configuration whose foreign key holds the key of a record
+type Relation struct {
+	Name        string `json:"name" validate:"required"`
+	Label       string `json:"label,omitempty"`
+	Config      string `json:"config" validate:"required"`
+	ForeignKey  string `json:"foreign_key" validate:"required"`
+	ParentField string `json:"parent_field,omitempty"` // the parent column held by the foreign key, the primary key by default
+}
+
+// AggregateConfig whitelists the columns aggregate queries may group by and the metrics allowed
+// per column. Counting all records is always allowed.
+type AggregateConfig struct {
+	GroupBy []string      `json:"group_by,omitempty"`
+	Metrics []MetricField `json:"metrics,omitempty"`
+}
+
+// MetricField lists the aggregate functions allowed on a column
+type MetricField struct {
+	Field     string              `json:"field" validate:"required"`
+	Functions []AggregateFunction `json:"functions"`
 }
 
 type CreateConfig struct {
@@ -122,7 +238,8 @@ type UpdateConfig struct {
 
 type Config struct {
 	TableName       string        `json:"table_name" validate:"required"`
-	CreateStatement string        `json:"create_statement" validate:"required"`
+	CreateStatement string        `json:"create_statement"`
+	PrimaryKey      []string      `json:"primary_key,omitempty"` // overrides the primary key of the schema
 	QueryConfig     *QueryConfig  `json:"query_config,omitempty"`
 	CreateConfig    *CreateConfig `json:"create_config,omitempty"`
 	UpdateConfig    *UpdateConfig `json:"update_config,omitempty"`
@@ -133,6 +250,13 @@ type QueryParams struct {
 	PageSize int                    `json:"page_size,omitempty"`
 	Search   map[string]interface{} `json:"search,omitempty"`
 	Sort     []SortField            `json:"sort,omitempty"`
+	Filters  []Filter               `json:"filters,omitempty"`
+	Where    *FilterNode            `json:"where,omitempty"`  // ANDed with Search and Filters
+	Fields   []string               `json:"fields,omitempty"` // narrows the selected columns, the primary key is always kept
+	// CursorMode pages by the sort fields plus the primary key instead of OFFSET, starting after Cursor
+	CursorMode bool   `json:"cursor_mode,omitempty"`
+	Cursor     string `json:"cursor,omitempty"`
+	SkipCount  bool   `json:"skip_count,omitempty"`
 }
 
 type SortField struct {
@@ -146,24 +270,146 @@ type QueryResult struct {
 	Page       int                      `json:"page"`
 	PageSize   int                      `json:"page_size"`
 	TotalPages int                      `json:"total_pages"`
+	PrimaryKey []string                 `json:"primary_key,omitempty"`
+	NextCursor string                   `json:"next_cursor,omitempty"`
+	PrevCursor string                   `json:"prev_cursor,omitempty"`
+}
+
+// Metric is an aggregate function of a column, count without a column counts the records
+type Metric struct {
+	Function AggregateFunction `json:"function"`
+	Field    string            `json:"field,omitempty"`
+}
+
+type AggregateParams struct {
+	Search  map[string]interface{} `json:"search,omitempty"`
+	Filters []Filter               `json:"filters,omitempty"`
+	Where   *FilterNode            `json:"where,omitempty"`
+	GroupBy []string               `json:"group_by,omitempty"`
+	Metrics []Metric               `json:"metrics,omitempty"` // the record count when empty
+}
+
+// AggregateResult holds a row per group, keyed by the group-by columns and the metric keys
+// such as count and sum_amount. Without group-by columns there is a single row.
+type AggregateResult struct {
+	GroupBy []string                 `json:"group_by,omitempty"`
+	Metrics []string                 `json:"metrics"`
+	Rows    []map[string]interface{} `json:"rows"`
+}
+
+// FacetParams selects the facets to count, Search, Filters and Where work as in List.
+// All single and multi_select search fields with a dictionary are counted when Fields is empty.
+type FacetParams struct {
+	Search  map[string]interface{} `json:"search,omitempty"`
+	Filters []Filter               `json:"filters,omitempty"`
+	Where   *FilterNode            `json:"where,omitempty"`
+	Fields  []string               `json:"fields,omitempty"`
+}
+
+// FacetValue is a dictionary item with the number of matching records
+type FacetValue struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// Facet holds the counts of a search field, in dictionary order
+type Facet struct {
+	Field  string       `json:"field"`
+	Values []FacetValue `json:"values"`
+}
+
+type FacetResult struct {
+	Facets []Facet `json:"facets"`
+}
+
+// SavedView is a named set of list settings of a configuration. A private view is visible to its
+// owner only, a shared view to everyone.
+type SavedView struct {
+	ID         uint                   `json:"id"`
+	ConfigName string                 `json:"config_name"`
+	Name       string                 `json:"name"`
+	Owner      string                 `json:"owner"`
+	Shared     bool                   `json:"shared"`
+	Search     map[string]interface{} `json:"search,omitempty"`
+	Where      *FilterNode            `json:"where,omitempty"`
+	Sort       string                 `json:"sort,omitempty"`      // e.g. "status,-created_at"
+	Fields     []string               `json:"fields,omitempty"`    // all displayed fields when empty
+	PageSize   int                    `json:"page_size,omitempty"` // the default page size when 0
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
 }
 
 type TableField struct {
-	Name         string         `json:"name"`
-	Type         PostgreSQLType `json:"type"`
-	Length       int            `json:"length,omitempty"`
-	Precision    int            `json:"precision,omitempty"`
-	Scale        int            `json:"scale,omitempty"`
-	NotNull      bool           `json:"not_null"`
-	PrimaryKey   bool           `json:"primary_key"`
-	Unique       bool           `json:"unique"`
-	DefaultValue *string        `json:"default_value,omitempty"`
-	Comment      string         `json:"comment,omitempty"`
+	Name          string         `json:"name"`
+	Type          PostgreSQLType `json:"type"`
+	RawType       string         `json:"raw_type,omitempty"`
+	ElementType   PostgreSQLType `json:"element_type,omitempty"`
+	Length        int            `json:"length,omitempty"`
+	Precision     int            `json:"precision,omitempty"`
+	Scale         int            `json:"scale,omitempty"`
+	NotNull       bool           `json:"not_null"`
+	PrimaryKey    bool           `json:"primary_key"`
+	Unique        bool           `json:"unique"`
+	DefaultValue  *string        `json:"default_value,omitempty"`
+	Comment       string         `json:"comment,omitempty"`
+	AutoIncrement bool           `json:"auto_increment"`
+	ReadOnly      bool           `json:"read_only,omitempty"`
+	Unsigned      bool           `json:"unsigned,omitempty"`
+	EnumValues    []string       `json:"enum_values,omitempty"`
+}
+
+type ForeignKey struct {
+	Name              string   `json:"name,omitempty"`
+	Columns           []string `json:"columns"`
+	ReferencedTable   string   `json:"referenced_table"`
+	ReferencedColumns []string `json:"referenced_columns,omitempty"`
+	OnDelete          string   `json:"on_delete,omitempty"`
+	OnUpdate          string   `json:"on_update,omitempty"`
+}
+
+type CheckConstraint struct {
+	Name       string `json:"name,omitempty"`
+	Expression string `json:"expression"`
+}
+
+type Index struct {
+	Name    string   `json:"name,omitempty"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique,omitempty"`
 }
 
 type TableSchema struct {
-	TableName string       `json:"table_name"`
-	Fields    []TableField `json:"fields"`
+	TableName   string            `json:"table_name"`
+	Schema      string            `json:"schema,omitempty"`
+	Comment     string            `json:"comment,omitempty"`
+	Fields      []TableField      `json:"fields"`
+	PrimaryKey  []string          `json:"primary_key,omitempty"`
+	UniqueKeys  [][]string        `json:"unique_keys,omitempty"`
+	ForeignKeys []ForeignKey      `json:"foreign_keys,omitempty"`
+	Checks      []CheckConstraint `json:"checks,omitempty"`
+	Indexes     []Index           `json:"indexes,omitempty"`
+}
+
+type MissingColumn struct {
+	Field  string `json:"field"`
+	Source string `json:"source"` // display, search, sort, filter, create, update, create_statement
+}
+
+type TypeMismatch struct {
+	Field        string `json:"field"`
+	DeclaredType string `json:"declared_type"`
+	LiveType     string `json:"live_type"`
+}
+
+type DriftReport struct {
+	ConfigID         uint            `json:"config_id"`
+	ConfigName       string          `json:"config_name"`
+	TableName        string          `json:"table_name"`
+	HasDrift         bool            `json:"has_drift"`
+	MissingColumns   []MissingColumn `json:"missing_columns"`
+	TypeMismatches   []TypeMismatch  `json:"type_mismatches"`
+	UnexposedColumns []string        `json:"unexposed_columns"`
 }
 
 type DictItem struct {
@@ -57,6 +57,32 @@ document.addEventListener('DOMContentLoaded', function() {
             selectedConnectionId: '',
             saving: false,
             creating: false,
+            scaffolding: false,
+            // 列表接口 filter[字段][操作符] 支持的操作符
+            filterOperators: [
+                { value: 'eq', label: '等于' },
+                { value: 'ne', label: '不等于' },
+                { value: 'gt', label: '大于' },
+                { value: 'gte', label: '大于等于' },
+                { value: 'lt', label: '小于' },
+                { value: 'lte', label: '小于等于' },
+                { value: 'in', label: '属于' },
+                { value: 'not_in', label: '不属于' },
+                { value: 'prefix', label: '前缀' },
+                { value: 'suffix', label: '后缀' },
+                { value: 'contains', label: '包含' },
+                { value: 'is_null', label: '为空' },
+                { value: 'not_null', label: '不为空' },
+                { value: 'between', label: '区间' }
+            ],
+            aggregateFunctions: [
+                { value: 'count', label: '计数' },
+                { value: 'sum', label: '求和' },
+                { value: 'avg', label: '平均' },
+                { value: 'min', label: '最小' },
+                { value: 'max', label: '最大' }
+            ],
+            driftReports: {}, // 配置ID -> 表结构差异报告
             message: '',
             messageType: 'success',
             sqlFields: [], // 解析到的SQL字段
@@ -67,11 +93,19 @@ document.addEventListener('DOMContentLoaded', function() {
                 table_name: '',
                 connection_id: '',
                 create_statement: '',
+                primary_key: '',
+                query_default_sort: '',
+                description: '',
                 query_pagination: true,
                 displayFields: [],
                 searchFields: [],
                 creatableFields: [],
-                updatableFields: []
+                updatableFields: [],
+                filterFields: [],
+                hiddenFields: [],
+                relations: [],
+                aggregateGroupBy: [],
+                aggregateMetrics: []
             },
             dragState: {
                 draggedIndex: null,
@@ -183,6 +217,50 @@ document.addEventListener('DOMContentLoaded', function() {
             }
         },
         
+        // 未填写建表语句时，从数据库读取表结构
+        async loadTableFields(target) {
+            const config = target === 'selectedConfig' ? this.selectedConfig : this.newConfig;
+            if (!config || !config.connection_id || !config.table_name) {
+                alert('请先选择数据库连接并填写表名');
+                return;
+            }
+            
+            try {
+                const response = await axios.get(ConfigManager.getApiUrl(
+                    `/connections/${config.connection_id}/tables/${encodeURIComponent(config.table_name)}`
+                ));
+                const columns = response.data.data.columns || [];
+                const fields = columns.map(column => ({
+                    name: column.name.toLowerCase(),
+                    type: column.type.toLowerCase().replace(/\s+/g, ''),
+                    autoIncrement: column.is_auto_increment,
+                    comment: column.comment || ''
+                }));
+                
+                // 表注释作为配置说明的默认值
+                if (!config.description && response.data.data.comment) {
+                    config.description = response.data.data.comment;
+                }
+                
+                if (target === 'selectedConfig') {
+                    this.sqlFields = fields;
+                    this.initializeFieldConfigurations();
+                    this.validateFields();
+                } else {
+                    this.newSqlFields = fields;
+                    this.initializeNewConfigFields();
+                }
+            } catch (error) {
+                const errorMessage = '读取表结构失败: ' + (error.response?.data?.error || error.message);
+                if (target === 'selectedConfig') {
+                    this.message = errorMessage;
+                    this.messageType = 'error';
+                } else {
+                    alert(errorMessage);
+                }
+            }
+        },
+        
         // 解析SQL建表语句，提取字段信息
         parseSQLFields(sql) {
             if (!sql) return [];
@@ -214,12 +292,25 @@ document.addEventListener('DOMContentLoaded', function() {
                     // 匹配字段名和类型，改进正则表达式以处理更复杂的定义
                     const fieldMatch = trimmed.match(/^(\w+)\s+(\w+(?:\s*\([^)]*\))?)/i);
                     if (fieldMatch) {
+                        // MySQL 行内注释 COMMENT '...'
+                        const commentMatch = trimmed.match(/\scomment\s+'((?:[^'\\]|''|\\.)*)'/i);
                         fields.push({
                             name: fieldMatch[1].toLowerCase(),
-                            type: fieldMatch[2].toLowerCase().replace(/\s+/g, '')
+                            type: fieldMatch[2].toLowerCase().replace(/\s+/g, ''),
+                            comment: commentMatch ? commentMatch[1].replace(/''/g, "'") : ''
                         });
                     }
                 });
+                
+                // PostgreSQL 的 COMMENT ON COLUMN [schema.]table.column IS '...'
+                const columnCommentRegex = /comment\s+on\s+column\s+(?:[\w"]+\.)*"?(\w+)"?\s+is\s+'((?:[^']|'')*)'/gi;
+                let columnComment;
+                while ((columnComment = columnCommentRegex.exec(sql)) !== null) {
+                    const field = fields.find(f => f.name === columnComment[1].toLowerCase());
+                    if (field) {
+                        field.comment = columnComment[2].replace(/''/g, "'");
+                    }
+                }
             } catch (error) {
                 console.warn('SQL解析失败:', error);
             }
@@ -254,6 +345,29 @@ document.addEventListener('DOMContentLoaded', function() {
             return result;
         },
         
+        // 是否为 JSON 列，JSON 列可以填写路径展示或搜索其中的值
+        isJsonColumn(fieldName) {
+            if (!fieldName || !Array.isArray(this.sqlFields)) return false;
+            const sqlField = this.sqlFields.find(field => field && field.name === fieldName.toLowerCase());
+            return !!sqlField && sqlField.type.includes('json');
+        },
+        
+        // 把 attrs.color、meta->tags 形式的字段拆分为列和 JSON 路径，便于编辑
+        splitJsonPath(field) {
+            if (!field || typeof field.field !== 'string') return field;
+            const separator = field.field.includes('->') ? '->' : '.';
+            const parts = field.field.replace(/->>/g, '->').split(separator);
+            if (parts.length < 2) return field;
+            return { ...field, field: parts[0], json_path: parts.slice(1).join('.') };
+        },
+        
+        // 合并列和 JSON 路径
+        joinJsonPath(field) {
+            const { json_path, ...rest } = field;
+            const path = (json_path || '').trim().replace(/^\.+|\.+$/g, '');
+            return path ? { ...rest, field: rest.field + '.' + path } : rest;
+        },
+        
         // 验证字段是否有效
         isValidField(fieldName) {
             if (!fieldName) return true; // 空字段不算错误
@@ -285,7 +399,14 @@ document.addEventListener('DOMContentLoaded', function() {
             // 检查搜索字段
             if (Array.isArray(this.selectedConfig.searchFields)) {
                 this.selectedConfig.searchFields.forEach((field, index) => {
-                    if (field && field.field && !this.isValidField(field.field)) {
+                    // 全文检索字段的 field 是参数名，检查检索的列
+                    if (field && field.type === 'fulltext') {
+                        (field.columns || []).forEach(column => {
+                            if (!this.isValidField(column)) {
+                                this.validationErrors.push(`全文检索列 ${column} 不存在`);
+                            }
+                        });
+                    } else if (field && field.field && !this.isValidField(field.field)) {
                         this.validationErrors.push(`搜索字段 ${field.field} 不存在`);
                     }
                 });
@@ -426,7 +547,7 @@ document.addEventListener('DOMContentLoaded', function() {
                 try {
                     const parsed = JSON.parse(this.selectedConfig.query_display_fields);
                     if (Array.isArray(parsed)) {
-                        this.selectedConfig.displayFields = parsed;
+                        this.selectedConfig.displayFields = parsed.map(field => this.splitJsonPath(field));
                     }
                 } catch (e) {
                     console.warn('展示字段JSON解析失败:', e);
@@ -441,13 +562,82 @@ document.addEventListener('DOMContentLoaded', function() {
                 try {
                     const parsed = JSON.parse(this.selectedConfig.query_search_fields);
                     if (Array.isArray(parsed)) {
-                        this.selectedConfig.searchFields = parsed;
+                        // 全文检索字段的 field 是参数名，不拆分
+                        this.selectedConfig.searchFields = parsed.map(field => field.type === 'fulltext' ? field : this.splitJsonPath(field));
                     }
                 } catch (e) {
                     console.warn('搜索字段JSON解析失败:', e);
                 }
             }
             
+            // 处理过滤字段
+            if (!Array.isArray(this.selectedConfig.filterFields)) {
+                this.selectedConfig.filterFields = [];
+            }
+            if (this.selectedConfig.query_filter_fields) {
+                try {
+                    const parsed = JSON.parse(this.selectedConfig.query_filter_fields);
+                    if (Array.isArray(parsed)) {
+                        this.selectedConfig.filterFields = parsed.map(field => ({
+                            field: field.field,
+                            operators: field.operators || []
+                        }));
+                    }
+                } catch (e) {
+                    console.warn('过滤字段JSON解析失败:', e);
+                }
+            }
+            
+            // 处理隐藏字段
+            if (!Array.isArray(this.selectedConfig.hiddenFields)) {
+                this.selectedConfig.hiddenFields = [];
+            }
+            if (this.selectedConfig.query_hidden_fields) {
+                try {
+                    const parsed = JSON.parse(this.selectedConfig.query_hidden_fields);
+                    if (Array.isArray(parsed)) {
+                        this.selectedConfig.hiddenFields = parsed;
+                    }
+                } catch (e) {
+                    console.warn('隐藏字段JSON解析失败:', e);
+                }
+            }
+            
+            // 处理子表关系
+            if (!Array.isArray(this.selectedConfig.relations)) {
+                this.selectedConfig.relations = [];
+            }
+            if (this.selectedConfig.query_relations) {
+                try {
+                    const parsed = JSON.parse(this.selectedConfig.query_relations);
+                    if (Array.isArray(parsed)) {
+                        this.selectedConfig.relations = parsed;
+                    }
+                } catch (e) {
+                    console.warn('子表关系JSON解析失败:', e);
+                }
+            }
+            
+            // 处理统计配置
+            if (!Array.isArray(this.selectedConfig.aggregateGroupBy)) {
+                this.selectedConfig.aggregateGroupBy = [];
+            }
+            if (!Array.isArray(this.selectedConfig.aggregateMetrics)) {
+                this.selectedConfig.aggregateMetrics = [];
+            }
+            if (this.selectedConfig.query_aggregate) {
+                try {
+                    const parsed = JSON.parse(this.selectedConfig.query_aggregate);
+                    this.selectedConfig.aggregateGroupBy = parsed.group_by || [];
+                    this.selectedConfig.aggregateMetrics = (parsed.metrics || []).map(metric => ({
+                        field: metric.field,
+                        functions: metric.functions || []
+                    }));
+                } catch (e) {
+                    console.warn('统计配置JSON解析失败:', e);
+                }
+            }
+            
             // 处理可创建字段
             if (!Array.isArray(this.selectedConfig.creatableFields)) {
                 this.selectedConfig.creatableFields = [];
@@ -495,7 +685,26 @@ document.addEventListener('DOMContentLoaded', function() {
             
             // 转换展示字段
             if (cleaned.displayFields && Array.isArray(cleaned.displayFields)) {
-                const validFields = cleaned.displayFields.filter(field => field.field && field.field.trim());
+                const validFields = cleaned.displayFields
+                    .filter(field => field.field && field.field.trim())
+                    .map(field => this.joinJsonPath(field))
+                    .map(field => {
+                        // 关联配置只保留填写的项，未填写标签字段时不关联
+                        if (!field.reference) {
+                            return field;
+                        }
+                        const { reference, ...rest } = field;
+                        if (!reference.label_field || !(reference.config || reference.table)) {
+                            return rest;
+                        }
+                        const cleanedReference = {};
+                        ['config', 'table', 'value_field', 'label_field'].forEach(key => {
+                            if (reference[key] && reference[key].trim()) {
+                                cleanedReference[key] = reference[key].trim();
+                            }
+                        });
+                        return { ...rest, reference: cleanedReference };
+                    });
                 cleaned.query_display_fields = validFields.length > 0 ? JSON.stringify(validFields) : '';
                 
                 // 从展示字段中提取可排序字段
@@ -505,10 +714,63 @@ document.addEventListener('DOMContentLoaded', function() {
             
             // 转换搜索字段
             if (cleaned.searchFields && Array.isArray(cleaned.searchFields)) {
-                const validFields = cleaned.searchFields.filter(field => field.field && field.field.trim());
+                const validFields = cleaned.searchFields.filter(field => field.field && field.field.trim()).map(field => {
+                    // 只有全文检索字段保留检索列、分词配置和相关度排序
+                    if (field.type === 'fulltext') {
+                        const { json_path, ...fulltext } = field;
+                        return fulltext;
+                    }
+                    const { columns, language, rank, ...rest } = this.joinJsonPath(field);
+                    return rest;
+                });
                 cleaned.query_search_fields = validFields.length > 0 ? JSON.stringify(validFields) : '';
             }
             
+            // 转换过滤字段
+            if (cleaned.filterFields && Array.isArray(cleaned.filterFields)) {
+                const validFields = cleaned.filterFields.filter(field => field.field && field.field.trim() && field.operators.length > 0);
+                cleaned.query_filter_fields = validFields.length > 0 ? JSON.stringify(validFields) : '';
+            }
+            
+            // 转换隐藏字段
+            if (cleaned.hiddenFields && Array.isArray(cleaned.hiddenFields)) {
+                cleaned.query_hidden_fields = cleaned.hiddenFields.length > 0 ? JSON.stringify(cleaned.hiddenFields) : '';
+            }
+            
+            // 转换子表关系，未填写的可选项不保存
+            if (cleaned.relations && Array.isArray(cleaned.relations)) {
+                const validRelations = cleaned.relations
+                    .filter(relation => relation.name && relation.name.trim() && relation.config && relation.foreign_key && relation.foreign_key.trim())
+                    .map(relation => {
+                        const cleanedRelation = {
+                            name: relation.name.trim(),
+                            config: relation.config,
+                            foreign_key: relation.foreign_key.trim()
+                        };
+                        if (relation.label && relation.label.trim()) {
+                            cleanedRelation.label = relation.label.trim();
+                        }
+                        if (relation.parent_field) {
+                            cleanedRelation.parent_field = relation.parent_field;
+                        }
+                        return cleanedRelation;
+                    });
+                cleaned.query_relations = validRelations.length > 0 ? JSON.stringify(validRelations) : '';
+            }
+            
+            // 转换统计配置
+            if (cleaned.aggregateGroupBy || cleaned.aggregateMetrics) {
+                const aggregate = {};
+                if (cleaned.aggregateGroupBy && cleaned.aggregateGroupBy.length > 0) {
+                    aggregate.group_by = cleaned.aggregateGroupBy;
+                }
+                const validMetrics = (cleaned.aggregateMetrics || []).filter(metric => metric.field && metric.functions.length > 0);
+                if (validMetrics.length > 0) {
+                    aggregate.metrics = validMetrics;
+                }
+                cleaned.query_aggregate = Object.keys(aggregate).length > 0 ? JSON.stringify(aggregate) : '';
+            }
+            
             // 转换可创建字段
             if (cleaned.creatableFields && Array.isArray(cleaned.creatableFields)) {
                 const validFields = cleaned.creatableFields.filter(field => field.field && field.field.trim());
@@ -531,6 +793,11 @@ document.addEventListener('DOMContentLoaded', function() {
             delete cleaned.searchFields;
             delete cleaned.creatableFields;
             delete cleaned.updatableFields;
+            delete cleaned.filterFields;
+            delete cleaned.hiddenFields;
+            delete cleaned.relations;
+            delete cleaned.aggregateGroupBy;
+            delete cleaned.aggregateMetrics;
             
             return cleaned;
         },
@@ -548,11 +815,45 @@ document.addEventListener('DOMContentLoaded', function() {
             try {
                 const response = await axios.get(ConfigManager.getApiUrl('/configs'));
                 this.configs = response.data.data;
+                this.loadDriftReports();
             } catch (error) {
                 console.error('Failed to load configs:', error);
             }
         },
         
+        // 加载每个配置与数据库实时表结构的差异报告
+        async loadDriftReports() {
+            const reports = {};
+            await Promise.allSettled((this.configs || []).map(async config => {
+                try {
+                    const response = await axios.get(ConfigManager.getApiUrl('/configs/' + config.id + '/drift'));
+                    reports[config.id] = response.data.data;
+                } catch (error) {
+                    reports[config.id] = { error: error.response?.data?.error || error.message };
+                }
+            }));
+            this.driftReports = reports;
+        },
+        
+        // 差异报告的提示文本
+        driftSummary(configId) {
+            const report = this.driftReports[configId];
+            if (!report) return '';
+            if (report.error) return '无法检测表结构: ' + report.error;
+            
+            const lines = [];
+            if (report.missing_columns.length > 0) {
+                lines.push('字段已不存在: ' + report.missing_columns.map(column => `${column.field} (${column.source})`).join(', '));
+            }
+            if (report.type_mismatches.length > 0) {
+                lines.push('类型不一致: ' + report.type_mismatches.map(mismatch => `${mismatch.field} ${mismatch.declared_type} → ${mismatch.live_type}`).join(', '));
+            }
+            if (report.unexposed_columns.length > 0) {
+                lines.push('未暴露的新字段: ' + report.unexposed_columns.join(', '));
+            }
+            return lines.join('\n');
+        },
+        
         selectConfig(config) {
             this.selectedConfig = {...config};
             this.message = '';
@@ -562,6 +863,11 @@ document.addEventListener('DOMContentLoaded', function() {
             this.selectedConfig.searchFields = this.selectedConfig.searchFields || [];
             this.selectedConfig.creatableFields = this.selectedConfig.creatableFields || [];
             this.selectedConfig.updatableFields = this.selectedConfig.updatableFields || [];
+            this.selectedConfig.filterFields = this.selectedConfig.filterFields || [];
+            this.selectedConfig.hiddenFields = this.selectedConfig.hiddenFields || [];
+            this.selectedConfig.relations = this.selectedConfig.relations || [];
+            this.selectedConfig.aggregateGroupBy = this.selectedConfig.aggregateGroupBy || [];
+            this.selectedConfig.aggregateMetrics = this.selectedConfig.aggregateMetrics || [];
             
             // 自动格式化SQL语句
             if (this.selectedConfig.create_statement) {
@@ -574,6 +880,11 @@ document.addEventListener('DOMContentLoaded', function() {
             // 解析SQL字段
             this.sqlFields = this.parseSQLFields(this.selectedConfig.create_statement);
             
+            // 未填写建表语句时从数据库读取字段
+            if (!this.selectedConfig.create_statement && this.selectedConfig.connection_id && this.selectedConfig.table_name) {
+                this.loadTableFields('selectedConfig');
+            }
+            
             // 初始化字段配置，确保包含所有字段
             this.initializeFieldConfigurations();
             
@@ -630,6 +941,47 @@ document.addEventListener('DOMContentLoaded', function() {
             }
         },
         
+        // 根据表结构生成默认的展示、搜索、创建和更新字段配置
+        async scaffoldNewConfig() {
+            if (!this.newConfig.connection_id || !this.newConfig.table_name) {
+                alert('请先选择数据库连接并填写表名');
+                return;
+            }
+            
+            this.scaffolding = true;
+            try {
+                const response = await axios.post(ConfigManager.getApiUrl('/configs/scaffold'), {
+                    connection_id: this.newConfig.connection_id,
+                    table_name: this.newConfig.table_name,
+                    create_statement: this.newConfig.create_statement
+                });
+                const scaffold = response.data.data;
+                const parseFields = (value) => {
+                    try {
+                        return value ? JSON.parse(value) : [];
+                    } catch (e) {
+                        return [];
+                    }
+                };
+                
+                if (!this.newConfig.name) {
+                    this.newConfig.name = scaffold.name;
+                }
+                if (!this.newConfig.description) {
+                    this.newConfig.description = scaffold.description;
+                }
+                this.newConfig.query_pagination = scaffold.query_pagination;
+                this.newConfig.displayFields = parseFields(scaffold.query_display_fields);
+                this.newConfig.searchFields = parseFields(scaffold.query_search_fields);
+                this.newConfig.creatableFields = parseFields(scaffold.create_creatable_fields);
+                this.newConfig.updatableFields = parseFields(scaffold.update_updatable_fields);
+            } catch (error) {
+                alert('生成配置失败: ' + (error.response?.data?.error || error.message));
+            } finally {
+                this.scaffolding = false;
+            }
+        },
+        
         async deleteConfig() {
             if (!this.selectedConfig || !confirm('确定要删除这个配置吗？')) return;
             
@@ -693,11 +1045,19 @@ document.addEventListener('DOMContentLoaded', function() {
                 table_name: '',
                 connection_id: '',
                 create_statement: '',
+                primary_key: '',
+                query_default_sort: '',
+                description: '',
                 query_pagination: true,
                 displayFields: [],
                 searchFields: [],
                 creatableFields: [],
-                updatableFields: []
+                updatableFields: [],
+                filterFields: [],
+                hiddenFields: [],
+                relations: [],
+                aggregateGroupBy: [],
+                aggregateMetrics: []
             };
         },
         
@@ -729,7 +1089,7 @@ document.addEventListener('DOMContentLoaded', function() {
             if (!this.selectedConfig.displayFields || this.selectedConfig.displayFields.length === 0) {
                 this.selectedConfig.displayFields = this.sqlFields.map(field => ({
                     field: field.name,
-                    label: this.getFieldLabel(field.name),
+                    label: this.getFieldLabel(field.name, field.comment),
                     width: null,
                     sortable: field.name !== 'id', // id字段默认不可排序
                     searchable: false
@@ -741,7 +1101,7 @@ document.addEventListener('DOMContentLoaded', function() {
                     .filter(field => this.isSearchableType(field.type))
                     .map(field => ({
                         field: field.name,
-                        label: this.getFieldLabel(field.name),
+                        label: this.getFieldLabel(field.name, field.comment),
                         type: this.getDefaultSearchType(field.type),
                         dict_source: '',
                         dict_source_type: '' // 新增字段
@@ -770,7 +1130,8 @@ document.addEventListener('DOMContentLoaded', function() {
             if (!this.selectedConfig.creatableFields || this.selectedConfig.creatableFields.length === 0) {
                 this.selectedConfig.creatableFields = this.sqlFields.map(field => ({
                     field: field.name,
-                    label: this.getFieldLabel(field.name),
+                    label: this.getFieldLabel(field.name, field.comment),
+                    help_text: this.getFieldHelpText(field.name, field.comment),
                     type: this.getDefaultInputType(field.type),
                     required: field.name !== 'id', // id字段默认不必填
                     user_readonly: false, // 默认都是可以编辑的
@@ -786,7 +1147,8 @@ document.addEventListener('DOMContentLoaded', function() {
                 missingFields.forEach(field => {
                     this.selectedConfig.creatableFields.push({
                         field: field.name,
-                        label: this.getFieldLabel(field.name),
+                        label: this.getFieldLabel(field.name, field.comment),
+                        help_text: this.getFieldHelpText(field.name, field.comment),
                         type: this.getDefaultInputType(field.type),
                         required: field.name !== 'id',
                         user_readonly: false, // 默认都是可以编辑的
@@ -817,7 +1179,8 @@ document.addEventListener('DOMContentLoaded', function() {
                 
                 this.selectedConfig.updatableFields = sortedFields.map(field => ({
                     field: field.name,
-                    label: this.getFieldLabel(field.name),
+                    label: this.getFieldLabel(field.name, field.comment),
+                    help_text: this.getFieldHelpText(field.name, field.comment),
                     type: this.getDefaultInputType(field.type),
                     required: false,
                     is_primary_key: field.name === 'id', // 标记主键字段
@@ -850,7 +1213,8 @@ document.addEventListener('DOMContentLoaded', function() {
                 missingFields.forEach(field => {
                     this.selectedConfig.updatableFields.push({
                         field: field.name,
-                        label: this.getFieldLabel(field.name),
+                        label: this.getFieldLabel(field.name, field.comment),
+                        help_text: this.getFieldHelpText(field.name, field.comment),
                         type: this.getDefaultInputType(field.type),
                         required: false,
                         is_primary_key: field.name === 'id',
@@ -876,7 +1240,7 @@ document.addEventListener('DOMContentLoaded', function() {
             
             this.newConfig.displayFields = this.newSqlFields.map(field => ({
                 field: field.name,
-                label: this.getFieldLabel(field.name),
+                label: this.getFieldLabel(field.name, field.comment),
                 width: null,
                 sortable: field.name !== 'id',
                 searchable: false
@@ -886,7 +1250,7 @@ document.addEventListener('DOMContentLoaded', function() {
                 .filter(field => this.isSearchableType(field.type))
                 .map(field => ({
                     field: field.name,
-                    label: this.getFieldLabel(field.name),
+                    label: this.getFieldLabel(field.name, field.comment),
                     type: this.getDefaultSearchType(field.type),
                     dict_source: '',
                     dict_source_type: '' // 新增字段
@@ -894,7 +1258,8 @@ document.addEventListener('DOMContentLoaded', function() {
                 
             this.newConfig.creatableFields = this.newSqlFields.map(field => ({
                 field: field.name,
-                label: this.getFieldLabel(field.name),
+                label: this.getFieldLabel(field.name, field.comment),
+                help_text: this.getFieldHelpText(field.name, field.comment),
                 type: this.getDefaultInputType(field.type),
                 required: field.name !== 'id',
                 user_readonly: false, // 默认都是可以编辑的
@@ -909,7 +1274,8 @@ document.addEventListener('DOMContentLoaded', function() {
             
             this.newConfig.updatableFields = sortedFields.map(field => ({
                 field: field.name,
-                label: this.getFieldLabel(field.name),
+                label: this.getFieldLabel(field.name, field.comment),
+                help_text: this.getFieldHelpText(field.name, field.comment),
                 type: this.getDefaultInputType(field.type),
                 required: false,
                 is_primary_key: field.name === 'id',
@@ -919,8 +1285,13 @@ document.addEventListener('DOMContentLoaded', function() {
             }));
         },
         
-        // 获取字段标签（中文化处理）
-        getFieldLabel(fieldName) {
+        // 获取字段标签，优先使用字段注释的第一句（中文化处理）
+        getFieldLabel(fieldName, comment) {
+            const commentLabel = (comment || '').split(/[:：,，;；(（\n]/)[0].trim();
+            if (commentLabel) {
+                return commentLabel;
+            }
+            
             const labelMap = {
                 'id': 'ID',
                 'name': '姓名',
@@ -934,6 +1305,12 @@ document.addEventListener('DOMContentLoaded', function() {
             return labelMap[fieldName] || fieldName;
         },
         
+        // 注释比标签包含更多信息时作为表单帮助文本
+        getFieldHelpText(fieldName, comment) {
+            const text = (comment || '').trim();
+            return text && text !== this.getFieldLabel(fieldName, comment) ? text : '';
+        },
+        
         // 判断字段类型是否可搜索
         isSearchableType(sqlType) {
             const type = sqlType.toLowerCase();
@@ -944,6 +1321,12 @@ document.addEventListener('DOMContentLoaded', function() {
         
         // 检查字段是否支持自增
         isAutoIncrementSupported(fieldName) {
+            // 从数据库读取的字段直接带有自增标记
+            const sqlField = this.sqlFields.find(field => field.name === fieldName);
+            if (sqlField && sqlField.autoIncrement) {
+                return true;
+            }
+            
             if (!this.selectedConfig || !this.selectedConfig.create_statement) {
                 return false;
             }
@@ -974,12 +1357,16 @@ document.addEventListener('DOMContentLoaded', function() {
         // 获取默认输入类型
         getDefaultInputType(sqlType) {
             const type = sqlType.toLowerCase();
-            if (type.includes('int') || type.includes('numeric') || type.includes('decimal')) {
+            if (type.includes('[]') || type.includes('array')) {
+                return 'tags';
+            } else if (type.includes('int') || type.includes('numeric') || type.includes('decimal')) {
                 return 'number';
             } else if (type.includes('date')) {
                 return 'date';
             } else if (type.includes('timestamp')) {
                 return 'datetime';
+            } else if (type.includes('json')) {
+                return 'json';
             } else if (type.includes('text')) {
                 return 'textarea';
             } else {
@@ -1044,6 +1431,15 @@ document.addEventListener('DOMContentLoaded', function() {
             });
         },
         
+        // 切换展示字段的关联配置
+        toggleReference(field) {
+            if (field.reference) {
+                delete field.reference;
+            } else {
+                field.reference = { config: '', table: '', value_field: '', label_field: '' };
+            }
+        },
+        
         // 删除展示字段
         removeDisplayField(index) {
             if (this.selectedConfig.displayFields && index >= 0 && index < this.selectedConfig.displayFields.length) {
@@ -1051,6 +1447,63 @@ document.addEventListener('DOMContentLoaded', function() {
             }
         },
         
+        // 添加过滤字段
+        addFilterField() {
+            if (!this.selectedConfig.filterFields) {
+                this.selectedConfig.filterFields = [];
+            }
+            this.selectedConfig.filterFields.push({
+                field: '',
+                operators: ['eq']
+            });
+        },
+        
+        // 删除过滤字段
+        removeFilterField(index) {
+            if (this.selectedConfig.filterFields && index >= 0 && index < this.selectedConfig.filterFields.length) {
+                this.selectedConfig.filterFields.splice(index, 1);
+            }
+        },
+        
+        // 添加统计指标字段
+        addAggregateMetric() {
+            if (!this.selectedConfig.aggregateMetrics) {
+                this.selectedConfig.aggregateMetrics = [];
+            }
+            this.selectedConfig.aggregateMetrics.push({
+                field: '',
+                functions: ['sum']
+            });
+        },
+        
+        // 删除统计指标字段
+        removeAggregateMetric(index) {
+            if (this.selectedConfig.aggregateMetrics && index >= 0 && index < this.selectedConfig.aggregateMetrics.length) {
+                this.selectedConfig.aggregateMetrics.splice(index, 1);
+            }
+        },
+        
+        // 添加子表关系
+        addRelation() {
+            if (!this.selectedConfig.relations) {
+                this.selectedConfig.relations = [];
+            }
+            this.selectedConfig.relations.push({
+                name: '',
+                label: '',
+                config: '',
+                foreign_key: '',
+                parent_field: ''
+            });
+        },
+        
+        // 删除子表关系
+        removeRelation(index) {
+            if (this.selectedConfig.relations && index >= 0 && index < this.selectedConfig.relations.length) {
+                this.selectedConfig.relations.splice(index, 1);
+            }
+        },
+        
         // 添加搜索字段
         addSearchField() {
             if (!this.selectedConfig.searchFields) {
@@ -1061,7 +1514,8 @@ document.addEventListener('DOMContentLoaded', function() {
                 label: '',
                 type: 'fuzzy',
                 dict_source: '',
-                dict_source_type: '' // 新增字典来源类型字段
+                dict_source_type: '', // 新增字典来源类型字段
+                columns: [] // 全文检索的列
             });
         },
         
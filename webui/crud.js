@@ -1,4 +1,4 @@
-const { createApp } = Vue;
+const { createApp, toRaw } = Vue;
 
 // 创建专用的axios实例
 // 全局配置管理器（和 app.js 保持一致）
@@ -29,7 +29,525 @@ const ConfigManager = {
 
 const crudAxios = axios.create();
 
+// 过滤操作符的显示名称（和 app.js 保持一致）
+const FILTER_OPERATOR_LABELS = {
+    eq: '等于', ne: '不等于', gt: '大于', gte: '大于等于', lt: '小于', lte: '小于等于',
+    in: '属于', not_in: '不属于', prefix: '前缀', suffix: '后缀', contains: '包含',
+    is_null: '为空', not_null: '不为空', between: '区间'
+};
+
+// 统计函数的显示名称（和 app.js 保持一致）
+const AGGREGATE_FUNCTION_LABELS = {
+    count: '计数', sum: '求和', avg: '平均', min: '最小', max: '最大'
+};
+
+// 高级筛选的条件组，组内条件按 AND / OR 组合，可嵌套子组并取反
+const FilterGroup = {
+    name: 'FilterGroup',
+    props: ['group', 'fields', 'root'],
+    emits: ['remove'],
+    template: `
+        <div class="filter-group border rounded p-2 mb-2" :class="{ 'bg-white': !root }">
+            <div class="d-flex align-items-center mb-2">
+                <select v-model="group.type" class="form-select form-select-sm w-auto me-2">
+                    <option value="and">满足全部 (AND)</option>
+                    <option value="or">满足任一 (OR)</option>
+                </select>
+                <div class="form-check me-auto">
+                    <input v-model="group.not" class="form-check-input" type="checkbox">
+                    <label class="form-check-label small">取反 (NOT)</label>
+                </div>
+                <button type="button" class="btn btn-sm btn-outline-primary me-1" @click="addCondition">
+                    <i class="bi bi-plus"></i> 条件
+                </button>
+                <button type="button" class="btn btn-sm btn-outline-primary me-1" @click="addGroup">
+                    <i class="bi bi-diagram-3"></i> 条件组
+                </button>
+                <button v-if="!root" type="button" class="btn btn-sm btn-outline-danger" @click="$emit('remove')">
+                    <i class="bi bi-trash"></i>
+                </button>
+            </div>
+            <div v-for="(item, index) in group.items" :key="index" class="ms-3">
+                <filter-group v-if="item.kind === 'group'" :group="item" :fields="fields" @remove="group.items.splice(index, 1)"></filter-group>
+                <div v-else class="row g-2 mb-2 align-items-center">
+                    <div class="col-md-3">
+                        <select v-model="item.field" class="form-select form-select-sm" @change="item.operator = operatorsOf(item.field)[0] || ''">
+                            <option value="" disabled>选择字段</option>
+                            <option v-for="field in fields" :key="field.field" :value="field.field">{{ field.field }}</option>
+                        </select>
+                    </div>
+                    <div class="col-md-3">
+                        <select v-model="item.operator" class="form-select form-select-sm">
+                            <option v-for="operator in operatorsOf(item.field)" :key="operator" :value="operator">{{ operatorLabel(operator) }}</option>
+                        </select>
+                    </div>
+                    <div class="col-md-5">
+                        <input v-if="item.operator !== 'is_null' && item.operator !== 'not_null'" v-model="item.value"
+                               type="text" class="form-control form-control-sm"
+                               :placeholder="['in', 'not_in', 'between'].includes(item.operator) ? '多个值用逗号分隔' : '值'">
+                    </div>
+                    <div class="col-md-1">
+                        <button type="button" class="btn btn-sm btn-outline-danger" @click="group.items.splice(index, 1)">
+                            <i class="bi bi-x"></i>
+                        </button>
+                    </div>
+                </div>
+            </div>
+        </div>
+    `,
+    methods: {
+        operatorsOf(fieldName) {
+            const field = this.fields.find(field => field.field === fieldName);
+            return field ? field.operators : [];
+        },
+        operatorLabel(operator) {
+            return FILTER_OPERATOR_LABELS[operator] || operator;
+        },
+        addCondition() {
+            const field = this.fields[0];
+            this.group.items.push({ kind: 'condition', field: field ? field.field : '', operator: field ? field.operators[0] : '', value: '' });
+        },
+        addGroup() {
+            this.group.items.push({ kind: 'group', type: 'and', not: false, items: [] });
+        }
+    }
+};
+
+// 结构化 JSON 编辑器：对象按键值逐行编辑，数组或嵌套结构切换为源码编辑
+const JsonEditor = {
+    name: 'JsonEditor',
+    props: ['modelValue'],
+    emits: ['update:modelValue'],
+    template: `
+        <div class="json-editor border rounded p-2">
+            <div class="d-flex align-items-center mb-2">
+                <small class="text-muted me-auto">{{ rawMode ? 'JSON 源码' : '键值编辑' }}</small>
+                <button type="button" class="btn btn-sm btn-outline-secondary" @click="toggleMode">
+                    {{ rawMode ? '按键值编辑' : '编辑源码' }}
+                </button>
+            </div>
+            <textarea v-if="rawMode" v-model="raw" class="form-control form-control-sm font-monospace" rows="6" @input="emitRaw"></textarea>
+            <template v-else>
+                <div v-for="(row, index) in rows" :key="index" class="input-group input-group-sm mb-1">
+                    <input v-model="row.key" class="form-control" placeholder="键" @input="emitRows">
+                    <select v-model="row.type" class="form-select flex-grow-0 w-auto" @change="emitRows">
+                        <option value="string">文本</option>
+                        <option value="number">数字</option>
+                        <option value="boolean">布尔</option>
+                        <option value="json">JSON</option>
+                    </select>
+                    <select v-if="row.type === 'boolean'" v-model="row.value" class="form-select" @change="emitRows">
+                        <option value="true">true</option>
+                        <option value="false">false</option>
+                    </select>
+                    <input v-else v-model="row.value" class="form-control" :class="{ 'font-monospace': row.type === 'json' }"
+                           :type="row.type === 'number' ? 'number' : 'text'"
+                           :placeholder="row.type === 'json' ? '如 [1, 2] 或 {&quot;a&quot;: 1}' : '值'" @input="emitRows">
+                    <button type="button" class="btn btn-outline-danger" @click="removeRow(index)">×</button>
+                </div>
+                <button type="button" class="btn btn-sm btn-outline-primary" @click="addRow">+ 添加键</button>
+            </template>
+            <div v-if="error" class="text-danger small mt-1">{{ error }}</div>
+        </div>
+    `,
+    data() {
+        return {
+            rows: [],
+            raw: '',
+            rawMode: false,
+            error: ''
+        };
+    },
+    watch: {
+        modelValue: {
+            handler(value) {
+                // 自己提交的值不需要重新加载，避免打断输入
+                if (toRaw(value) !== this.emitted) {
+                    this.load(value);
+                }
+            },
+            immediate: true
+        }
+    },
+    methods: {
+        load(value) {
+            let parsed = value;
+            if (typeof value === 'string') {
+                try {
+                    parsed = value.trim() === '' ? {} : JSON.parse(value);
+                } catch (e) {
+                    this.raw = value;
+                    this.rawMode = true;
+                    this.error = '不是合法的 JSON';
+                    return;
+                }
+            }
+            if (parsed === null || parsed === undefined) {
+                parsed = {};
+            }
+            this.raw = JSON.stringify(parsed, null, 2);
+            this.error = '';
+            this.rawMode = !this.isObject(parsed);
+            this.rows = this.rawMode ? [] : Object.entries(parsed).map(([key, item]) => this.toRow(key, item));
+        },
+        isObject(value) {
+            return value !== null && typeof value === 'object' && !Array.isArray(value);
+        },
+        toRow(key, value) {
+            if (typeof value === 'number') {
+                return { key, type: 'number', value: String(value) };
+            }
+            if (typeof value === 'boolean') {
+                return { key, type: 'boolean', value: String(value) };
+            }
+            if (typeof value === 'string') {
+                return { key, type: 'string', value };
+            }
+            return { key, type: 'json', value: JSON.stringify(value) };
+        },
+        // 按行的类型转换取值，非法的值抛出错误
+        rowValue(row) {
+            switch (row.type) {
+                case 'number':
+                    if (row.value === '' || isNaN(Number(row.value))) {
+                        throw new Error(`${row.key} 不是数字`);
+                    }
+                    return Number(row.value);
+                case 'boolean':
+                    return row.value === 'true';
+                case 'json':
+                    try {
+                        return JSON.parse(row.value);
+                    } catch (e) {
+                        throw new Error(`${row.key} 的值不是合法的 JSON`);
+                    }
+            }
+            return row.value;
+        },
+        rowsToObject() {
+            const result = {};
+            this.rows.forEach(row => {
+                if (row.key.trim()) {
+                    result[row.key.trim()] = this.rowValue(row);
+                }
+            });
+            return result;
+        },
+        emitRows() {
+            try {
+                const value = this.rowsToObject();
+                this.error = '';
+                this.emit(value);
+            } catch (e) {
+                this.error = e.message;
+            }
+        },
+        // 源码不合法时原样提交，由服务端返回校验错误
+        emitRaw() {
+            try {
+                const value = JSON.parse(this.raw);
+                this.error = '';
+                this.emit(value);
+            } catch (e) {
+                this.error = '不是合法的 JSON';
+                this.emit(this.raw);
+            }
+        },
+        emit(value) {
+            this.emitted = value;
+            this.$emit('update:modelValue', value);
+        },
+        toggleMode() {
+            if (!this.rawMode) {
+                try {
+                    this.raw = JSON.stringify(this.rowsToObject(), null, 2);
+                } catch (e) {
+                    this.error = e.message;
+                    return;
+                }
+                this.rawMode = true;
+                return;
+            }
+            let parsed;
+            try {
+                parsed = this.raw.trim() === '' ? {} : JSON.parse(this.raw);
+            } catch (e) {
+                this.error = '不是合法的 JSON';
+                return;
+            }
+            if (!this.isObject(parsed)) {
+                this.error = '只有对象可以按键值编辑';
+                return;
+            }
+            this.rows = Object.entries(parsed).map(([key, item]) => this.toRow(key, item));
+            this.rawMode = false;
+            this.error = '';
+            this.emit(parsed);
+        },
+        addRow() {
+            this.rows.push({ key: '', type: 'string', value: '' });
+        },
+        removeRow(index) {
+            this.rows.splice(index, 1);
+            this.emitRows();
+        }
+    }
+};
+
+// 标签输入：数组的每个元素显示为一个标签，回车或逗号添加，退格删除最后一个
+const TagInput = {
+    name: 'TagInput',
+    props: ['modelValue', 'placeholder'],
+    emits: ['update:modelValue'],
+    template: `
+        <div class="form-control d-flex flex-wrap align-items-center gap-1" @click="$refs.input.focus()">
+            <span v-for="(tag, index) in tags" :key="index" class="badge bg-secondary d-flex align-items-center">
+                {{ tag }}
+                <button type="button" class="btn-close btn-close-white ms-1" style="font-size: .5rem;" @click.stop="removeTag(index)"></button>
+            </span>
+            <input ref="input" v-model="text" class="border-0 flex-grow-1" style="outline: none; min-width: 6rem;"
+                   :placeholder="tags.length === 0 ? (placeholder || '输入后回车添加') : ''"
+                   @keydown.enter.prevent="addTag" @keydown.backspace="removeLast" @input="splitText" @blur="addTag">
+        </div>
+    `,
+    data() {
+        return {
+            text: ''
+        };
+    },
+    computed: {
+        tags() {
+            return Array.isArray(this.modelValue) ? this.modelValue : [];
+        }
+    },
+    methods: {
+        addTag() {
+            const tag = this.text.trim();
+            this.text = '';
+            if (tag !== '' && !this.tags.includes(tag)) {
+                this.$emit('update:modelValue', [...this.tags, tag]);
+            }
+        },
+        // 输入或粘贴逗号时拆分出标签
+        splitText() {
+            if (!this.text.includes(',')) {
+                return;
+            }
+            const parts = this.text.split(',');
+            this.text = parts.pop();
+            const added = parts.map(part => part.trim()).filter(part => part !== '' && !this.tags.includes(part));
+            if (added.length > 0) {
+                this.$emit('update:modelValue', [...this.tags, ...new Set(added)]);
+            }
+        },
+        removeTag(index) {
+            this.$emit('update:modelValue', this.tags.filter((_, i) => i !== index));
+        },
+        removeLast() {
+            if (this.text === '' && this.tags.length > 0) {
+                this.removeTag(this.tags.length - 1);
+            }
+        }
+    }
+};
+
+// 子表：列出外键指向当前记录的子记录，使用子表配置的搜索、排序和分页，新增时预填外键
+const ChildTable = {
+    name: 'ChildTable',
+    props: ['configName', 'recordKey', 'relation', 'parentValue'],
+    template: `
+        <div>
+            <div class="d-flex align-items-center gap-2 mb-2">
+                <input v-for="field in searchFields" :key="field.field" v-model="filters[field.field]"
+                       type="text" class="form-control form-control-sm w-auto" :placeholder="'搜索 ' + (field.label || field.field)"
+                       @keyup.enter="search">
+                <button v-if="searchFields.length > 0" type="button" class="btn btn-sm btn-outline-primary" @click="search">
+                    <i class="bi bi-search"></i>
+                </button>
+                <button type="button" class="btn btn-sm btn-success ms-auto" @click="showCreate = !showCreate">
+                    <i class="bi bi-plus"></i> 新增
+                </button>
+            </div>
+            <div v-if="showCreate" class="border rounded p-2 mb-2">
+                <div class="row g-2">
+                    <div v-for="field in creatableFields" :key="field" class="col-md-4">
+                        <label class="form-label small mb-1">{{ field }}</label>
+                        <input v-model="formData[field]" type="text" class="form-control form-control-sm"
+                               :readonly="field === relation.foreign_key">
+                    </div>
+                </div>
+                <button type="button" class="btn btn-sm btn-primary mt-2" :disabled="saving" @click="create">
+                    {{ saving ? '保存中...' : '保存' }}
+                </button>
+            </div>
+            <div class="table-responsive">
+                <table class="table table-sm table-hover mb-2">
+                    <thead>
+                        <tr>
+                            <th v-for="field in columns" :key="field" :class="{ 'cursor-pointer': sortableFields.includes(field) }" @click="toggleSort(field)">
+                                {{ labelOf(field) }}
+                                <i v-if="sort.field === field" class="bi" :class="sort.order === 'desc' ? 'bi-arrow-down' : 'bi-arrow-up'"></i>
+                            </th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        <tr v-if="records.length === 0">
+                            <td :colspan="columns.length || 1" class="text-center text-muted">{{ loading ? '加载中...' : '暂无数据' }}</td>
+                        </tr>
+                        <tr v-for="(record, index) in records" :key="index">
+                            <td v-for="field in columns" :key="field">{{ formatValue(record[field + '_label'] != null ? record[field + '_label'] : record[field]) }}</td>
+                        </tr>
+                    </tbody>
+                </table>
+            </div>
+            <div v-if="totalPages > 1" class="d-flex justify-content-end align-items-center gap-2">
+                <button type="button" class="btn btn-sm btn-outline-secondary" :disabled="page <= 1" @click="changePage(page - 1)">上一页</button>
+                <span class="small text-muted">{{ page }} / {{ totalPages }}</span>
+                <button type="button" class="btn btn-sm btn-outline-secondary" :disabled="page >= totalPages" @click="changePage(page + 1)">下一页</button>
+            </div>
+        </div>
+    `,
+    data() {
+        return {
+            loading: false,
+            records: [],
+            displayFields: [],
+            searchFields: [],
+            sortableFields: [],
+            creatableFields: [],
+            filters: {},
+            sort: { field: '', order: 'asc' },
+            page: 1,
+            pageSize: 10,
+            totalPages: 0,
+            showCreate: false,
+            formData: {},
+            saving: false
+        };
+    },
+    computed: {
+        // 未配置展示字段时使用返回记录的列
+        columns() {
+            if (this.displayFields.length > 0) {
+                return this.displayFields.map(field => field.field);
+            }
+            return this.records.length > 0 ? Object.keys(this.records[0]).filter(key => !key.endsWith('_label')) : [];
+        }
+    },
+    async mounted() {
+        await this.loadConfiguration();
+        await this.loadData();
+    },
+    methods: {
+        async loadConfiguration() {
+            try {
+                const response = await crudAxios.get(ConfigManager.getApiUrl(`/configs/by-name/${this.relation.config}`));
+                const config = response.data.data;
+                this.displayFields = config.query_display_fields ? JSON.parse(config.query_display_fields) : [];
+                // 子表只提供文本搜索
+                const searchFields = config.query_search_fields ? JSON.parse(config.query_search_fields) : [];
+                this.searchFields = searchFields.filter(field => field.type === 'fuzzy' || field.type === 'exact');
+                this.sortableFields = config.query_sortable_fields ? JSON.parse(config.query_sortable_fields) : [];
+                const creatableFields = config.create_creatable_fields ? JSON.parse(config.create_creatable_fields) : [];
+                this.creatableFields = creatableFields.map(field => typeof field === 'string' ? field : field.field);
+                if (!this.creatableFields.includes(this.relation.foreign_key)) {
+                    this.creatableFields.unshift(this.relation.foreign_key);
+                }
+                this.resetForm();
+            } catch (error) {
+                console.error('Failed to load child configuration:', error);
+            }
+        },
+        async loadData() {
+            try {
+                this.loading = true;
+                const params = new URLSearchParams();
+                params.append('page', this.page);
+                params.append('page_size', this.pageSize);
+                Object.keys(this.filters).forEach(key => {
+                    if (this.filters[key] !== undefined && this.filters[key] !== '') {
+                        params.append(key, this.filters[key]);
+                    }
+                });
+                if (this.sort.field) {
+                    params.append('sort', (this.sort.order === 'desc' ? '-' : '') + this.sort.field);
+                }
+                const response = await crudAxios.get(ConfigManager.getApiUrl(
+                    `/${this.configName}/get/${this.recordKey}/children/${encodeURIComponent(this.relation.name)}?${params.toString()}`
+                ));
+                const result = response.data.data;
+                this.records = result.data || [];
+                this.totalPages = result.total_pages || 0;
+            } catch (error) {
+                console.error('Failed to load child records:', error);
+                this.records = [];
+            } finally {
+                this.loading = false;
+            }
+        },
+        search() {
+            this.page = 1;
+            this.loadData();
+        },
+        changePage(page) {
+            this.page = page;
+            this.loadData();
+        },
+        toggleSort(field) {
+            if (!this.sortableFields.includes(field)) {
+                return;
+            }
+            if (this.sort.field === field) {
+                this.sort.order = this.sort.order === 'asc' ? 'desc' : 'asc';
+            } else {
+                this.sort = { field, order: 'asc' };
+            }
+            this.loadData();
+        },
+        resetForm() {
+            this.formData = {};
+            this.creatableFields.forEach(field => {
+                this.formData[field] = '';
+            });
+            this.formData[this.relation.foreign_key] = this.parentValue;
+        },
+        async create() {
+            try {
+                this.saving = true;
+                await crudAxios.post(ConfigManager.getApiUrl(`/${this.relation.config}/create`), this.formData);
+                this.showCreate = false;
+                this.resetForm();
+                await this.loadData();
+            } catch (error) {
+                console.error('Failed to create child record:', error);
+                alert('保存失败: ' + (error.response?.data?.error || error.message));
+            } finally {
+                this.saving = false;
+            }
+        },
+        labelOf(fieldName) {
+            const field = this.displayFields.find(field => field.field === fieldName);
+            return field && field.label ? field.label : fieldName;
+        },
+        formatValue(value) {
+            if (value === null || value === undefined) {
+                return '-';
+            }
+            if (typeof value === 'object') {
+                return JSON.stringify(value);
+            }
+            return String(value);
+        }
+    }
+};
+
 createApp({
+    components: {
+        FilterGroup,
+        ChildTable,
+        JsonEditor,
+        TagInput
+    },
     data() {
         return {
             configName: '',
@@ -45,12 +563,31 @@ createApp({
             dictData: {},
             parsedSqlFields: [], // 添加这个来存储解析的SQL字段
             filters: {},
-            currentSort: '',
-            sortOrder: 'asc',
+            filterFields: [], // 可用于高级筛选的字段及其操作符
+            advancedFilter: { type: 'and', not: false, items: [] }, // 高级筛选条件树
+            showAdvancedFilter: false,
+            sorts: [], // 排序字段列表 [{field, order}]，按住 Shift 点击表头可按多个字段排序
             currentPage: 1,
             pageSize: 20,
             totalRecords: 0,
             totalPages: 0,
+            cursorMode: false, // 游标分页：不使用 OFFSET，也不统计总数，适合大表
+            cursor: '',
+            nextCursor: '',
+            prevCursor: '',
+            primaryKey: ['id'], // 主键列，联合主键包含多个列
+            relations: [], // 子表关系，在编辑框中显示为标签页
+            aggregateConfig: null, // 统计配置：允许的分组字段和指标
+            summary: null, // 当前筛选条件下的汇总，显示在表格底部
+            facets: [], // 字典搜索字段每个取值的记录数，显示在侧栏和下拉选项中
+            showChart: false,
+            chartGroupBy: '',
+            chartMetric: 'count',
+            chartRows: [],
+            views: [], // 当前用户的视图和其他人共享的视图
+            currentView: '', // 当前视图名称，空为默认视图
+            visibleFields: [], // 显示列，空为全部展示字段
+            activeTab: '', // 编辑框当前标签页，空为记录本身
             editingRecord: null,
             formData: {},
             saving: false,
@@ -58,6 +595,41 @@ createApp({
         }
     },
     computed: {
+        // 全文检索字段显示为全局搜索框，不在字段筛选中重复显示
+        fulltextField() {
+            return this.searchFields.find(field => field.type === 'fulltext') || null;
+        },
+        fieldSearchFields() {
+            return this.searchFields.filter(field => field.type !== 'fulltext');
+        },
+        // 带字典的单选和多选字段可以按取值统计记录数
+        hasFacets() {
+            return this.searchFields.some(field => field.dict_source && (field.type === 'single' || field.type === 'multi_select'));
+        },
+        // 配置允许的全部统计指标，例如 sum:amount
+        aggregateMetrics() {
+            const metrics = [];
+            ((this.aggregateConfig && this.aggregateConfig.metrics) || []).forEach(metric => {
+                metric.functions.forEach(fn => metrics.push(fn + ':' + metric.field));
+            });
+            return metrics;
+        },
+        // 图表柱长按最大值换算为百分比
+        chartMax() {
+            return Math.max(0, ...this.chartRows.map(row => Number(row.value) || 0));
+        },
+        // 关联其他表的展示字段，可以按标签搜索
+        referenceFields() {
+            return this.displayFields.filter(field => field.reference && field.reference.label_field);
+        },
+        pageSizeOptions() {
+            const sizes = [10, 20, 50, 100];
+            if (!sizes.includes(this.pageSize)) {
+                sizes.push(this.pageSize);
+                sizes.sort((a, b) => a - b);
+            }
+            return sizes;
+        },
         paginationPages() {
             const pages = [];
             const start = Math.max(1, this.currentPage - 2);
@@ -81,8 +653,22 @@ createApp({
         this.modal = new bootstrap.Modal(document.getElementById('recordModal'));
         
         await this.loadConfiguration();
+        await this.loadViews();
+        
+        // 通过 ?view=<名称> 打开保存的视图
+        const viewName = new URLSearchParams(window.location.search).get('view');
+        if (viewName) {
+            await this.loadView(viewName);
+        }
         await this.loadData();
         this.loading = false;
+        
+        // 通过 ?record=<主键> 直接打开记录，联合主键的各列值用逗号分隔
+        const recordKey = new URLSearchParams(window.location.search).get('record');
+        if (recordKey) {
+            const values = this.primaryKey.length > 1 ? recordKey.split(',') : [recordKey];
+            await this.openRecord(values.map(value => encodeURIComponent(value)).join(','));
+        }
     },
     methods: {
         
@@ -96,6 +682,11 @@ createApp({
                     throw new Error(`Configuration '${this.configName}' not found`);
                 }
                 
+                // 解析主键配置
+                if (config.primary_key) {
+                    this.primaryKey = config.primary_key.split(',').map(column => column.trim()).filter(column => column);
+                }
+                
                 // 解析展示字段
                 if (config.query_display_fields) {
                     this.displayFields = JSON.parse(config.query_display_fields);
@@ -106,6 +697,11 @@ createApp({
                     this.searchFields = JSON.parse(config.query_search_fields);
                 }
                 
+                // 解析过滤字段
+                if (config.query_filter_fields) {
+                    this.filterFields = JSON.parse(config.query_filter_fields);
+                }
+                
                 // 解析排序字段
                 if (config.query_sortable_fields) {
                     this.sortableFields = JSON.parse(config.query_sortable_fields);
@@ -121,14 +717,29 @@ createApp({
                     this.creatableFields = JSON.parse(config.create_creatable_fields);
                 }
                 
-                // 如果配置为空，尝试从SQL语句解析字段
-                const sqlFields = this.parseSQLFields(config.create_statement);
+                // 解析子表关系
+                if (config.query_relations) {
+                    this.relations = JSON.parse(config.query_relations);
+                }
+                
+                // 解析统计配置
+                if (config.query_aggregate) {
+                    this.aggregateConfig = JSON.parse(config.query_aggregate);
+                    this.chartGroupBy = (this.aggregateConfig.group_by || [])[0] || '';
+                }
+                
+                // 如果配置为空，尝试从SQL语句解析字段，未填写建表语句时从数据库读取
+                let sqlFields = this.parseSQLFields(config.create_statement);
+                if (sqlFields.length === 0 && config.connection_id && config.table_name) {
+                    sqlFields = await this.loadTableFields(config.connection_id, config.table_name);
+                }
                 this.parsedSqlFields = sqlFields; // 保存解析的字段
                 console.log('Parsed SQL fields:', sqlFields);
                 
-                // 如果没有配置展示字段，使用SQL解析的所有字段
+                // 如果没有配置展示字段，使用SQL解析的所有字段（隐藏字段除外，接口不会返回）
+                const hiddenFields = config.query_hidden_fields ? JSON.parse(config.query_hidden_fields) : [];
                 if (this.displayFields.length === 0 && sqlFields.length > 0) {
-                    this.displayFields = sqlFields.map(field => ({
+                    this.displayFields = sqlFields.filter(field => !hiddenFields.includes(field.name)).map(field => ({
                         field: field.name,
                         label: field.name,
                         width: null,
@@ -143,11 +754,7 @@ createApp({
                     console.log('Set updatable fields:', this.updatableFields);
                 }
                 
-                // 如果没有配置排序字段，使用SQL解析的所有字段
-                if (this.sortableFields.length === 0 && sqlFields.length > 0) {
-                    this.sortableFields = sqlFields.map(field => field.name);
-                    console.log('Set sortable fields:', this.sortableFields);
-                }
+                // 未配置排序字段时不能排序，与服务端一致
                 
                 // 如果没有配置搜索字段，为文本和数字字段创建默认搜索配置
                 if (this.searchFields.length === 0 && sqlFields.length > 0) {
@@ -165,9 +772,9 @@ createApp({
                 // 加载字典数据
                 await this.loadDictData();
                 
-                // 初始化多选字段的过滤器为空数组
+                // 初始化多选和数组字段的过滤器为空数组
                 this.searchFields.forEach(field => {
-                    if (field.type === 'multi_select') {
+                    if (this.isArrayFilter(field)) {
                         this.filters[field.field] = [];
                     }
                 });
@@ -190,6 +797,24 @@ createApp({
             }
         },
         
+        // 从数据库读取表字段
+        async loadTableFields(connectionId, tableName) {
+            try {
+                const response = await crudAxios.get(ConfigManager.getApiUrl(
+                    `/connections/${connectionId}/tables/${encodeURIComponent(tableName)}`
+                ));
+                const columns = response.data.data.columns || [];
+                return columns.map(column => ({
+                    name: column.name.toLowerCase(),
+                    type: column.type.toLowerCase().replace(/\s+/g, ''),
+                    comment: column.comment || ''
+                }));
+            } catch (error) {
+                console.warn('读取表结构失败:', error);
+                return [];
+            }
+        },
+        
         // 解析SQL建表语句，提取字段信息
         parseSQLFields(sql) {
             if (!sql) return [];
@@ -277,60 +902,21 @@ createApp({
                 const params = new URLSearchParams();
                 
                 // 分页参数
-                params.append('page', this.currentPage);
+                if (this.cursorMode) {
+                    params.append('cursor', this.cursor);
+                    params.append('skip_count', 'true');
+                } else {
+                    params.append('page', this.currentPage);
+                }
                 params.append('page_size', this.pageSize);
-                
-                // 搜索参数
-                Object.keys(this.filters).forEach(key => {
-                    if (this.filters[key] !== undefined && this.filters[key] !== '') {
-                        if (key.endsWith('_min') || key.endsWith('_max')) {
-                            // 范围搜索处理（数字范围）
-                            const baseField = key.replace(/_min$/, '').replace(/_max$/, '');
-                            const minValue = this.filters[baseField + '_min'];
-                            const maxValue = this.filters[baseField + '_max'];
-                            
-                            if (minValue !== undefined && minValue !== '') {
-                                params.append(baseField, JSON.stringify({min: minValue, max: maxValue}));
-                            }
-                        } else if (key.endsWith('_start') || key.endsWith('_end')) {
-                            // 日期范围搜索处理
-                            const baseField = key.replace(/_start$/, '').replace(/_end$/, '');
-                            const startValue = this.filters[baseField + '_start'];
-                            const endValue = this.filters[baseField + '_end'];
-                            
-                            if (startValue !== undefined && startValue !== '') {
-                                // 将日期转换为时间戳
-                                const startTimestamp = startValue ? new Date(startValue).getTime() / 1000 : null;
-                                const endTimestamp = endValue ? new Date(endValue + 'T23:59:59').getTime() / 1000 : null;
-                                
-                                const rangeData = {};
-                                if (startTimestamp) rangeData.start = startTimestamp;
-                                if (endTimestamp) rangeData.end = endTimestamp;
-                                
-                                if (Object.keys(rangeData).length > 0) {
-                                    params.append(baseField, JSON.stringify(rangeData));
-                                }
-                            }
-                        } else if (!key.endsWith('_min') && !key.endsWith('_max') && !key.endsWith('_start') && !key.endsWith('_end')) {
-                            // 检查是否是多选字段
-                            const searchField = this.searchFields.find(f => f.field === key);
-                            if (searchField && searchField.type === 'multi_select') {
-                                // 多选字段：值应该是数组，转换为JSON字符串发送
-                                if (Array.isArray(this.filters[key]) && this.filters[key].length > 0) {
-                                    params.append(key, JSON.stringify(this.filters[key]));
-                                }
-                            } else {
-                                // 普通字段
-                                params.append(key, this.filters[key]);
-                            }
-                        }
-                    }
-                });
+                this.appendSearchParams(params);
+                if (this.visibleFields.length > 0) {
+                    params.append('fields', this.visibleFields.join(','));
+                }
                 
                 // 排序参数
-                if (this.currentSort) {
-                    params.append('sort', this.currentSort);
-                    params.append('order', this.sortOrder);
+                if (this.sorts.length > 0) {
+                    params.append('sort', this.sorts.map(sort => (sort.order === 'desc' ? '-' : '') + sort.field).join(','));
                 }
                 
                 const response = await crudAxios.get(ConfigManager.getApiUrl(`/${this.configName}/list?${params.toString()}`));
@@ -339,7 +925,14 @@ createApp({
                 this.records = result.data || [];
                 this.totalRecords = result.total;
                 this.totalPages = result.total_pages;
-                this.currentPage = result.page;
+                this.nextCursor = result.next_cursor || '';
+                this.prevCursor = result.prev_cursor || '';
+                if (!this.cursorMode) {
+                    this.currentPage = result.page;
+                }
+                if (result.primary_key && result.primary_key.length > 0) {
+                    this.primaryKey = result.primary_key;
+                }
                 
                 // 提取表格字段 - 优先使用展示字段配置的顺序
                 if (this.displayFields.length > 0) {
@@ -351,6 +944,9 @@ createApp({
                     // 如果没有记录，从SQL解析的字段中获取
                     this.tableFields = this.parsedSqlFields.map(field => field.name);
                 }
+                if (this.visibleFields.length > 0) {
+                    this.tableFields = this.tableFields.filter(field => this.visibleFields.includes(field));
+                }
                 
                 // 设置可编辑字段 - 优先使用可创建字段配置
                 if (this.creatableFields.length > 0) {
@@ -365,12 +961,15 @@ createApp({
                     this.editableFields = this.tableFields.filter(field => field !== 'id').map(field => ({ field: field, label: field, type: 'text' }));
                 } else if (this.parsedSqlFields && this.parsedSqlFields.length > 0) {
                     // 最后从SQL解析字段中获取（排除id）
-                    this.editableFields = this.parsedSqlFields.filter(field => field.name !== 'id').map(field => ({ field: field.name, label: field.name, type: 'text' }));
+                    this.editableFields = this.parsedSqlFields.filter(field => field.name !== 'id').map(field => ({ field: field.name, label: field.name, help_text: field.comment, type: 'text' }));
                 }
                 
                 console.log('Table fields:', this.tableFields);
                 console.log('Editable fields:', this.editableFields);
                 
+                // 刷新汇总和分面统计
+                await Promise.all([this.loadSummary(), this.loadFacets()]);
+                
             } catch (error) {
                 console.error('Failed to load data:', error);
                 alert('数据加载失败: ' + error.message);
@@ -379,38 +978,261 @@ createApp({
             }
         },
         
+        // 添加搜索和高级筛选参数，列表和统计接口共用
+        appendSearchParams(params) {
+            // 搜索参数
+            Object.keys(this.filters).forEach(key => {
+                if (this.filters[key] !== undefined && this.filters[key] !== '') {
+                    if (key.endsWith('_min') || key.endsWith('_max')) {
+                        // 范围搜索处理（数字范围）
+                        const baseField = key.replace(/_min$/, '').replace(/_max$/, '');
+                        const minValue = this.filters[baseField + '_min'];
+                        const maxValue = this.filters[baseField + '_max'];
+                        
+                        if (minValue !== undefined && minValue !== '') {
+                            params.append(baseField, JSON.stringify({min: minValue, max: maxValue}));
+                        }
+                    } else if (key.endsWith('_start') || key.endsWith('_end')) {
+                        // 日期范围搜索处理
+                        const baseField = key.replace(/_start$/, '').replace(/_end$/, '');
+                        const startValue = this.filters[baseField + '_start'];
+                        const endValue = this.filters[baseField + '_end'];
+                        
+                        if (startValue !== undefined && startValue !== '') {
+                            // 将日期转换为时间戳
+                            const startTimestamp = startValue ? new Date(startValue).getTime() / 1000 : null;
+                            const endTimestamp = endValue ? new Date(endValue + 'T23:59:59').getTime() / 1000 : null;
+                            
+                            const rangeData = {};
+                            if (startTimestamp) rangeData.start = startTimestamp;
+                            if (endTimestamp) rangeData.end = endTimestamp;
+                            
+                            if (Object.keys(rangeData).length > 0) {
+                                params.append(baseField, JSON.stringify(rangeData));
+                            }
+                        }
+                    } else if (!key.endsWith('_min') && !key.endsWith('_max') && !key.endsWith('_start') && !key.endsWith('_end')) {
+                        // 检查是否是多选字段
+                        const searchField = this.searchFields.find(f => f.field === key);
+                        if (searchField && this.isArrayFilter(searchField)) {
+                            // 多选和数组字段：值应该是数组，转换为JSON字符串发送
+                            if (Array.isArray(this.filters[key]) && this.filters[key].length > 0) {
+                                params.append(key, JSON.stringify(this.filters[key]));
+                            }
+                        } else {
+                            // 普通字段
+                            params.append(key, this.filters[key]);
+                        }
+                    }
+                }
+            });
+            
+            // 高级筛选参数
+            const where = this.buildFilterNode(this.advancedFilter);
+            if (where) {
+                params.append('where', JSON.stringify(where));
+            }
+        },
+        
+        // 读取当前筛选条件下的汇总：记录数和配置的全部指标
+        async loadSummary() {
+            if (!this.aggregateConfig) {
+                return;
+            }
+            try {
+                const params = new URLSearchParams();
+                this.appendSearchParams(params);
+                params.append('metrics', ['count', ...this.aggregateMetrics].join(','));
+                const response = await crudAxios.get(ConfigManager.getApiUrl(`/${this.configName}/aggregate?${params.toString()}`));
+                const rows = response.data.data.rows || [];
+                this.summary = rows[0] || null;
+            } catch (error) {
+                console.warn('Failed to load summary:', error);
+                this.summary = null;
+            }
+        },
+        
+        // 读取当前筛选条件下字典搜索字段每个取值的记录数
+        async loadFacets() {
+            if (!this.hasFacets) {
+                return;
+            }
+            try {
+                const params = new URLSearchParams();
+                this.appendSearchParams(params);
+                const response = await crudAxios.get(ConfigManager.getApiUrl(`/${this.configName}/facets?${params.toString()}`));
+                this.facets = response.data.data.facets || [];
+            } catch (error) {
+                console.warn('Failed to load facets:', error);
+                this.facets = [];
+            }
+        },
+        
+        // 字典取值的记录数，没有统计时为 null
+        facetCount(field, value) {
+            const facet = this.facets.find(facet => facet.field === field);
+            if (!facet) {
+                return null;
+            }
+            const item = facet.values.find(item => String(item.value) === String(value));
+            return item ? item.count : 0;
+        },
+        
+        facetLabel(field) {
+            const searchField = this.searchFields.find(searchField => searchField.field === field);
+            return (searchField && searchField.label) || field;
+        },
+        
+        isFacetSelected(field, value) {
+            const selected = this.filters[field];
+            return Array.isArray(selected) ? selected.includes(value) : String(selected ?? '') === String(value);
+        },
+        
+        // 点击侧栏的取值切换筛选：单选再次点击取消，多选加入或移出已选列表
+        async toggleFacet(field, value) {
+            const searchField = this.searchFields.find(searchField => searchField.field === field);
+            if (searchField && searchField.type === 'multi_select') {
+                const selected = Array.isArray(this.filters[field]) ? this.filters[field] : [];
+                this.filters[field] = selected.includes(value) ? selected.filter(item => item !== value) : [...selected, value];
+            } else {
+                this.filters[field] = this.isFacetSelected(field, value) ? '' : value;
+            }
+            await this.applyFilters();
+        },
+        
+        // 按分组字段统计，生成图表数据
+        async loadChart() {
+            if (!this.chartGroupBy) {
+                return;
+            }
+            try {
+                const params = new URLSearchParams();
+                this.appendSearchParams(params);
+                params.append('group_by', this.chartGroupBy);
+                params.append('metrics', this.chartMetric);
+                const response = await crudAxios.get(ConfigManager.getApiUrl(`/${this.configName}/aggregate?${params.toString()}`));
+                const result = response.data.data;
+                const key = result.metrics[0];
+                this.chartRows = (result.rows || []).map(row => ({
+                    label: this.formatValue(row[this.chartGroupBy]),
+                    value: row[key]
+                }));
+            } catch (error) {
+                console.error('Failed to load chart:', error);
+                alert('统计失败: ' + (error.response?.data?.error || error.message));
+            }
+        },
+        
+        // 字段在汇总中的指标，例如 求和 70、平均 35
+        summaryOf(field) {
+            if (!this.summary) {
+                return [];
+            }
+            return this.aggregateMetrics
+                .filter(metric => metric.split(':')[1] === field)
+                .map(metric => {
+                    const fn = metric.split(':')[0];
+                    return { label: AGGREGATE_FUNCTION_LABELS[fn] || fn, value: this.summary[fn + '_' + field] };
+                });
+        },
+        
+        // 指标的显示名称，例如 sum:amount 显示为 amount 求和
+        metricLabel(metric) {
+            const [fn, field] = metric.split(':');
+            return (field ? field + ' ' : '') + (AGGREGATE_FUNCTION_LABELS[fn] || fn);
+        },
+        
         async applyFilters() {
             this.currentPage = 1;
+            this.cursor = '';
             await this.loadData();
         },
         
         clearFilters() {
-            // 重新初始化filters对象，对多选字段设置为空数组
+            // 重新初始化filters对象，对多选和数组字段设置为空数组
             this.filters = {};
             this.searchFields.forEach(field => {
-                if (field.type === 'multi_select') {
+                if (this.isArrayFilter(field)) {
                     this.filters[field.field] = [];
                 }
             });
             this.currentPage = 1;
+            this.cursor = '';
             this.loadData();
         },
         
-        toggleSort(field) {
-            if (this.currentSort === field) {
-                this.sortOrder = this.sortOrder === 'asc' ? 'desc' : 'asc';
+        // 将条件组转换为接口的 where 表达式，空条件和空组被忽略
+        buildFilterNode(group) {
+            const children = [];
+            group.items.forEach(item => {
+                if (item.kind === 'group') {
+                    const child = this.buildFilterNode(item);
+                    if (child) {
+                        children.push(child);
+                    }
+                } else if (item.field && item.operator) {
+                    const node = { field: item.field, operator: item.operator };
+                    if (item.operator !== 'is_null' && item.operator !== 'not_null') {
+                        node.value = item.value;
+                    }
+                    children.push(node);
+                }
+            });
+            if (children.length === 0) {
+                return null;
+            }
+            const node = { [group.type]: children };
+            return group.not ? { not: node } : node;
+        },
+        
+        async applyAdvancedFilter() {
+            this.currentPage = 1;
+            this.cursor = '';
+            await this.loadData();
+        },
+        
+        clearAdvancedFilter() {
+            this.advancedFilter = { type: 'and', not: false, items: [] };
+            this.currentPage = 1;
+            this.cursor = '';
+            this.loadData();
+        },
+        
+        // 点击表头切换排序方向；按住 Shift 点击时追加排序字段，已降序的字段再次点击则移除
+        toggleSort(field, event) {
+            const index = this.sorts.findIndex(sort => sort.field === field);
+            if (event && event.shiftKey) {
+                if (index === -1) {
+                    this.sorts.push({ field, order: 'asc' });
+                } else if (this.sorts[index].order === 'asc') {
+                    this.sorts[index].order = 'desc';
+                } else {
+                    this.sorts.splice(index, 1);
+                }
+            } else if (index !== -1 && this.sorts.length === 1) {
+                this.sorts[0].order = this.sorts[0].order === 'asc' ? 'desc' : 'asc';
             } else {
-                this.currentSort = field;
-                this.sortOrder = 'asc';
+                this.sorts = [{ field, order: 'asc' }];
             }
+            this.cursor = '';
             this.loadData();
         },
         
         getSortIcon(field) {
-            if (this.currentSort !== field) {
+            const sort = this.sorts.find(sort => sort.field === field);
+            if (!sort) {
                 return 'bi-arrow-down-up';
             }
-            return this.sortOrder === 'asc' ? 'bi-sort-up' : 'bi-sort-down';
+            return sort.order === 'asc' ? 'bi-sort-up' : 'bi-sort-down';
+        },
+        
+        // 多字段排序时显示排序优先级
+        getSortPriority(field) {
+            if (this.sorts.length < 2) {
+                return '';
+            }
+            const index = this.sorts.findIndex(sort => sort.field === field);
+            return index === -1 ? '' : index + 1;
         },
         
         async changePage(page) {
@@ -420,25 +1242,272 @@ createApp({
             }
         },
         
+        async changeCursor(cursor) {
+            if (cursor) {
+                this.cursor = cursor;
+                await this.loadData();
+            }
+        },
+        
+        // 切换页码分页和游标分页，切换后回到第一页
+        async togglePaginationMode() {
+            this.cursorMode = !this.cursorMode;
+            this.cursor = '';
+            this.currentPage = 1;
+            await this.loadData();
+        },
+        
         refreshData() {
             this.loadData();
         },
         
+        async changePageSize() {
+            this.currentPage = 1;
+            this.cursor = '';
+            await this.loadData();
+        },
+        
+        isColumnVisible(field) {
+            return this.visibleFields.length === 0 || this.visibleFields.includes(field);
+        },
+        
+        // 切换显示列，至少保留一列；显示全部列时清空列表，不发送 fields 参数
+        async toggleColumn(field, event) {
+            const allFields = this.displayFields.map(displayField => displayField.field);
+            let visible = this.visibleFields.length > 0 ? [...this.visibleFields] : allFields;
+            if (visible.includes(field)) {
+                if (visible.length === 1) {
+                    event.target.checked = true;
+                    return;
+                }
+                visible = visible.filter(visibleField => visibleField !== field);
+            } else {
+                visible.push(field);
+            }
+            visible = allFields.filter(displayField => visible.includes(displayField));
+            this.visibleFields = visible.length === allFields.length ? [] : visible;
+            await this.loadData();
+        },
+        
+        // 读取当前用户的视图和其他人共享的视图
+        async loadViews() {
+            try {
+                const response = await crudAxios.get(ConfigManager.getApiUrl(`/${this.configName}/views`));
+                this.views = response.data.data || [];
+            } catch (error) {
+                console.error('Failed to load views:', error);
+                this.views = [];
+            }
+        },
+        
+        // 读取视图并应用到页面，不刷新数据
+        async loadView(name) {
+            try {
+                const response = await crudAxios.get(ConfigManager.getApiUrl(`/${this.configName}/views/${encodeURIComponent(name)}`));
+                this.applyViewSettings(response.data.data);
+                this.currentView = name;
+            } catch (error) {
+                console.error('Failed to load view:', error);
+                alert('视图加载失败: ' + (error.response?.data?.error || error.message));
+            }
+        },
+        
+        // 切换视图，空名称恢复默认视图；地址栏同步为 ?view=<名称>，便于收藏和分享
+        async switchView(name) {
+            if (name) {
+                await this.loadView(name);
+            } else {
+                this.currentView = '';
+                this.applyViewSettings({});
+            }
+            const url = new URL(window.location.href);
+            if (this.currentView) {
+                url.searchParams.set('view', this.currentView);
+            } else {
+                url.searchParams.delete('view');
+            }
+            window.history.replaceState(null, '', url.toString());
+            this.currentPage = 1;
+            this.cursor = '';
+            await this.loadData();
+        },
+        
+        // 把视图的搜索参数还原为筛选表单的值，是 appendSearchParams 的逆过程
+        applyViewSettings(view) {
+            this.filters = {};
+            this.searchFields.forEach(field => {
+                if (this.isArrayFilter(field)) {
+                    this.filters[field.field] = [];
+                }
+            });
+            Object.entries(view.search || {}).forEach(([key, value]) => {
+                const searchField = this.searchFields.find(field => field.field === key);
+                let parsed = value;
+                if (typeof value === 'string' && searchField && (searchField.type === 'range' || searchField.type === 'date_range' || this.isArrayFilter(searchField))) {
+                    try {
+                        parsed = JSON.parse(value);
+                    } catch (e) {
+                        parsed = value;
+                    }
+                }
+                if (searchField && searchField.type === 'range' && parsed && typeof parsed === 'object') {
+                    this.filters[key + '_min'] = parsed.min ?? '';
+                    this.filters[key + '_max'] = parsed.max ?? '';
+                } else if (searchField && searchField.type === 'date_range' && parsed && typeof parsed === 'object') {
+                    // 开始日期按 UTC 零点发送，结束日期按本地时间 23:59:59 发送
+                    this.filters[key + '_start'] = parsed.start ? new Date(parsed.start * 1000).toISOString().slice(0, 10) : '';
+                    this.filters[key + '_end'] = parsed.end ? this.formatLocalDate(new Date(parsed.end * 1000)) : '';
+                } else if (searchField && this.isArrayFilter(searchField)) {
+                    this.filters[key] = Array.isArray(parsed) ? parsed : String(parsed).split(',').map(item => item.trim()).filter(item => item);
+                } else {
+                    this.filters[key] = value;
+                }
+            });
+            this.advancedFilter = view.where ? this.filterGroupOf(view.where) : { type: 'and', not: false, items: [] };
+            this.showAdvancedFilter = this.advancedFilter.items.length > 0;
+            this.sorts = (view.sort || '').split(',').map(item => item.trim()).filter(item => item).map(item => {
+                if (item.startsWith('-')) {
+                    return { field: item.slice(1), order: 'desc' };
+                }
+                return { field: item.replace(/^\+/, ''), order: 'asc' };
+            });
+            this.visibleFields = view.fields || [];
+            this.pageSize = view.page_size || 20;
+        },
+        
+        formatLocalDate(date) {
+            const pad = value => String(value).padStart(2, '0');
+            return `${date.getFullYear()}-${pad(date.getMonth() + 1)}-${pad(date.getDate())}`;
+        },
+        
+        // 把筛选表达式还原为高级筛选的条件组，是 buildFilterNode 的逆过程
+        filterGroupOf(node) {
+            let not = false;
+            if (node.not) {
+                not = true;
+                node = node.not;
+            }
+            const type = node.or ? 'or' : 'and';
+            const children = node.and || node.or || [node];
+            const items = children.map(child => {
+                if (child.and || child.or || child.not) {
+                    return { kind: 'group', ...this.filterGroupOf(child) };
+                }
+                return { kind: 'condition', field: child.field, operator: child.operator, value: child.value ?? '' };
+            });
+            return { type, not, items };
+        },
+        
+        // 当前的搜索、高级筛选、排序、显示列和每页条数
+        currentViewSettings() {
+            const params = new URLSearchParams();
+            this.appendSearchParams(params);
+            const search = {};
+            params.forEach((value, key) => {
+                if (key !== 'where') {
+                    search[key] = value;
+                }
+            });
+            return {
+                search,
+                where: this.buildFilterNode(this.advancedFilter),
+                sort: this.sorts.map(sort => (sort.order === 'desc' ? '-' : '') + sort.field).join(','),
+                fields: this.visibleFields,
+                page_size: this.pageSize
+            };
+        },
+        
+        // 保存为视图；同名的自己的视图确认后覆盖
+        async saveView() {
+            const name = (prompt('视图名称', this.currentView) || '').trim();
+            if (!name) {
+                return;
+            }
+            const shared = confirm('是否共享给所有人？（取消则仅自己可见）');
+            const view = { ...this.currentViewSettings(), name, shared };
+            try {
+                try {
+                    await crudAxios.post(ConfigManager.getApiUrl(`/${this.configName}/views`), view);
+                } catch (error) {
+                    if (error.response?.status !== 409 || !confirm(`视图「${name}」已存在，是否覆盖？`)) {
+                        throw error;
+                    }
+                    await crudAxios.put(ConfigManager.getApiUrl(`/${this.configName}/views/${encodeURIComponent(name)}`), view);
+                }
+                await this.loadViews();
+                this.currentView = name;
+                const url = new URL(window.location.href);
+                url.searchParams.set('view', name);
+                window.history.replaceState(null, '', url.toString());
+            } catch (error) {
+                console.error('Failed to save view:', error);
+                alert('视图保存失败: ' + (error.response?.data?.error || error.message));
+            }
+        },
+        
+        async deleteView() {
+            if (!this.currentView || !confirm(`确定要删除视图「${this.currentView}」吗？`)) {
+                return;
+            }
+            try {
+                await crudAxios.delete(ConfigManager.getApiUrl(`/${this.configName}/views/${encodeURIComponent(this.currentView)}`));
+                await this.loadViews();
+                await this.switchView('');
+            } catch (error) {
+                console.error('Failed to delete view:', error);
+                alert('视图删除失败: ' + (error.response?.data?.error || error.message));
+            }
+        },
+        
         showCreateModal() {
             this.editingRecord = null;
+            this.activeTab = '';
             this.formData = {};
             // 为每个可编辑字段初始化空值
             this.editableFields.forEach(field => {
                 const fieldName = typeof field === 'string' ? field : field.field;
-                this.formData[fieldName] = '';
+                const inputType = this.getFieldType(field);
+                this.formData[fieldName] = inputType === 'json' ? {} : (inputType === 'tags' ? [] : '');
             });
             this.modal.show();
         },
         
         editRecord(record) {
-            this.editingRecord = record;
-            this.formData = { ...record };
-            this.modal.show();
+            return this.openRecord(this.recordKeySegment(record));
+        },
+        
+        // 按主键重新读取记录后打开编辑框，避免使用列表中过期的数据
+        async openRecord(key) {
+            try {
+                const response = await crudAxios.get(ConfigManager.getApiUrl(`/${this.configName}/get/${key}`));
+                const record = response.data.data;
+                this.editingRecord = record;
+                this.formData = { ...record };
+                this.activeTab = '';
+                this.modal.show();
+            } catch (error) {
+                console.error('Failed to load record:', error);
+                if (error.response?.status === 404) {
+                    alert('记录不存在或已被删除');
+                    await this.loadData();
+                } else {
+                    alert('加载记录失败: ' + (error.response?.data?.error || error.message));
+                }
+            }
+        },
+        
+        // 生成记录的主键路径段：联合主键的各列值分别编码后用逗号连接
+        recordKeySegment(record) {
+            if (this.primaryKey.length === 1) {
+                return encodeURIComponent(record[this.primaryKey[0]]);
+            }
+            return this.primaryKey.map(column => encodeURIComponent(record[column])).join(',');
+        },
+        
+        // 子表外键的取值：关系指定的父字段，默认为单列主键
+        relationParentValue(relation) {
+            const field = relation.parent_field || this.primaryKey[0];
+            return this.editingRecord ? this.editingRecord[field] : '';
         },
         
         async saveRecord() {
@@ -447,8 +1516,8 @@ createApp({
                 
                 if (this.editingRecord) {
                     // 更新记录
-                    const id = this.editingRecord.id;
-                    await crudAxios.put(ConfigManager.getApiUrl(`/${this.configName}/update/${id}`), this.formData);
+                    const key = this.recordKeySegment(this.editingRecord);
+                    await crudAxios.put(ConfigManager.getApiUrl(`/${this.configName}/update/${key}`), this.formData);
                 } else {
                     // 创建记录
                     await crudAxios.post(ConfigManager.getApiUrl(`/${this.configName}/create`), this.formData);
@@ -471,8 +1540,8 @@ createApp({
             }
             
             try {
-                const id = record.id;
-                await crudAxios.delete(ConfigManager.getApiUrl(`/${this.configName}/delete/${id}`));
+                const key = this.recordKeySegment(record);
+                await crudAxios.delete(ConfigManager.getApiUrl(`/${this.configName}/delete/${key}`));
                 await this.loadData();
             } catch (error) {
                 console.error('Failed to delete record:', error);
@@ -524,6 +1593,11 @@ createApp({
         // 清空多选字段
         clearMultiSelect(fieldName) {
             this.filters[fieldName] = [];
+        },
+        
+        // 多选和数组搜索的值是数组
+        isArrayFilter(field) {
+            return field.type === 'multi_select' || field.type === 'contains' || field.type === 'overlaps';
         }
     }
 }).mount('#app');
\ No newline at end of file